@@ -30,6 +30,8 @@ module "web_application" {
   ssl_certificate_arn = var.ssl_certificate_arn
   domain_name         = var.domain_name
 
+  enable_deletion_protection = var.enable_deletion_protection
+
   additional_tags = var.additional_tags
 }
 
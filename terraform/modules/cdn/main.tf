@@ -0,0 +1,184 @@
+# CDN Module
+# Private S3 static asset bucket served through CloudFront, with an optional
+# ALB custom origin for dynamic paths
+
+locals {
+  s3_origin_id  = "S3-${aws_s3_bucket.assets.bucket}"
+  alb_origin_id = "ALB-${var.project_name}-${var.environment}"
+}
+
+# AWS managed cache and origin request policies
+data "aws_cloudfront_cache_policy" "caching_optimized" {
+  name = "Managed-CachingOptimized"
+}
+
+data "aws_cloudfront_cache_policy" "caching_disabled" {
+  name = "Managed-CachingDisabled"
+}
+
+data "aws_cloudfront_origin_request_policy" "all_viewer_except_host_header" {
+  name = "Managed-AllViewerExceptHostHeader"
+}
+
+# Static asset bucket
+resource "aws_s3_bucket" "assets" {
+  bucket        = "${var.project_name}-${var.environment}-assets-${random_string.bucket_suffix.result}"
+  force_destroy = var.force_destroy
+
+  tags = merge(
+    {
+      Name        = "${var.project_name}-${var.environment}-assets"
+      Environment = var.environment
+      Module      = "cdn"
+    },
+    var.additional_tags
+  )
+}
+
+resource "aws_s3_bucket_public_access_block" "assets" {
+  bucket = aws_s3_bucket.assets.id
+
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+resource "aws_s3_bucket_server_side_encryption_configuration" "assets" {
+  bucket = aws_s3_bucket.assets.id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm = "AES256"
+    }
+  }
+}
+
+# Origin Access Control for CloudFront
+resource "aws_cloudfront_origin_access_control" "assets" {
+  name                              = "${var.project_name}-${var.environment}-assets-oac"
+  description                       = "OAC for ${var.project_name} static assets"
+  origin_access_control_origin_type = "s3"
+  signing_behavior                  = "always"
+  signing_protocol                  = "sigv4"
+}
+
+resource "aws_cloudfront_distribution" "main" {
+  enabled             = true
+  is_ipv6_enabled     = true
+  comment             = "CDN for ${var.project_name} ${var.environment}"
+  default_root_object = var.default_root_object
+  price_class         = var.price_class
+  aliases             = var.aliases
+
+  origin {
+    domain_name              = aws_s3_bucket.assets.bucket_regional_domain_name
+    origin_access_control_id = aws_cloudfront_origin_access_control.assets.id
+    origin_id                = local.s3_origin_id
+  }
+
+  # ALB custom origin (optional)
+  dynamic "origin" {
+    for_each = var.alb_origin_domain_name != null ? [1] : []
+    content {
+      domain_name = var.alb_origin_domain_name
+      origin_id   = local.alb_origin_id
+
+      custom_origin_config {
+        http_port              = 80
+        https_port             = 443
+        origin_protocol_policy = var.alb_origin_protocol_policy
+        origin_ssl_protocols   = ["TLSv1.2"]
+      }
+    }
+  }
+
+  default_cache_behavior {
+    allowed_methods        = ["GET", "HEAD", "OPTIONS"]
+    cached_methods         = ["GET", "HEAD"]
+    target_origin_id       = local.s3_origin_id
+    cache_policy_id        = data.aws_cloudfront_cache_policy.caching_optimized.id
+    viewer_protocol_policy = "redirect-to-https"
+    compress               = true
+  }
+
+  # Dynamic requests are forwarded to the ALB uncached
+  dynamic "ordered_cache_behavior" {
+    for_each = var.alb_origin_domain_name != null ? [1] : []
+    content {
+      path_pattern             = var.alb_path_pattern
+      allowed_methods          = ["DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PUT"]
+      cached_methods           = ["GET", "HEAD"]
+      target_origin_id         = local.alb_origin_id
+      cache_policy_id          = data.aws_cloudfront_cache_policy.caching_disabled.id
+      origin_request_policy_id = data.aws_cloudfront_origin_request_policy.all_viewer_except_host_header.id
+      viewer_protocol_policy   = "redirect-to-https"
+      compress                 = true
+    }
+  }
+
+  restrictions {
+    geo_restriction {
+      restriction_type = "none"
+    }
+  }
+
+  dynamic "viewer_certificate" {
+    for_each = var.acm_certificate_arn != null ? [1] : []
+    content {
+      acm_certificate_arn      = var.acm_certificate_arn
+      ssl_support_method       = "sni-only"
+      minimum_protocol_version = "TLSv1.2_2021"
+    }
+  }
+
+  dynamic "viewer_certificate" {
+    for_each = var.acm_certificate_arn == null ? [1] : []
+    content {
+      cloudfront_default_certificate = true
+    }
+  }
+
+  tags = merge(
+    {
+      Name        = "${var.project_name}-${var.environment}-cdn"
+      Environment = var.environment
+      Module      = "cdn"
+    },
+    var.additional_tags
+  )
+}
+
+# S3 bucket policy for CloudFront
+resource "aws_s3_bucket_policy" "assets" {
+  bucket = aws_s3_bucket.assets.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "AllowCloudFrontServicePrincipal"
+        Effect = "Allow"
+        Principal = {
+          Service = "cloudfront.amazonaws.com"
+        }
+        Action   = "s3:GetObject"
+        Resource = "${aws_s3_bucket.assets.arn}/*"
+        Condition = {
+          StringEquals = {
+            "AWS:SourceArn" = aws_cloudfront_distribution.main.arn
+          }
+        }
+      }
+    ]
+  })
+
+  depends_on = [aws_s3_bucket_public_access_block.assets]
+}
+
+# Random string for unique bucket names
+resource "random_string" "bucket_suffix" {
+  length  = 8
+  special = false
+  upper   = false
+}
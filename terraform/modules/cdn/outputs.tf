@@ -0,0 +1,31 @@
+# Outputs for CDN Module
+
+output "distribution_id" {
+  description = "ID of the CloudFront distribution"
+  value       = aws_cloudfront_distribution.main.id
+}
+
+output "distribution_arn" {
+  description = "ARN of the CloudFront distribution"
+  value       = aws_cloudfront_distribution.main.arn
+}
+
+output "cloudfront_domain_name" {
+  description = "Domain name of the CloudFront distribution"
+  value       = aws_cloudfront_distribution.main.domain_name
+}
+
+output "cloudfront_hosted_zone_id" {
+  description = "Route53 hosted zone ID for alias records pointing at the distribution"
+  value       = aws_cloudfront_distribution.main.hosted_zone_id
+}
+
+output "bucket_id" {
+  description = "Name of the static asset bucket"
+  value       = aws_s3_bucket.assets.id
+}
+
+output "bucket_arn" {
+  description = "ARN of the static asset bucket"
+  value       = aws_s3_bucket.assets.arn
+}
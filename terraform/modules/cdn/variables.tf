@@ -0,0 +1,91 @@
+# Variables for CDN Module
+
+variable "project_name" {
+  description = "Name of the project"
+  type        = string
+  validation {
+    condition     = length(var.project_name) > 0 && length(var.project_name) <= 30 && can(regex("^[a-z][a-z0-9-]*$", var.project_name))
+    error_message = "Project name must be 1-30 characters, start with a lowercase letter, and contain only lowercase letters, numbers, and hyphens."
+  }
+}
+
+variable "environment" {
+  description = "Environment name (staging, production)"
+  type        = string
+  validation {
+    condition     = contains(["staging", "production"], var.environment)
+    error_message = "Environment must be either 'staging' or 'production'."
+  }
+}
+
+variable "force_destroy" {
+  description = "Allow the asset bucket to be destroyed even when it contains objects"
+  type        = bool
+  default     = false
+}
+
+# Distribution Configuration
+variable "price_class" {
+  description = "CloudFront price class (PriceClass_100, PriceClass_200, PriceClass_All)"
+  type        = string
+  default     = "PriceClass_100"
+  validation {
+    condition     = contains(["PriceClass_100", "PriceClass_200", "PriceClass_All"], var.price_class)
+    error_message = "Price class must be one of: PriceClass_100, PriceClass_200, PriceClass_All."
+  }
+}
+
+variable "default_root_object" {
+  description = "Object returned when the root URL is requested"
+  type        = string
+  default     = "index.html"
+}
+
+variable "acm_certificate_arn" {
+  description = "ARN of an ACM certificate in us-east-1 covering the aliases"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.acm_certificate_arn == null || can(regex("^arn:aws:acm:us-east-1:[0-9]{12}:certificate/.+$", var.acm_certificate_arn))
+    error_message = "ACM certificate must be a valid certificate ARN in us-east-1."
+  }
+}
+
+variable "aliases" {
+  description = "Alternate domain names (CNAMEs) served by the distribution"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = length(var.aliases) == 0 || var.acm_certificate_arn != null
+    error_message = "An ACM certificate is required when aliases are set."
+  }
+}
+
+# ALB Origin Configuration
+variable "alb_origin_domain_name" {
+  description = "DNS name of the web-application ALB served for alb_path_pattern (null disables the ALB origin)"
+  type        = string
+  default     = null
+}
+
+variable "alb_path_pattern" {
+  description = "Path pattern routed to the ALB origin"
+  type        = string
+  default     = "/api/*"
+}
+
+variable "alb_origin_protocol_policy" {
+  description = "Protocol CloudFront uses to connect to the ALB (http-only, https-only, match-viewer)"
+  type        = string
+  default     = "https-only"
+  validation {
+    condition     = contains(["http-only", "https-only", "match-viewer"], var.alb_origin_protocol_policy)
+    error_message = "ALB origin protocol policy must be one of: http-only, https-only, match-viewer."
+  }
+}
+
+variable "additional_tags" {
+  description = "Additional tags to apply to resources"
+  type        = map(string)
+  default     = {}
+}
@@ -0,0 +1,17 @@
+# Terraform and Provider Version Constraints - CDN Module
+
+terraform {
+  required_version = ">= 1.13.3"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 6.14.0"
+    }
+
+    random = {
+      source  = "hashicorp/random"
+      version = "~> 3.6.0"
+    }
+  }
+}
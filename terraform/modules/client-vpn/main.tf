@@ -0,0 +1,137 @@
+# Client VPN Module
+# AWS Client VPN endpoint giving remote users access to a VPC without a bastion host
+
+locals {
+  name_prefix = "${var.project_name}-${var.environment}"
+
+  tags = merge(
+    {
+      Environment = var.environment
+      Module      = "client-vpn"
+    },
+    var.additional_tags
+  )
+
+  # Full-tunnel clients send all traffic through the VPN, so internet access needs a default route
+  # through each associated subnet and an authorization rule, unless the caller already authorizes it
+  authorize_internet = !var.split_tunnel && !contains(var.authorization_rules[*].target_network_cidr, "0.0.0.0/0")
+  authorization_rules = concat(
+    var.authorization_rules,
+    local.authorize_internet ? [{ target_network_cidr = "0.0.0.0/0", access_group_id = null, description = "Internet access for full-tunnel clients" }] : []
+  )
+}
+
+# Connection Logs
+resource "aws_cloudwatch_log_group" "connections" {
+  count = var.enable_connection_logs ? 1 : 0
+
+  name              = "/aws/client-vpn/${local.name_prefix}"
+  retention_in_days = var.log_retention_days
+
+  tags = merge(
+    local.tags,
+    {
+      Name = "${local.name_prefix}-client-vpn-logs"
+    }
+  )
+}
+
+resource "aws_cloudwatch_log_stream" "connections" {
+  count = var.enable_connection_logs ? 1 : 0
+
+  name           = "connections"
+  log_group_name = aws_cloudwatch_log_group.connections[0].name
+}
+
+# Security Group - applied to the endpoint's network interfaces in the associated subnets
+resource "aws_security_group" "client_vpn" {
+  name_prefix = "${local.name_prefix}-client-vpn-"
+  description = "Security group for the ${local.name_prefix} Client VPN endpoint"
+  vpc_id      = var.vpc_id
+
+  egress {
+    description = "All outbound traffic from VPN clients"
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  tags = merge(
+    local.tags,
+    {
+      Name = "${local.name_prefix}-client-vpn-sg"
+    }
+  )
+}
+
+# Client VPN Endpoint
+resource "aws_ec2_client_vpn_endpoint" "main" {
+  description            = "${local.name_prefix} Client VPN"
+  server_certificate_arn = var.server_certificate_arn
+  client_cidr_block      = var.client_cidr_block
+  split_tunnel           = var.split_tunnel
+  dns_servers            = var.dns_servers
+  transport_protocol     = var.transport_protocol
+  vpn_port               = var.vpn_port
+  session_timeout_hours  = var.session_timeout_hours
+  vpc_id                 = var.vpc_id
+  security_group_ids     = concat([aws_security_group.client_vpn.id], var.additional_security_group_ids)
+
+  authentication_options {
+    type                           = var.authentication.type
+    root_certificate_chain_arn     = var.authentication.root_certificate_chain_arn
+    saml_provider_arn              = var.authentication.saml_provider_arn
+    self_service_saml_provider_arn = var.authentication.self_service_saml_provider_arn
+    active_directory_id            = var.authentication.active_directory_id
+  }
+
+  connection_log_options {
+    enabled               = var.enable_connection_logs
+    cloudwatch_log_group  = var.enable_connection_logs ? aws_cloudwatch_log_group.connections[0].name : null
+    cloudwatch_log_stream = var.enable_connection_logs ? aws_cloudwatch_log_stream.connections[0].name : null
+  }
+
+  tags = merge(
+    local.tags,
+    {
+      Name = "${local.name_prefix}-client-vpn"
+    }
+  )
+}
+
+# Network Associations - one per subnet; each association is billed hourly
+resource "aws_ec2_client_vpn_network_association" "main" {
+  count = length(var.subnet_ids)
+
+  client_vpn_endpoint_id = aws_ec2_client_vpn_endpoint.main.id
+  subnet_id              = var.subnet_ids[count.index]
+}
+
+# Default Routes - full-tunnel internet traffic leaves through each associated subnet
+resource "aws_ec2_client_vpn_route" "internet" {
+  count = var.split_tunnel ? 0 : length(var.subnet_ids)
+
+  client_vpn_endpoint_id = aws_ec2_client_vpn_endpoint.main.id
+  destination_cidr_block = "0.0.0.0/0"
+  target_vpc_subnet_id   = aws_ec2_client_vpn_network_association.main[count.index].subnet_id
+  description            = "Internet access for full-tunnel clients"
+}
+
+# Authorization Rules - clients can only reach networks with a matching rule
+resource "aws_ec2_client_vpn_authorization_rule" "main" {
+  for_each = {
+    for rule in local.authorization_rules :
+    "${rule.target_network_cidr}-${rule.access_group_id != null ? rule.access_group_id : "all"}" => rule
+  }
+
+  client_vpn_endpoint_id = aws_ec2_client_vpn_endpoint.main.id
+  target_network_cidr    = each.value.target_network_cidr
+  access_group_id        = each.value.access_group_id
+  authorize_all_groups   = each.value.access_group_id == null ? true : null
+  description            = each.value.description
+}
@@ -0,0 +1,31 @@
+# Outputs for Client VPN Module
+
+output "client_vpn_endpoint_id" {
+  description = "ID of the Client VPN endpoint"
+  value       = aws_ec2_client_vpn_endpoint.main.id
+}
+
+output "client_vpn_endpoint_arn" {
+  description = "ARN of the Client VPN endpoint"
+  value       = aws_ec2_client_vpn_endpoint.main.arn
+}
+
+output "dns_name" {
+  description = "DNS name clients connect to"
+  value       = aws_ec2_client_vpn_endpoint.main.dns_name
+}
+
+output "security_group_id" {
+  description = "ID of the security group applied to the endpoint"
+  value       = aws_security_group.client_vpn.id
+}
+
+output "network_association_ids" {
+  description = "IDs of the subnet associations"
+  value       = aws_ec2_client_vpn_network_association.main[*].id
+}
+
+output "connection_log_group_name" {
+  description = "Name of the CloudWatch log group receiving connection logs (if enabled)"
+  value       = var.enable_connection_logs ? aws_cloudwatch_log_group.connections[0].name : null
+}
@@ -0,0 +1,174 @@
+# Variables for Client VPN Module
+
+variable "project_name" {
+  description = "Name of the project"
+  type        = string
+  validation {
+    condition     = length(var.project_name) > 0 && length(var.project_name) <= 50 && can(regex("^[a-zA-Z0-9-]+$", var.project_name))
+    error_message = "Project name must be 1-50 characters and contain only alphanumeric characters and hyphens."
+  }
+}
+
+variable "environment" {
+  description = "Environment name (shared, staging, production)"
+  type        = string
+  validation {
+    condition     = contains(["shared", "staging", "production"], var.environment)
+    error_message = "Environment must be one of: shared, staging, production."
+  }
+}
+
+# Network Configuration
+variable "vpc_id" {
+  description = "ID of the VPC the endpoint gives access to"
+  type        = string
+}
+
+variable "subnet_ids" {
+  description = "Subnets associated with the endpoint (one per availability zone for high availability)"
+  type        = list(string)
+  validation {
+    condition     = length(var.subnet_ids) >= 1
+    error_message = "At least one subnet ID must be provided."
+  }
+}
+
+variable "client_cidr_block" {
+  description = "IPv4 CIDR block client addresses are assigned from (between /12 and /22, must not overlap the VPC)"
+  type        = string
+  validation {
+    condition     = can(cidrnetmask(var.client_cidr_block)) && tonumber(split("/", var.client_cidr_block)[1]) >= 12 && tonumber(split("/", var.client_cidr_block)[1]) <= 22
+    error_message = "Client CIDR block must be a valid IPv4 CIDR block between /12 and /22."
+  }
+}
+
+variable "split_tunnel" {
+  description = "Only route traffic for the VPC through the VPN (client internet traffic stays local)"
+  type        = bool
+  default     = true
+}
+
+variable "dns_servers" {
+  description = "DNS servers pushed to clients (up to two; the client's DNS is used when empty)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = length(var.dns_servers) <= 2
+    error_message = "At most two DNS servers may be provided."
+  }
+}
+
+variable "additional_security_group_ids" {
+  description = "Additional security groups applied to the endpoint's network interfaces"
+  type        = list(string)
+  default     = []
+}
+
+# Endpoint Configuration
+variable "server_certificate_arn" {
+  description = "ARN of the ACM server certificate for the endpoint"
+  type        = string
+  validation {
+    condition     = can(regex("^arn:aws[a-z-]*:acm:[a-z0-9-]+:[0-9]{12}:certificate/", var.server_certificate_arn))
+    error_message = "Server certificate ARN must be an ACM certificate ARN."
+  }
+}
+
+variable "authentication" {
+  description = "Client authentication: certificate-authentication (root_certificate_chain_arn), federated-authentication (saml_provider_arn), or directory-service-authentication (active_directory_id)"
+  type = object({
+    type                           = string
+    root_certificate_chain_arn     = optional(string)
+    saml_provider_arn              = optional(string)
+    self_service_saml_provider_arn = optional(string)
+    active_directory_id            = optional(string)
+  })
+  validation {
+    condition     = contains(["certificate-authentication", "federated-authentication", "directory-service-authentication"], var.authentication.type)
+    error_message = "Authentication type must be one of: certificate-authentication, federated-authentication, directory-service-authentication."
+  }
+  validation {
+    condition = (
+      var.authentication.type == "certificate-authentication" ? var.authentication.root_certificate_chain_arn != null :
+      var.authentication.type == "federated-authentication" ? var.authentication.saml_provider_arn != null :
+      var.authentication.active_directory_id != null
+    )
+    error_message = "Certificate authentication requires root_certificate_chain_arn, federated authentication requires saml_provider_arn, and directory service authentication requires active_directory_id."
+  }
+}
+
+variable "authorization_rules" {
+  description = "Networks clients may reach; access_group_id limits a rule to one IdP or directory group (all users are authorized when null)"
+  type = list(object({
+    target_network_cidr = string
+    access_group_id     = optional(string)
+    description         = optional(string)
+  }))
+  validation {
+    condition     = length(var.authorization_rules) >= 1
+    error_message = "At least one authorization rule must be provided."
+  }
+  validation {
+    condition     = alltrue([for rule in var.authorization_rules : can(cidrnetmask(rule.target_network_cidr))])
+    error_message = "Authorization rule target networks must be valid IPv4 CIDR blocks."
+  }
+  validation {
+    condition = var.authentication.type != "certificate-authentication" || alltrue([
+      for rule in var.authorization_rules : rule.access_group_id == null
+    ])
+    error_message = "Authorization rules can only be limited to an access group with federated or directory service authentication."
+  }
+}
+
+variable "transport_protocol" {
+  description = "Transport protocol of the endpoint (udp or tcp)"
+  type        = string
+  default     = "udp"
+  validation {
+    condition     = contains(["udp", "tcp"], var.transport_protocol)
+    error_message = "Transport protocol must be one of: udp, tcp."
+  }
+}
+
+variable "vpn_port" {
+  description = "Port clients connect to (443 or 1194)"
+  type        = number
+  default     = 443
+  validation {
+    condition     = contains([443, 1194], var.vpn_port)
+    error_message = "VPN port must be one of: 443, 1194."
+  }
+}
+
+variable "session_timeout_hours" {
+  description = "Maximum VPN session duration in hours"
+  type        = number
+  default     = 24
+  validation {
+    condition     = contains([8, 10, 12, 24], var.session_timeout_hours)
+    error_message = "Session timeout must be one of: 8, 10, 12, 24 hours."
+  }
+}
+
+# Connection Logging
+variable "enable_connection_logs" {
+  description = "Log client connection attempts to a CloudWatch log group"
+  type        = bool
+  default     = true
+}
+
+variable "log_retention_days" {
+  description = "Retention period of the connection log group in days"
+  type        = number
+  default     = 30
+  validation {
+    condition     = contains([1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1827, 3653], var.log_retention_days)
+    error_message = "Log retention days must be a valid CloudWatch Logs retention period."
+  }
+}
+
+variable "additional_tags" {
+  description = "Additional tags to apply to resources"
+  type        = map(string)
+  default     = {}
+}
@@ -0,0 +1,64 @@
+# KMS Module
+# Shared customer-managed KMS key with an alias and grants for downstream services
+
+data "aws_caller_identity" "current" {}
+
+locals {
+  name_prefix = "${var.project_name}-${var.environment}"
+  alias_name  = var.alias_name != null ? var.alias_name : local.name_prefix
+
+  tags = merge(
+    {
+      Environment = var.environment
+      Module      = "kms"
+    },
+    var.additional_tags
+  )
+}
+
+# KMS Key
+# The account root statement delegates access to IAM policies; downstream
+# services are given access through grants rather than key policy entries
+resource "aws_kms_key" "main" {
+  description             = var.description != null ? var.description : "Shared KMS key for ${local.name_prefix}"
+  deletion_window_in_days = var.deletion_window_in_days
+  enable_key_rotation     = var.enable_key_rotation
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "Enable IAM User Permissions"
+        Effect = "Allow"
+        Principal = {
+          AWS = "arn:aws:iam::${data.aws_caller_identity.current.account_id}:root"
+        }
+        Action   = "kms:*"
+        Resource = "*"
+      }
+    ]
+  })
+
+  tags = merge(
+    local.tags,
+    {
+      Name = "${local.name_prefix}-key"
+    }
+  )
+}
+
+resource "aws_kms_alias" "main" {
+  name          = "alias/${local.alias_name}"
+  target_key_id = aws_kms_key.main.key_id
+}
+
+# KMS Grants
+resource "aws_kms_grant" "main" {
+  for_each = { for grant in var.grants : grant.name => grant }
+
+  name               = each.key
+  key_id             = aws_kms_key.main.key_id
+  grantee_principal  = each.value.grantee_principal
+  operations         = each.value.operations
+  retiring_principal = each.value.retiring_principal
+}
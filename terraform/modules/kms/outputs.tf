@@ -0,0 +1,26 @@
+# Outputs for KMS Module
+
+output "key_id" {
+  description = "ID of the KMS key"
+  value       = aws_kms_key.main.key_id
+}
+
+output "key_arn" {
+  description = "ARN of the KMS key"
+  value       = aws_kms_key.main.arn
+}
+
+output "alias_name" {
+  description = "Name of the KMS alias (including the alias/ prefix)"
+  value       = aws_kms_alias.main.name
+}
+
+output "alias_arn" {
+  description = "ARN of the KMS alias"
+  value       = aws_kms_alias.main.arn
+}
+
+output "grant_ids" {
+  description = "Map of grant name to grant ID"
+  value       = { for name, grant in aws_kms_grant.main : name => grant.grant_id }
+}
@@ -0,0 +1,92 @@
+# Variables for KMS Module
+
+variable "project_name" {
+  description = "Name of the project"
+  type        = string
+  validation {
+    condition     = length(var.project_name) > 0 && length(var.project_name) <= 50 && can(regex("^[a-zA-Z0-9-]+$", var.project_name))
+    error_message = "Project name must be 1-50 characters and contain only alphanumeric characters and hyphens."
+  }
+}
+
+variable "environment" {
+  description = "Environment name (shared, staging, production)"
+  type        = string
+  validation {
+    condition     = contains(["shared", "staging", "production"], var.environment)
+    error_message = "Environment must be one of: shared, staging, production."
+  }
+}
+
+# Key Configuration
+variable "description" {
+  description = "Description of the KMS key (defaults to \"Shared KMS key for <project_name>-<environment>\")"
+  type        = string
+  default     = null
+}
+
+variable "alias_name" {
+  description = "Alias name without the alias/ prefix (defaults to <project_name>-<environment>)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.alias_name == null || (can(regex("^[a-zA-Z0-9/_-]+$", var.alias_name)) && !startswith(coalesce(var.alias_name, "-"), "aws/"))
+    error_message = "Alias name must contain only letters, numbers, slashes, underscores, and hyphens, and must not start with aws/."
+  }
+}
+
+variable "deletion_window_in_days" {
+  description = "Days before a deleted key is permanently removed"
+  type        = number
+  default     = 30
+  validation {
+    condition     = var.deletion_window_in_days >= 7 && var.deletion_window_in_days <= 30
+    error_message = "Deletion window must be between 7 and 30 days."
+  }
+}
+
+variable "enable_key_rotation" {
+  description = "Enable automatic yearly key rotation"
+  type        = bool
+  default     = true
+}
+
+# Grant Configuration
+variable "grants" {
+  description = "Grants giving principals a set of operations on the key without key policy entries"
+  type = list(object({
+    name               = string
+    grantee_principal  = string
+    operations         = list(string)
+    retiring_principal = optional(string)
+  }))
+  default = []
+  validation {
+    condition     = length(distinct([for grant in var.grants : grant.name])) == length(var.grants)
+    error_message = "Grant names must be unique."
+  }
+  validation {
+    condition = alltrue([
+      for grant in var.grants : can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:", grant.grantee_principal))
+    ])
+    error_message = "Grant grantee principals must be IAM principal ARNs."
+  }
+  validation {
+    condition = alltrue([
+      for grant in var.grants : length(grant.operations) > 0 && alltrue([
+        for operation in grant.operations : contains([
+          "Decrypt", "Encrypt", "GenerateDataKey", "GenerateDataKeyWithoutPlaintext", "ReEncryptFrom", "ReEncryptTo",
+          "GenerateDataKeyPair", "GenerateDataKeyPairWithoutPlaintext", "CreateGrant", "RetireGrant", "DescribeKey",
+          "Sign", "Verify", "GetPublicKey", "GenerateMac", "VerifyMac", "DeriveSharedSecret"
+        ], operation)
+      ])
+    ])
+    error_message = "Each grant must list at least one valid KMS grant operation (e.g. Encrypt, Decrypt, GenerateDataKey)."
+  }
+}
+
+variable "additional_tags" {
+  description = "Additional tags to apply to resources"
+  type        = map(string)
+  default     = {}
+}
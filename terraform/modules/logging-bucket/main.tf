@@ -0,0 +1,179 @@
+# Logging Bucket Module
+# Hardened bucket for centralized S3, load balancer, CloudTrail, and VPC flow logs.
+# Logs expire after retention_days and each delivery service is allowed in by toggle.
+
+data "aws_caller_identity" "current" {}
+
+locals {
+  bucket_name = "${var.project_name}-${var.environment}-${var.bucket_name}"
+  account_id  = data.aws_caller_identity.current.account_id
+
+  # Every log delivery service writes with the bucket-owner-full-control ACL and is
+  # limited to deliveries on behalf of this account
+  log_delivery_conditions = {
+    StringEquals = {
+      "s3:x-amz-acl"      = "bucket-owner-full-control"
+      "aws:SourceAccount" = local.account_id
+    }
+  }
+
+  elb_statements = var.allow_elb_logs ? [
+    {
+      Sid    = "ELBLogDelivery"
+      Effect = "Allow"
+      Principal = merge(
+        { Service = "logdelivery.elasticloadbalancing.amazonaws.com" },
+        var.elb_service_account_arn != null ? { AWS = var.elb_service_account_arn } : {}
+      )
+      Action   = "s3:PutObject"
+      Resource = "${aws_s3_bucket.main.arn}/*"
+    }
+  ] : []
+
+  cloudtrail_statements = var.allow_cloudtrail_logs ? [
+    {
+      Sid       = "CloudTrailAclCheck"
+      Effect    = "Allow"
+      Principal = { Service = "cloudtrail.amazonaws.com" }
+      Action    = "s3:GetBucketAcl"
+      Resource  = aws_s3_bucket.main.arn
+      Condition = { StringEquals = { "aws:SourceAccount" = local.account_id } }
+    },
+    {
+      Sid       = "CloudTrailWrite"
+      Effect    = "Allow"
+      Principal = { Service = "cloudtrail.amazonaws.com" }
+      Action    = "s3:PutObject"
+      Resource  = "${aws_s3_bucket.main.arn}/*"
+      Condition = local.log_delivery_conditions
+    }
+  ] : []
+
+  flow_logs_statements = var.allow_flow_logs ? [
+    {
+      Sid       = "FlowLogsAclCheck"
+      Effect    = "Allow"
+      Principal = { Service = "delivery.logs.amazonaws.com" }
+      Action    = "s3:GetBucketAcl"
+      Resource  = aws_s3_bucket.main.arn
+      Condition = { StringEquals = { "aws:SourceAccount" = local.account_id } }
+    },
+    {
+      Sid       = "FlowLogsWrite"
+      Effect    = "Allow"
+      Principal = { Service = "delivery.logs.amazonaws.com" }
+      Action    = "s3:PutObject"
+      Resource  = "${aws_s3_bucket.main.arn}/*"
+      Condition = local.log_delivery_conditions
+    }
+  ] : []
+}
+
+resource "aws_s3_bucket" "main" {
+  bucket        = local.bucket_name
+  force_destroy = var.force_destroy
+
+  tags = merge(
+    {
+      Name        = local.bucket_name
+      Environment = var.environment
+      Module      = "logging-bucket"
+    },
+    var.additional_tags
+  )
+}
+
+resource "aws_s3_bucket_public_access_block" "main" {
+  bucket = aws_s3_bucket.main.id
+
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+resource "aws_s3_bucket_ownership_controls" "main" {
+  bucket = aws_s3_bucket.main.id
+
+  rule {
+    object_ownership = "BucketOwnerEnforced"
+  }
+}
+
+resource "aws_s3_bucket_versioning" "main" {
+  bucket = aws_s3_bucket.main.id
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+
+resource "aws_s3_bucket_server_side_encryption_configuration" "main" {
+  bucket = aws_s3_bucket.main.id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm     = var.kms_key_arn != null ? "aws:kms" : "AES256"
+      kms_master_key_id = var.kms_key_arn
+    }
+    bucket_key_enabled = var.kms_key_arn != null
+  }
+}
+
+# Expired logs become noncurrent versions, which are deleted after noncurrent_version_retention_days
+resource "aws_s3_bucket_lifecycle_configuration" "main" {
+  bucket = aws_s3_bucket.main.id
+
+  rule {
+    id     = "expire-logs"
+    status = "Enabled"
+
+    filter {}
+
+    expiration {
+      days = var.retention_days
+    }
+
+    noncurrent_version_expiration {
+      noncurrent_days = var.noncurrent_version_retention_days
+    }
+
+    abort_incomplete_multipart_upload {
+      days_after_initiation = 7
+    }
+  }
+
+  depends_on = [aws_s3_bucket_versioning.main]
+}
+
+resource "aws_s3_bucket_policy" "main" {
+  bucket = aws_s3_bucket.main.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = concat(
+      [
+        {
+          Sid       = "DenyInsecureTransport"
+          Effect    = "Deny"
+          Principal = "*"
+          Action    = "s3:*"
+          Resource = [
+            aws_s3_bucket.main.arn,
+            "${aws_s3_bucket.main.arn}/*"
+          ]
+          Condition = {
+            Bool = {
+              "aws:SecureTransport" = "false"
+            }
+          }
+        }
+      ],
+      local.elb_statements,
+      local.cloudtrail_statements,
+      local.flow_logs_statements
+    )
+  })
+
+  # A policy applied before the public access block can be rejected as public
+  depends_on = [aws_s3_bucket_public_access_block.main]
+}
@@ -0,0 +1,16 @@
+# Outputs for Logging Bucket Module
+
+output "bucket_name" {
+  description = "Name of the logging bucket"
+  value       = aws_s3_bucket.main.id
+}
+
+output "bucket_arn" {
+  description = "ARN of the logging bucket"
+  value       = aws_s3_bucket.main.arn
+}
+
+output "retention_days" {
+  description = "Days after which log objects expire"
+  value       = var.retention_days
+}
@@ -0,0 +1,112 @@
+# Variables for Logging Bucket Module
+
+variable "project_name" {
+  description = "Name of the project"
+  type        = string
+  validation {
+    condition     = length(var.project_name) > 0 && length(var.project_name) <= 30 && can(regex("^[a-z][a-z0-9-]*$", var.project_name))
+    error_message = "Project name must be 1-30 characters, start with a lowercase letter, and contain only lowercase letters, numbers, and hyphens."
+  }
+}
+
+variable "environment" {
+  description = "Environment name (shared, staging, production)"
+  type        = string
+  validation {
+    condition     = contains(["shared", "staging", "production"], var.environment)
+    error_message = "Environment must be one of: shared, staging, production."
+  }
+}
+
+variable "bucket_name" {
+  description = "Bucket name suffix; the full name is <project_name>-<environment>-<bucket_name>"
+  type        = string
+  default     = "logs"
+  validation {
+    condition     = length(var.bucket_name) > 0 && length(var.bucket_name) <= 30 && can(regex("^[a-z0-9][a-z0-9-]*[a-z0-9]$", var.bucket_name))
+    error_message = "Bucket name must be 2-30 characters of lowercase letters, numbers, and hyphens, and must not start or end with a hyphen."
+  }
+  # S3 bucket names are limited to 63 characters
+  validation {
+    condition     = length("${var.project_name}-${var.environment}-${var.bucket_name}") <= 63
+    error_message = "The full bucket name (<project_name>-<environment>-<bucket_name>) must be at most 63 characters."
+  }
+}
+
+variable "force_destroy" {
+  description = "Allow the bucket to be destroyed even when it contains logs"
+  type        = bool
+  default     = false
+}
+
+# Retention
+variable "retention_days" {
+  description = "Days after which log objects expire"
+  type        = number
+  default     = 90
+  validation {
+    condition     = var.retention_days >= 1
+    error_message = "Retention days must be at least 1."
+  }
+}
+
+variable "noncurrent_version_retention_days" {
+  description = "Days after which expired or overwritten log versions are permanently deleted"
+  type        = number
+  default     = 30
+  validation {
+    condition     = var.noncurrent_version_retention_days >= 1
+    error_message = "Noncurrent version retention days must be at least 1."
+  }
+}
+
+# Encryption
+variable "kms_key_arn" {
+  description = "KMS key ARN for server-side encryption (null uses S3-managed AES256 keys); the key policy must allow each log delivery service"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.kms_key_arn == null || can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/[a-zA-Z0-9-]+$", var.kms_key_arn))
+    error_message = "KMS key ARN must be a KMS key ARN (arn:aws:kms:region:account-id:key/key-id)."
+  }
+  # Load balancer access logs can only be delivered to SSE-S3 buckets
+  validation {
+    condition     = var.kms_key_arn == null || !var.allow_elb_logs
+    error_message = "ELB access logs require S3-managed encryption; leave kms_key_arn unset when allow_elb_logs is true."
+  }
+}
+
+# Log Delivery
+variable "allow_elb_logs" {
+  description = "Allow Elastic Load Balancing to deliver access logs to the bucket"
+  type        = bool
+  default     = false
+}
+
+variable "elb_service_account_arn" {
+  description = "ARN of the regional ELB account that delivers access logs in regions launched before August 2022 (e.g. data.aws_elb_service_account.main.arn); newer regions use the log delivery service principal"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.elb_service_account_arn == null || can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:root$", var.elb_service_account_arn))
+    error_message = "ELB service account ARN must be an account root ARN (arn:aws:iam::account-id:root)."
+  }
+}
+
+variable "allow_cloudtrail_logs" {
+  description = "Allow CloudTrail trails in this account to deliver logs to the bucket"
+  type        = bool
+  default     = false
+}
+
+variable "allow_flow_logs" {
+  description = "Allow VPC flow logs in this account to deliver logs to the bucket"
+  type        = bool
+  default     = false
+}
+
+variable "additional_tags" {
+  description = "Additional tags to apply to resources"
+  type        = map(string)
+  default     = {}
+}
@@ -0,0 +1,278 @@
+# NAT Instance Module
+# Creates a self-healing NAT instance (single-instance Auto Scaling Group) behind a
+# fixed network interface, as a low-cost alternative to NAT Gateways. The private
+# route tables route through the interface, so replacements never touch routes.
+
+data "aws_ami" "amazon_linux" {
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["amzn2-ami-hvm-*-x86_64-gp2"]
+  }
+}
+
+data "aws_region" "current" {}
+
+# Security Group
+resource "aws_security_group" "nat" {
+  name_prefix = "${var.project_name}-${var.environment}-nat-"
+  description = "Security group for the NAT instance"
+  vpc_id      = var.vpc_id
+
+  ingress {
+    description = "All traffic from the VPC"
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = [var.vpc_cidr]
+  }
+
+  egress {
+    description = "All outbound traffic"
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  tags = merge(
+    {
+      Name        = "${var.project_name}-${var.environment}-nat-sg"
+      Environment = var.environment
+      Module      = "nat-instance"
+    },
+    var.additional_tags
+  )
+}
+
+# Network Interface - outlives the instances; each replacement attaches it at boot.
+# shared-networking routes the private subnets' default route at this interface
+# (nat_instance_network_interface_id), so the route is managed in Terraform.
+resource "aws_network_interface" "nat" {
+  subnet_id         = var.public_subnet_id
+  security_groups   = [aws_security_group.nat.id]
+  source_dest_check = false
+  description       = "Stable NAT interface for ${var.project_name}-${var.environment}"
+
+  tags = merge(
+    {
+      Name        = "${var.project_name}-${var.environment}-nat-eni"
+      Environment = var.environment
+      Module      = "nat-instance"
+    },
+    var.additional_tags
+  )
+}
+
+# IAM Role - allows the instance to disable source/dest check and attach the NAT interface on replacement
+resource "aws_iam_role" "nat" {
+  name_prefix = "${var.project_name}-${var.environment}-nat-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "ec2.amazonaws.com"
+        }
+      }
+    ]
+  })
+
+  tags = merge(
+    {
+      Name        = "${var.project_name}-${var.environment}-nat-role"
+      Environment = var.environment
+      Module      = "nat-instance"
+    },
+    var.additional_tags
+  )
+}
+
+resource "aws_iam_role_policy" "nat" {
+  name_prefix = "${var.project_name}-${var.environment}-nat-"
+  role        = aws_iam_role.nat.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = [
+          "ec2:ModifyInstanceAttribute"
+        ]
+        Effect   = "Allow"
+        Resource = "*"
+      },
+      {
+        Action = [
+          "ec2:AttachNetworkInterface"
+        ]
+        Effect = "Allow"
+        Resource = [
+          aws_network_interface.nat.arn,
+          "arn:aws:ec2:${data.aws_region.current.id}:*:instance/*"
+        ]
+      }
+    ]
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "nat_ssm" {
+  role       = aws_iam_role.nat.name
+  policy_arn = "arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"
+}
+
+resource "aws_iam_instance_profile" "nat" {
+  name_prefix = "${var.project_name}-${var.environment}-nat-"
+  role        = aws_iam_role.nat.name
+
+  tags = merge(
+    {
+      Name        = "${var.project_name}-${var.environment}-nat-profile"
+      Environment = var.environment
+      Module      = "nat-instance"
+    },
+    var.additional_tags
+  )
+}
+
+# Launch Template
+resource "aws_launch_template" "nat" {
+  name_prefix   = "${var.project_name}-${var.environment}-nat-"
+  image_id      = var.ami_id != null ? var.ami_id : data.aws_ami.amazon_linux.id
+  instance_type = var.instance_type
+  key_name      = var.key_pair_name
+
+  network_interfaces {
+    associate_public_ip_address = true
+    security_groups             = [aws_security_group.nat.id]
+    delete_on_termination       = true
+  }
+
+  iam_instance_profile {
+    name = aws_iam_instance_profile.nat.name
+  }
+
+  user_data = base64encode(templatefile("${path.module}/user_data.sh", {
+    region               = data.aws_region.current.id
+    vpc_cidr             = var.vpc_cidr
+    network_interface_id = aws_network_interface.nat.id
+  }))
+
+  metadata_options {
+    http_endpoint               = "enabled"
+    http_tokens                 = "required"
+    http_put_response_hop_limit = 1
+  }
+
+  monitoring {
+    enabled = true
+  }
+
+  tag_specifications {
+    resource_type = "instance"
+    tags = merge(
+      {
+        Name        = "${var.project_name}-${var.environment}-nat"
+        Environment = var.environment
+        Module      = "nat-instance"
+      },
+      var.additional_tags
+    )
+  }
+
+  tags = merge(
+    {
+      Name        = "${var.project_name}-${var.environment}-nat-template"
+      Environment = var.environment
+      Module      = "nat-instance"
+    },
+    var.additional_tags
+  )
+}
+
+# Auto Scaling Group - a fixed size of 1 replaces the NAT instance whenever it becomes impaired.
+# Limited to the interface's subnet because a network interface can only attach in its own AZ.
+resource "aws_autoscaling_group" "nat" {
+  name                      = "${var.project_name}-${var.environment}-nat-asg"
+  vpc_zone_identifier       = [var.public_subnet_id]
+  health_check_type         = "EC2"
+  health_check_grace_period = 60
+
+  min_size         = 1
+  max_size         = 1
+  desired_capacity = 1
+
+  launch_template {
+    id      = aws_launch_template.nat.id
+    version = "$Latest"
+  }
+
+  instance_refresh {
+    strategy = "Rolling"
+    preferences {
+      min_healthy_percentage = 0
+    }
+  }
+
+  tag {
+    key                 = "Name"
+    value               = "${var.project_name}-${var.environment}-nat-asg"
+    propagate_at_launch = false
+  }
+
+  tag {
+    key                 = "Environment"
+    value               = var.environment
+    propagate_at_launch = true
+  }
+
+  tag {
+    key                 = "Module"
+    value               = "nat-instance"
+    propagate_at_launch = true
+  }
+
+  depends_on = [aws_iam_role_policy.nat]
+}
+
+# Recovery Alarm - notification only. The Auto Scaling Group's EC2 health check
+# replaces an impaired instance in place of the EC2 recover action (see the README)
+# and the replacement attaches the NAT interface at boot; this alarm tells operators
+# that a replacement is underway.
+resource "aws_cloudwatch_metric_alarm" "nat_recovery" {
+  count = var.enable_recovery_alarm ? 1 : 0
+
+  alarm_name          = "${var.project_name}-${var.environment}-nat-recovery"
+  comparison_operator = "GreaterThanThreshold"
+  evaluation_periods  = var.recovery_alarm_evaluation_periods
+  metric_name         = "StatusCheckFailed_System"
+  namespace           = "AWS/EC2"
+  period              = 60
+  statistic           = "Maximum"
+  threshold           = 0
+  alarm_description   = "NAT instance failed its system status check; the Auto Scaling Group replaces it (notification only)"
+  alarm_actions       = var.alarm_actions
+  ok_actions          = var.alarm_actions
+
+  dimensions = {
+    AutoScalingGroupName = aws_autoscaling_group.nat.name
+  }
+
+  tags = merge(
+    {
+      Name        = "${var.project_name}-${var.environment}-nat-recovery"
+      Environment = var.environment
+      Module      = "nat-instance"
+    },
+    var.additional_tags
+  )
+}
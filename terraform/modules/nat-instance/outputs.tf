@@ -0,0 +1,42 @@
+# Outputs for NAT Instance Module
+
+# Auto Scaling Group
+output "autoscaling_group_name" {
+  description = "Name of the NAT instance Auto Scaling Group"
+  value       = aws_autoscaling_group.nat.name
+}
+
+output "autoscaling_group_arn" {
+  description = "ARN of the NAT instance Auto Scaling Group"
+  value       = aws_autoscaling_group.nat.arn
+}
+
+# Launch Template
+output "launch_template_id" {
+  description = "ID of the NAT instance Launch Template"
+  value       = aws_launch_template.nat.id
+}
+
+# Network Interface
+output "network_interface_id" {
+  description = "ID of the fixed NAT network interface to route private subnets through (shared-networking nat_instance_network_interface_id)"
+  value       = aws_network_interface.nat.id
+}
+
+# Security Group
+output "security_group_id" {
+  description = "ID of the NAT instance security group"
+  value       = aws_security_group.nat.id
+}
+
+# IAM
+output "iam_role_arn" {
+  description = "ARN of the NAT instance IAM role"
+  value       = aws_iam_role.nat.arn
+}
+
+# Recovery Alarm
+output "recovery_alarm_arn" {
+  description = "ARN of the NAT instance system status check notification alarm"
+  value       = var.enable_recovery_alarm ? aws_cloudwatch_metric_alarm.nat_recovery[0].arn : null
+}
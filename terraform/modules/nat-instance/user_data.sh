@@ -0,0 +1,57 @@
+#!/bin/bash
+# User data script for the NAT instance
+# Runs on every replacement launched by the Auto Scaling Group. The private
+# route tables point at the fixed NAT network interface, so the replacement
+# only has to attach that interface; routes are never modified here.
+
+set -euo pipefail
+
+REGION="${region}"
+NETWORK_INTERFACE_ID="${network_interface_id}"
+
+# Instance metadata (IMDSv2)
+TOKEN=$(curl -s -X PUT "http://169.254.169.254/latest/api/token" -H "X-aws-ec2-metadata-token-ttl-seconds: 300")
+INSTANCE_ID=$(curl -s -H "X-aws-ec2-metadata-token: $TOKEN" http://169.254.169.254/latest/meta-data/instance-id)
+
+# Enable IP forwarding. Private traffic arrives on the NAT interface but leaves
+# through the primary interface, so reverse path filtering must be loose.
+cat > /etc/sysctl.d/90-nat.conf <<'SYSCTL'
+net.ipv4.ip_forward = 1
+net.ipv4.conf.all.rp_filter = 2
+net.ipv4.conf.default.rp_filter = 2
+SYSCTL
+sysctl -p /etc/sysctl.d/90-nat.conf
+
+yum install -y iptables-services
+systemctl enable iptables
+systemctl start iptables
+
+PRIMARY_INTERFACE=$(ip route show default | awk '{print $5}' | head -n 1)
+iptables -t nat -A POSTROUTING -o "$PRIMARY_INTERFACE" -s "${vpc_cidr}" -j MASQUERADE
+iptables -F FORWARD
+service iptables save
+
+# A NAT instance must forward traffic it did not originate; the NAT interface
+# already has its source/destination check disabled
+aws ec2 modify-instance-attribute \
+  --region "$REGION" \
+  --instance-id "$INSTANCE_ID" \
+  --no-source-dest-check
+
+# Attach the NAT interface. It stays attached to the previous instance until
+# that instance finishes terminating, so keep retrying for a few minutes.
+for ATTEMPT in $(seq 1 30); do
+  if aws ec2 attach-network-interface \
+    --region "$REGION" \
+    --network-interface-id "$NETWORK_INTERFACE_ID" \
+    --instance-id "$INSTANCE_ID" \
+    --device-index 1; then
+    break
+  fi
+
+  if [ "$ATTEMPT" -eq 30 ]; then
+    echo "Failed to attach $NETWORK_INTERFACE_ID" >&2
+    exit 1
+  fi
+  sleep 10
+done
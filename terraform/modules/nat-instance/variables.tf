@@ -0,0 +1,94 @@
+# Variables for NAT Instance Module
+
+variable "project_name" {
+  description = "Name of the project"
+  type        = string
+  validation {
+    condition     = length(var.project_name) > 0 && length(var.project_name) <= 50 && can(regex("^[a-zA-Z][a-zA-Z0-9-]*$", var.project_name))
+    error_message = "Project name must be 1-50 characters, start with a letter, and contain only letters, numbers, and hyphens."
+  }
+}
+
+variable "environment" {
+  description = "Environment name (shared, staging, production)"
+  type        = string
+  validation {
+    condition     = contains(["shared", "staging", "production"], var.environment)
+    error_message = "Environment must be one of: shared, staging, production."
+  }
+}
+
+variable "vpc_id" {
+  description = "ID of the VPC"
+  type        = string
+}
+
+variable "vpc_cidr" {
+  description = "CIDR block of the VPC (traffic from this range is allowed through the NAT instance)"
+  type        = string
+  validation {
+    condition     = can(cidrhost(var.vpc_cidr, 0))
+    error_message = "VPC CIDR must be a valid CIDR block."
+  }
+}
+
+variable "public_subnet_id" {
+  description = "Public subnet for the NAT network interface; the Auto Scaling Group launches into the same subnet so replacements can attach it"
+  type        = string
+  validation {
+    condition     = startswith(var.public_subnet_id, "subnet-")
+    error_message = "Public subnet ID must be a valid subnet ID."
+  }
+}
+
+# Instance Configuration
+variable "ami_id" {
+  description = "AMI ID for the NAT instance (defaults to latest Amazon Linux 2)"
+  type        = string
+  default     = null
+}
+
+variable "instance_type" {
+  description = "EC2 instance type for the NAT instance"
+  type        = string
+  default     = "t3.nano"
+  validation {
+    condition     = can(regex("^[a-z][0-9][a-z]?\\.(nano|micro|small|medium|large|xlarge|[0-9]+xlarge)$", var.instance_type))
+    error_message = "Instance type must be a valid EC2 instance type (e.g., t3.nano, c5.large)."
+  }
+}
+
+variable "key_pair_name" {
+  description = "Name of the EC2 Key Pair for SSH access"
+  type        = string
+  default     = null
+}
+
+# Recovery Alarm Configuration
+variable "enable_recovery_alarm" {
+  description = "Create a notification alarm on StatusCheckFailed_System for the NAT instance (the Auto Scaling Group performs the replacement)"
+  type        = bool
+  default     = true
+}
+
+variable "recovery_alarm_evaluation_periods" {
+  description = "Number of consecutive failed one-minute system status checks before the recovery alarm fires"
+  type        = number
+  default     = 2
+  validation {
+    condition     = var.recovery_alarm_evaluation_periods >= 1 && var.recovery_alarm_evaluation_periods <= 10
+    error_message = "Recovery alarm evaluation periods must be between 1 and 10."
+  }
+}
+
+variable "alarm_actions" {
+  description = "List of ARNs (e.g., SNS topics) to notify when the recovery alarm changes state"
+  type        = list(string)
+  default     = []
+}
+
+variable "additional_tags" {
+  description = "Additional tags to apply to resources"
+  type        = map(string)
+  default     = {}
+}
@@ -0,0 +1,12 @@
+# Terraform and Provider Version Constraints - NAT Instance Module
+
+terraform {
+  required_version = ">= 1.13.3"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 6.14.0"
+    }
+  }
+}
@@ -0,0 +1,47 @@
+#!/usr/bin/env python3
+"""
+Database Connectivity Canary Lambda Function
+
+This function runs inside the VPC on a schedule and checks that the database
+accepts TCP connections on its port. The result is published as the
+DatabaseReachable metric (1 reachable, 0 unreachable) using the CloudWatch
+embedded metric format, so the function needs no route to the CloudWatch API.
+"""
+
+import json
+import os
+import socket
+import time
+from typing import Any, Dict
+
+DB_HOST = os.environ["DB_HOST"]
+DB_PORT = int(os.environ["DB_PORT"])
+DB_INSTANCE_IDENTIFIER = os.environ["DB_INSTANCE_IDENTIFIER"]
+CONNECT_TIMEOUT_SECONDS = 5
+
+
+def handler(event: Dict[str, Any], context: Any) -> Dict[str, Any]:
+    reachable = 1
+    try:
+        with socket.create_connection((DB_HOST, DB_PORT), timeout=CONNECT_TIMEOUT_SECONDS):
+            pass
+    except OSError as error:
+        reachable = 0
+        print(f"Database {DB_HOST}:{DB_PORT} is not reachable: {error}")
+
+    print(json.dumps({
+        "_aws": {
+            "Timestamp": int(time.time() * 1000),
+            "CloudWatchMetrics": [
+                {
+                    "Namespace": "${namespace}",
+                    "Dimensions": [["DBInstanceIdentifier"]],
+                    "Metrics": [{"Name": "DatabaseReachable", "Unit": "Count"}],
+                }
+            ],
+        },
+        "DBInstanceIdentifier": DB_INSTANCE_IDENTIFIER,
+        "DatabaseReachable": reachable,
+    }))
+
+    return {"reachable": reachable == 1}
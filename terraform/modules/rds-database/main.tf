@@ -0,0 +1,247 @@
+# RDS Database Module
+# Encrypted RDS instance with an RDS-managed master password, optional read replicas,
+# and an optional in-VPC connectivity canary
+
+locals {
+  identifier = lower("${var.project_name}-${var.environment}")
+  port       = var.port != null ? var.port : (var.engine == "postgres" ? 5432 : 3306)
+
+  replica_count = var.create_read_replica ? var.replica_count : 0
+
+  canary_name       = "${local.identifier}-db-canary"
+  canary_namespace  = "EPiC/Database"
+  canary_subnet_ids = length(var.canary_subnet_ids) > 0 ? var.canary_subnet_ids : (var.enable_canary ? tolist(data.aws_db_subnet_group.main[0].subnet_ids) : [])
+
+  tags = merge(
+    {
+      Environment = var.environment
+      Module      = "rds-database"
+    },
+    var.additional_tags
+  )
+}
+
+# Primary Instance
+# The master password is generated by RDS and kept in Secrets Manager, so it
+# never appears in the Terraform state
+resource "aws_db_instance" "main" {
+  identifier     = local.identifier
+  engine         = var.engine
+  engine_version = var.engine_version
+  instance_class = var.instance_class
+
+  allocated_storage     = var.allocated_storage
+  max_allocated_storage = var.max_allocated_storage > 0 ? var.max_allocated_storage : null
+  storage_type          = "gp3"
+  storage_encrypted     = true
+  kms_key_id            = var.kms_key_arn
+
+  db_name                     = var.database_name
+  username                    = var.master_username
+  manage_master_user_password = true
+  port                        = local.port
+
+  db_subnet_group_name   = var.db_subnet_group_name
+  vpc_security_group_ids = var.vpc_security_group_ids
+  publicly_accessible    = false
+  multi_az               = var.multi_az
+
+  backup_retention_period    = var.backup_retention_period
+  copy_tags_to_snapshot      = true
+  auto_minor_version_upgrade = true
+  deletion_protection        = var.deletion_protection
+  skip_final_snapshot        = var.skip_final_snapshot
+  final_snapshot_identifier  = var.skip_final_snapshot ? null : "${local.identifier}-final"
+
+  tags = merge(local.tags, {
+    Name = local.identifier
+  })
+}
+
+# Read Replicas
+# Replicas in the same region inherit the subnet group, storage encryption,
+# and engine version from the primary
+resource "aws_db_instance" "replica" {
+  count = local.replica_count
+
+  identifier          = "${local.identifier}-replica-${count.index + 1}"
+  replicate_source_db = aws_db_instance.main.identifier
+  instance_class      = coalesce(var.replica_instance_class, var.instance_class)
+
+  max_allocated_storage  = var.max_allocated_storage > 0 ? var.max_allocated_storage : null
+  storage_type           = "gp3"
+  port                   = local.port
+  vpc_security_group_ids = var.vpc_security_group_ids
+  publicly_accessible    = false
+
+  auto_minor_version_upgrade = true
+  skip_final_snapshot        = true
+
+  tags = merge(local.tags, {
+    Name = "${local.identifier}-replica-${count.index + 1}"
+    Role = "read-replica"
+  })
+
+  lifecycle {
+    precondition {
+      condition     = length("${local.identifier}-replica-${local.replica_count}") <= 63
+      error_message = "Replica identifiers must be at most 63 characters; shorten project_name to create read replicas."
+    }
+  }
+}
+
+# Connectivity Canary
+# A scheduled Lambda in the VPC opens a TCP connection to the primary and
+# writes the DatabaseReachable metric as an embedded metric format log line,
+# which works from database subnets without a route to the CloudWatch API
+data "aws_db_subnet_group" "main" {
+  count = var.enable_canary && length(var.canary_subnet_ids) == 0 ? 1 : 0
+
+  name = var.db_subnet_group_name
+}
+
+data "archive_file" "canary" {
+  count = var.enable_canary ? 1 : 0
+
+  type        = "zip"
+  output_path = "/tmp/${local.canary_name}.zip"
+  source {
+    content = templatefile("${path.module}/lambda/db_canary.py", {
+      namespace = local.canary_namespace
+    })
+    filename = "index.py"
+  }
+}
+
+resource "aws_iam_role" "canary" {
+  count = var.enable_canary ? 1 : 0
+
+  name = "${local.canary_name}-role"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "lambda.amazonaws.com"
+        }
+      }
+    ]
+  })
+
+  tags = merge(local.tags, {
+    Name = "${local.canary_name}-role"
+  })
+}
+
+resource "aws_iam_role_policy_attachment" "canary_vpc_access" {
+  count = var.enable_canary ? 1 : 0
+
+  role       = aws_iam_role.canary[0].name
+  policy_arn = "arn:aws:iam::aws:policy/service-role/AWSLambdaVPCAccessExecutionRole"
+}
+
+resource "aws_cloudwatch_log_group" "canary" {
+  count = var.enable_canary ? 1 : 0
+
+  name              = "/aws/lambda/${local.canary_name}"
+  retention_in_days = var.canary_log_retention_days
+
+  tags = merge(local.tags, {
+    Name = "${local.canary_name}-logs"
+  })
+}
+
+resource "aws_lambda_function" "canary" {
+  count = var.enable_canary ? 1 : 0
+
+  filename         = data.archive_file.canary[0].output_path
+  function_name    = local.canary_name
+  role             = aws_iam_role.canary[0].arn
+  handler          = "index.handler"
+  runtime          = "python3.11"
+  timeout          = 15
+  source_code_hash = data.archive_file.canary[0].output_base64sha256
+
+  vpc_config {
+    subnet_ids         = local.canary_subnet_ids
+    security_group_ids = var.canary_security_group_ids
+  }
+
+  environment {
+    variables = {
+      DB_HOST                = aws_db_instance.main.address
+      DB_PORT                = tostring(aws_db_instance.main.port)
+      DB_INSTANCE_IDENTIFIER = aws_db_instance.main.identifier
+    }
+  }
+
+  tags = merge(local.tags, {
+    Name = local.canary_name
+  })
+
+  # The log group must exist before the first run so its retention applies
+  depends_on = [
+    aws_iam_role_policy_attachment.canary_vpc_access,
+    aws_cloudwatch_log_group.canary
+  ]
+}
+
+resource "aws_cloudwatch_event_rule" "canary" {
+  count = var.enable_canary ? 1 : 0
+
+  name                = "${local.canary_name}-schedule"
+  description         = "Trigger the database connectivity canary"
+  schedule_expression = var.canary_interval_minutes == 1 ? "rate(1 minute)" : "rate(${var.canary_interval_minutes} minutes)"
+
+  tags = merge(local.tags, {
+    Name = "${local.canary_name}-schedule"
+  })
+}
+
+resource "aws_cloudwatch_event_target" "canary" {
+  count = var.enable_canary ? 1 : 0
+
+  rule      = aws_cloudwatch_event_rule.canary[0].name
+  target_id = "DbCanaryLambdaTarget"
+  arn       = aws_lambda_function.canary[0].arn
+}
+
+resource "aws_lambda_permission" "canary" {
+  count = var.enable_canary ? 1 : 0
+
+  statement_id  = "AllowExecutionFromEventBridge"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.canary[0].function_name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.canary[0].arn
+}
+
+# Missing datapoints mean the canary did not run or could not report, which is
+# treated as unreachable so a broken canary does not hide an outage
+resource "aws_cloudwatch_metric_alarm" "database_reachable" {
+  count = var.enable_canary ? 1 : 0
+
+  alarm_name          = "${local.identifier}-db-unreachable"
+  comparison_operator = "LessThanThreshold"
+  evaluation_periods  = var.canary_alarm_evaluation_periods
+  metric_name         = "DatabaseReachable"
+  namespace           = local.canary_namespace
+  period              = var.canary_interval_minutes * 60
+  statistic           = "Minimum"
+  threshold           = 1
+  treat_missing_data  = "breaching"
+  alarm_description   = "Database connectivity canary could not connect to ${aws_db_instance.main.identifier}"
+  alarm_actions       = var.alarm_actions
+  ok_actions          = var.alarm_actions
+
+  dimensions = {
+    DBInstanceIdentifier = aws_db_instance.main.identifier
+  }
+
+  tags = merge(local.tags, {
+    Name = "${local.identifier}-db-unreachable"
+  })
+}
@@ -0,0 +1,58 @@
+# Outputs for RDS Database Module
+
+output "db_instance_identifier" {
+  description = "Identifier of the primary instance"
+  value       = aws_db_instance.main.identifier
+}
+
+output "db_instance_arn" {
+  description = "ARN of the primary instance"
+  value       = aws_db_instance.main.arn
+}
+
+output "db_instance_endpoint" {
+  description = "Connection endpoint (address:port) of the primary instance"
+  value       = aws_db_instance.main.endpoint
+}
+
+output "db_instance_address" {
+  description = "Hostname of the primary instance"
+  value       = aws_db_instance.main.address
+}
+
+output "db_instance_port" {
+  description = "Port the database listens on"
+  value       = aws_db_instance.main.port
+}
+
+output "master_user_secret_arn" {
+  description = "ARN of the Secrets Manager secret holding the master credentials"
+  value       = aws_db_instance.main.master_user_secret[0].secret_arn
+}
+
+# Read Replicas
+output "read_replica_identifiers" {
+  description = "Identifiers of the read replicas (empty when create_read_replica is false)"
+  value       = aws_db_instance.replica[*].identifier
+}
+
+output "read_replica_endpoints" {
+  description = "Connection endpoints (address:port) of the read replicas (empty when create_read_replica is false)"
+  value       = aws_db_instance.replica[*].endpoint
+}
+
+# Connectivity Canary
+output "canary_function_arn" {
+  description = "ARN of the connectivity canary Lambda (null when enable_canary is false)"
+  value       = var.enable_canary ? aws_lambda_function.canary[0].arn : null
+}
+
+output "canary_schedule_rule_name" {
+  description = "Name of the EventBridge rule scheduling the canary (null when enable_canary is false)"
+  value       = var.enable_canary ? aws_cloudwatch_event_rule.canary[0].name : null
+}
+
+output "reachability_alarm_arn" {
+  description = "ARN of the DatabaseReachable alarm (null when enable_canary is false)"
+  value       = var.enable_canary ? aws_cloudwatch_metric_alarm.database_reachable[0].arn : null
+}
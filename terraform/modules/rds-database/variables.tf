@@ -0,0 +1,240 @@
+# Variables for RDS Database Module
+
+variable "project_name" {
+  description = "Name of the project"
+  type        = string
+  validation {
+    condition     = length(var.project_name) > 0 && length(var.project_name) <= 50 && can(regex("^[a-zA-Z0-9-]+$", var.project_name))
+    error_message = "Project name must be 1-50 characters and contain only alphanumeric characters and hyphens."
+  }
+}
+
+variable "environment" {
+  description = "Environment name (staging, production)"
+  type        = string
+  validation {
+    condition     = contains(["staging", "production"], var.environment)
+    error_message = "Environment must be either 'staging' or 'production'."
+  }
+}
+
+# Networking
+variable "db_subnet_group_name" {
+  description = "Name of the DB subnet group the instance is placed in (the shared-networking db_subnet_group_name output)"
+  type        = string
+}
+
+variable "vpc_security_group_ids" {
+  description = "Security groups attached to the primary instance and its read replicas"
+  type        = list(string)
+  validation {
+    condition     = length(var.vpc_security_group_ids) > 0
+    error_message = "At least one security group ID must be provided."
+  }
+}
+
+# Instance Configuration
+variable "engine" {
+  description = "Database engine (postgres, mysql)"
+  type        = string
+  default     = "postgres"
+  validation {
+    condition     = contains(["postgres", "mysql"], var.engine)
+    error_message = "Engine must be either 'postgres' or 'mysql'."
+  }
+}
+
+variable "engine_version" {
+  description = "Engine version; a major version lets RDS pick the default minor version (defaults to the engine's default version)"
+  type        = string
+  default     = null
+  validation {
+    condition = var.engine_version == null || can(regex(
+      var.engine == "postgres" ? "^1[0-9](\\.[0-9]+)?$" : "^[5-9]\\.[0-9]+(\\.[0-9]+)?$",
+      coalesce(var.engine_version, "-")
+    ))
+    error_message = "Engine version must match the engine: a PostgreSQL version such as 16 or 16.4, or a MySQL version such as 8.0 or 8.0.39."
+  }
+}
+
+variable "instance_class" {
+  description = "Instance class of the primary instance"
+  type        = string
+  default     = "db.t3.micro"
+  validation {
+    condition     = startswith(var.instance_class, "db.")
+    error_message = "Instance class must be an RDS instance class starting with db."
+  }
+}
+
+variable "allocated_storage" {
+  description = "Allocated storage in GiB"
+  type        = number
+  default     = 20
+  validation {
+    condition     = var.allocated_storage >= 20 && var.allocated_storage <= 65536
+    error_message = "Allocated storage must be between 20 and 65536 GiB."
+  }
+}
+
+variable "max_allocated_storage" {
+  description = "Upper limit in GiB for storage autoscaling (0 disables autoscaling)"
+  type        = number
+  default     = 100
+  validation {
+    condition     = var.max_allocated_storage == 0 || var.max_allocated_storage >= var.allocated_storage
+    error_message = "Max allocated storage must be 0 or at least allocated_storage."
+  }
+}
+
+variable "database_name" {
+  description = "Name of the database created on the primary instance"
+  type        = string
+  default     = "app"
+  validation {
+    condition     = can(regex("^[a-zA-Z][a-zA-Z0-9_]{0,62}$", var.database_name))
+    error_message = "Database name must start with a letter and contain only letters, numbers, and underscores (max 63 characters)."
+  }
+}
+
+variable "master_username" {
+  description = "Master username; the password is generated and stored in Secrets Manager by RDS"
+  type        = string
+  default     = "dbadmin"
+  validation {
+    condition     = can(regex("^[a-zA-Z][a-zA-Z0-9_]{0,15}$", var.master_username))
+    error_message = "Master username must start with a letter and contain only letters, numbers, and underscores (max 16 characters)."
+  }
+}
+
+variable "port" {
+  description = "Database port (defaults to 5432 for postgres and 3306 for mysql)"
+  type        = number
+  default     = null
+}
+
+variable "kms_key_arn" {
+  description = "KMS key used for storage encryption (defaults to the AWS managed RDS key)"
+  type        = string
+  default     = null
+}
+
+variable "multi_az" {
+  description = "Run the primary instance with a synchronous standby in another availability zone"
+  type        = bool
+  default     = false
+}
+
+variable "backup_retention_period" {
+  description = "Days automated backups are kept (0 disables backups)"
+  type        = number
+  default     = 7
+  validation {
+    condition     = var.backup_retention_period >= 0 && var.backup_retention_period <= 35
+    error_message = "Backup retention period must be between 0 and 35 days."
+  }
+}
+
+variable "deletion_protection" {
+  description = "Protect the primary instance from deletion"
+  type        = bool
+  default     = true
+}
+
+variable "skip_final_snapshot" {
+  description = "Skip the final snapshot when the primary instance is destroyed"
+  type        = bool
+  default     = false
+}
+
+# Read Replica Configuration
+variable "create_read_replica" {
+  description = "Create read replicas of the primary instance"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !var.create_read_replica || var.backup_retention_period > 0
+    error_message = "Read replicas require automated backups on the primary (backup_retention_period greater than 0)."
+  }
+}
+
+variable "replica_count" {
+  description = "Number of read replicas created when create_read_replica is true"
+  type        = number
+  default     = 1
+  validation {
+    condition     = var.replica_count >= 1 && var.replica_count <= 15 && floor(var.replica_count) == var.replica_count
+    error_message = "Replica count must be a whole number between 1 and 15."
+  }
+}
+
+variable "replica_instance_class" {
+  description = "Instance class of the read replicas (defaults to instance_class)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.replica_instance_class == null || startswith(coalesce(var.replica_instance_class, "db."), "db.")
+    error_message = "Replica instance class must be an RDS instance class starting with db."
+  }
+}
+
+# Connectivity Canary
+variable "enable_canary" {
+  description = "Create a scheduled Lambda in the VPC that checks the database accepts connections and alarms when it does not"
+  type        = bool
+  default     = false
+}
+
+variable "canary_interval_minutes" {
+  description = "Minutes between canary runs; also the period of the reachability alarm"
+  type        = number
+  default     = 5
+  validation {
+    condition     = var.canary_interval_minutes >= 1 && var.canary_interval_minutes <= 60 && floor(var.canary_interval_minutes) == var.canary_interval_minutes
+    error_message = "Canary interval must be a whole number of minutes between 1 and 60."
+  }
+}
+
+variable "canary_security_group_ids" {
+  description = "Security groups of the canary Lambda; the database security groups must allow them on the database port"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = !var.enable_canary || length(var.canary_security_group_ids) > 0
+    error_message = "At least one canary security group ID must be provided when enable_canary is true."
+  }
+}
+
+variable "canary_subnet_ids" {
+  description = "Subnets the canary Lambda runs in (defaults to the subnets of the DB subnet group)"
+  type        = list(string)
+  default     = []
+}
+
+variable "canary_alarm_evaluation_periods" {
+  description = "Consecutive failed or missing canary runs before the reachability alarm fires"
+  type        = number
+  default     = 2
+  validation {
+    condition     = var.canary_alarm_evaluation_periods >= 1 && var.canary_alarm_evaluation_periods <= 10
+    error_message = "Canary alarm evaluation periods must be between 1 and 10."
+  }
+}
+
+variable "canary_log_retention_days" {
+  description = "CloudWatch log retention period in days for the canary Lambda"
+  type        = number
+  default     = 14
+}
+
+variable "alarm_actions" {
+  description = "List of ARNs (e.g., SNS topics) to notify when the reachability alarm changes state"
+  type        = list(string)
+  default     = []
+}
+
+variable "additional_tags" {
+  description = "Additional tags to apply to resources"
+  type        = map(string)
+  default     = {}
+}
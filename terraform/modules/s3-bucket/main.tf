@@ -0,0 +1,116 @@
+# S3 Bucket Module
+# Hardened S3 bucket with versioning, encryption, and optional cross-region replication
+
+resource "aws_s3_bucket" "main" {
+  bucket        = "${var.project_name}-${var.environment}-${var.bucket_name}"
+  force_destroy = var.force_destroy
+
+  tags = merge(
+    {
+      Name        = "${var.project_name}-${var.environment}-${var.bucket_name}"
+      Environment = var.environment
+      Module      = "s3-bucket"
+    },
+    var.additional_tags
+  )
+}
+
+resource "aws_s3_bucket_public_access_block" "main" {
+  bucket = aws_s3_bucket.main.id
+
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+resource "aws_s3_bucket_ownership_controls" "main" {
+  bucket = aws_s3_bucket.main.id
+
+  rule {
+    object_ownership = "BucketOwnerEnforced"
+  }
+}
+
+resource "aws_s3_bucket_versioning" "main" {
+  bucket = aws_s3_bucket.main.id
+  versioning_configuration {
+    status = var.enable_versioning ? "Enabled" : "Suspended"
+  }
+}
+
+resource "aws_s3_bucket_server_side_encryption_configuration" "main" {
+  bucket = aws_s3_bucket.main.id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm     = var.kms_key_arn != null ? "aws:kms" : "AES256"
+      kms_master_key_id = var.kms_key_arn
+    }
+    bucket_key_enabled = var.kms_key_arn != null
+  }
+}
+
+# Event notifications (optional)
+resource "aws_s3_bucket_notification" "main" {
+  count = length(var.event_notifications) > 0 ? 1 : 0
+
+  bucket = aws_s3_bucket.main.id
+
+  dynamic "queue" {
+    for_each = [for notification in var.event_notifications : notification if notification.destination_type == "sqs"]
+    content {
+      queue_arn     = queue.value.destination_arn
+      events        = queue.value.events
+      filter_prefix = queue.value.filter_prefix
+      filter_suffix = queue.value.filter_suffix
+    }
+  }
+
+  dynamic "topic" {
+    for_each = [for notification in var.event_notifications : notification if notification.destination_type == "sns"]
+    content {
+      topic_arn     = topic.value.destination_arn
+      events        = topic.value.events
+      filter_prefix = topic.value.filter_prefix
+      filter_suffix = topic.value.filter_suffix
+    }
+  }
+
+  dynamic "lambda_function" {
+    for_each = [for notification in var.event_notifications : notification if notification.destination_type == "lambda"]
+    content {
+      lambda_function_arn = lambda_function.value.destination_arn
+      events              = lambda_function.value.events
+      filter_prefix       = lambda_function.value.filter_prefix
+      filter_suffix       = lambda_function.value.filter_suffix
+    }
+  }
+}
+
+# Cross-region replication (optional)
+resource "aws_s3_bucket_replication_configuration" "main" {
+  count = var.enable_replication ? 1 : 0
+
+  role   = var.replication_role_arn
+  bucket = aws_s3_bucket.main.id
+
+  rule {
+    id     = "cross-region-replication"
+    status = "Enabled"
+
+    filter {}
+
+    delete_marker_replication {
+      status = "Enabled"
+    }
+
+    destination {
+      bucket        = var.replication_destination_bucket_arn
+      storage_class = var.replication_storage_class
+    }
+  }
+
+  # Replication requires versioning to be enabled on the source first
+  depends_on = [aws_s3_bucket_versioning.main]
+}
@@ -0,0 +1,31 @@
+# Outputs for S3 Bucket Module
+
+output "bucket_id" {
+  description = "Name of the bucket"
+  value       = aws_s3_bucket.main.id
+}
+
+output "bucket_arn" {
+  description = "ARN of the bucket"
+  value       = aws_s3_bucket.main.arn
+}
+
+output "bucket_regional_domain_name" {
+  description = "Region-specific domain name of the bucket"
+  value       = aws_s3_bucket.main.bucket_regional_domain_name
+}
+
+output "event_notification_destination_arns" {
+  description = "ARNs of the queues, topics, and functions receiving event notifications"
+  value       = distinct([for notification in var.event_notifications : notification.destination_arn])
+}
+
+output "replication_enabled" {
+  description = "Whether cross-region replication is configured"
+  value       = var.enable_replication
+}
+
+output "replication_destination_bucket_arn" {
+  description = "ARN of the replication destination bucket (if enabled)"
+  value       = var.enable_replication ? var.replication_destination_bucket_arn : null
+}
@@ -0,0 +1,126 @@
+# Variables for S3 Bucket Module
+
+variable "project_name" {
+  description = "Name of the project"
+  type        = string
+  validation {
+    condition     = length(var.project_name) > 0 && length(var.project_name) <= 30 && can(regex("^[a-z][a-z0-9-]*$", var.project_name))
+    error_message = "Project name must be 1-30 characters, start with a lowercase letter, and contain only lowercase letters, numbers, and hyphens."
+  }
+}
+
+variable "environment" {
+  description = "Environment name (shared, staging, production)"
+  type        = string
+  validation {
+    condition     = contains(["shared", "staging", "production"], var.environment)
+    error_message = "Environment must be one of: shared, staging, production."
+  }
+}
+
+variable "bucket_name" {
+  description = "Bucket name suffix; the full name is <project_name>-<environment>-<bucket_name>"
+  type        = string
+  validation {
+    condition     = length(var.bucket_name) > 0 && length(var.bucket_name) <= 30 && can(regex("^[a-z0-9][a-z0-9-]*[a-z0-9]$", var.bucket_name))
+    error_message = "Bucket name must be 2-30 characters of lowercase letters, numbers, and hyphens, and must not start or end with a hyphen."
+  }
+}
+
+variable "force_destroy" {
+  description = "Allow the bucket to be destroyed even when it contains objects"
+  type        = bool
+  default     = false
+}
+
+variable "enable_versioning" {
+  description = "Enable object versioning on the bucket"
+  type        = bool
+  default     = true
+}
+
+variable "kms_key_arn" {
+  description = "KMS key ARN for server-side encryption (null uses S3-managed AES256 keys)"
+  type        = string
+  default     = null
+}
+
+# Replication Configuration
+variable "enable_replication" {
+  description = "Replicate objects to a bucket in another region for disaster recovery"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !var.enable_replication || var.enable_versioning
+    error_message = "Versioning must be enabled when replication is enabled."
+  }
+}
+
+variable "replication_destination_bucket_arn" {
+  description = "ARN of the versioned destination bucket objects are replicated to"
+  type        = string
+  default     = null
+  validation {
+    condition     = !var.enable_replication || can(regex("^arn:aws[a-z-]*:s3:::[a-z0-9][a-z0-9.-]+$", var.replication_destination_bucket_arn))
+    error_message = "A valid destination bucket ARN (arn:aws:s3:::bucket-name) is required when replication is enabled."
+  }
+}
+
+variable "replication_role_arn" {
+  description = "ARN of the IAM role S3 assumes to replicate objects"
+  type        = string
+  default     = null
+  validation {
+    condition     = !var.enable_replication || can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$", var.replication_role_arn))
+    error_message = "A valid IAM role ARN is required when replication is enabled."
+  }
+}
+
+variable "replication_storage_class" {
+  description = "Storage class for replicated objects"
+  type        = string
+  default     = "STANDARD"
+  validation {
+    condition     = contains(["STANDARD", "STANDARD_IA", "ONEZONE_IA", "INTELLIGENT_TIERING", "GLACIER_IR", "GLACIER", "DEEP_ARCHIVE"], var.replication_storage_class)
+    error_message = "Replication storage class must be one of: STANDARD, STANDARD_IA, ONEZONE_IA, INTELLIGENT_TIERING, GLACIER_IR, GLACIER, DEEP_ARCHIVE."
+  }
+}
+
+# Event Notifications
+variable "event_notifications" {
+  description = "Event notifications sent to SQS queues, SNS topics, or Lambda functions; destinations must already allow S3 to publish"
+  type = list(object({
+    events           = list(string)
+    destination_type = string
+    destination_arn  = string
+    filter_prefix    = optional(string)
+    filter_suffix    = optional(string)
+  }))
+  default = []
+  validation {
+    condition = alltrue([
+      for notification in var.event_notifications : contains(["sqs", "sns", "lambda"], notification.destination_type)
+    ])
+    error_message = "Event notification destination type must be one of: sqs, sns, lambda."
+  }
+  validation {
+    condition = alltrue([
+      for notification in var.event_notifications : can(regex("^arn:aws[a-z-]*:${notification.destination_type}:", notification.destination_arn))
+    ])
+    error_message = "Event notification destination ARNs must match their destination type (an SQS queue, SNS topic, or Lambda function ARN)."
+  }
+  validation {
+    condition = alltrue([
+      for notification in var.event_notifications : length(notification.events) > 0 && alltrue([
+        for event in notification.events : can(regex("^s3:[A-Za-z]+(:(\\*|[A-Za-z]+))?$", event))
+      ])
+    ])
+    error_message = "Each event notification must list at least one S3 event (e.g. s3:ObjectCreated:* or s3:IntelligentTiering)."
+  }
+}
+
+variable "additional_tags" {
+  description = "Additional tags to apply to resources"
+  type        = map(string)
+  default     = {}
+}
@@ -0,0 +1,32 @@
+# Service Control Policies Module
+# Creates AWS Organizations SCP guardrails and attaches them to organizational units
+
+resource "aws_organizations_policy" "scp" {
+  for_each = { for policy in var.policies : policy.name => policy }
+
+  name        = "${var.project_name}-${each.key}"
+  description = each.value.description
+  type        = "SERVICE_CONTROL_POLICY"
+  content     = each.value.content
+
+  tags = merge(
+    {
+      Name   = "${var.project_name}-${each.key}"
+      Module = "scp"
+    },
+    var.additional_tags
+  )
+}
+
+# Attach every policy to every target OU
+resource "aws_organizations_policy_attachment" "scp" {
+  for_each = {
+    for pair in setproduct([for policy in var.policies : policy.name], var.target_ou_ids) : "${pair[0]}-${pair[1]}" => {
+      policy_name = pair[0]
+      target_id   = pair[1]
+    }
+  }
+
+  policy_id = aws_organizations_policy.scp[each.value.policy_name].id
+  target_id = each.value.target_id
+}
@@ -0,0 +1,16 @@
+# Outputs for SCP Module
+
+output "policy_ids" {
+  description = "Map of policy name to SCP ID"
+  value       = { for name, policy in aws_organizations_policy.scp : name => policy.id }
+}
+
+output "policy_arns" {
+  description = "Map of policy name to SCP ARN"
+  value       = { for name, policy in aws_organizations_policy.scp : name => policy.arn }
+}
+
+output "attachment_count" {
+  description = "Number of policy attachments created"
+  value       = length(aws_organizations_policy_attachment.scp)
+}
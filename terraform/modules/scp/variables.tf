@@ -0,0 +1,47 @@
+# Variables for SCP Module
+
+variable "project_name" {
+  description = "Name of the project"
+  type        = string
+  validation {
+    condition     = length(var.project_name) > 0 && length(var.project_name) <= 50 && can(regex("^[a-zA-Z][a-zA-Z0-9-]*$", var.project_name))
+    error_message = "Project name must be 1-50 characters, start with a letter, and contain only letters, numbers, and hyphens."
+  }
+}
+
+variable "policies" {
+  description = "Service control policies to create. content is the JSON policy document"
+  type = list(object({
+    name        = string
+    description = optional(string, "Managed by Terraform")
+    content     = string
+  }))
+  validation {
+    condition     = length(var.policies) > 0
+    error_message = "At least one policy must be provided."
+  }
+  validation {
+    condition     = length(distinct([for policy in var.policies : policy.name])) == length(var.policies)
+    error_message = "Policy names must be unique."
+  }
+  validation {
+    condition     = alltrue([for policy in var.policies : can(jsondecode(policy.content))])
+    error_message = "Policy content must be a valid JSON document."
+  }
+}
+
+variable "target_ou_ids" {
+  description = "Organizational unit (or root) IDs every policy is attached to"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for id in var.target_ou_ids : can(regex("^(ou-[0-9a-z]{4,32}-[0-9a-z]{8,32}|r-[0-9a-z]{4,32})$", id))])
+    error_message = "Target IDs must be organizational unit IDs (ou-xxxx-xxxxxxxx) or root IDs (r-xxxx)."
+  }
+}
+
+variable "additional_tags" {
+  description = "Additional tags to apply to resources"
+  type        = map(string)
+  default     = {}
+}
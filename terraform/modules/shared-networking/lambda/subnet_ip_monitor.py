@@ -0,0 +1,60 @@
+#!/usr/bin/env python3
+"""
+Subnet IP Monitor Lambda Function
+
+EC2 does not publish subnet IP usage to CloudWatch, so this function:
+1. Lists every subnet in the configured VPC
+2. Publishes AvailableIPAddressCount per subnet as a custom metric
+"""
+
+import boto3
+import os
+import logging
+from typing import Dict, Any
+
+# Configure logging
+logger = logging.getLogger()
+logger.setLevel(logging.INFO)
+
+# AWS clients - get region from environment or session
+region = os.environ.get('AWS_REGION', boto3.Session().region_name)
+ec2 = boto3.client('ec2', region_name=region)
+cloudwatch = boto3.client('cloudwatch', region_name=region)
+
+VPC_ID = os.environ['VPC_ID']
+METRIC_NAMESPACE = os.environ.get('METRIC_NAMESPACE', 'EPiC/VPC')
+
+# PutMetricData accepts at most 1000 metrics per call; subnets are far fewer
+# but batch defensively to stay well inside request size limits
+BATCH_SIZE = 20
+
+
+def handler(event: Dict[str, Any], context: Any) -> Dict[str, Any]:
+    """Publish the available IP address count of every subnet in the VPC"""
+    paginator = ec2.get_paginator('describe_subnets')
+    metric_data = []
+
+    for page in paginator.paginate(Filters=[{'Name': 'vpc-id', 'Values': [VPC_ID]}]):
+        for subnet in page['Subnets']:
+            metric_data.append({
+                'MetricName': 'AvailableIPAddressCount',
+                'Dimensions': [
+                    {'Name': 'VpcId', 'Value': VPC_ID},
+                    {'Name': 'SubnetId', 'Value': subnet['SubnetId']}
+                ],
+                'Value': subnet['AvailableIpAddressCount'],
+                'Unit': 'Count'
+            })
+
+    for start in range(0, len(metric_data), BATCH_SIZE):
+        cloudwatch.put_metric_data(
+            Namespace=METRIC_NAMESPACE,
+            MetricData=metric_data[start:start + BATCH_SIZE]
+        )
+
+    logger.info(f"Published AvailableIPAddressCount for {len(metric_data)} subnets in {VPC_ID}")
+
+    return {
+        'statusCode': 200,
+        'subnets': len(metric_data)
+    }
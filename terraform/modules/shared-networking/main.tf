@@ -9,6 +9,11 @@ terraform {
       source  = "hashicorp/aws"
       version = "~> 6.14.0"
     }
+
+    archive = {
+      source  = "hashicorp/archive"
+      version = "~> 2.4.0"
+    }
   }
 }
 
@@ -18,90 +23,189 @@ data "aws_availability_zones" "available" {
 
 data "aws_region" "current" {}
 
+data "aws_caller_identity" "current" {}
+
+locals {
+  # Module-specific tags take precedence over caller-supplied tags
+  common_tags = merge(
+    var.tags,
+    {
+      Environment = var.environment
+      Module      = "shared-networking"
+    }
+  )
+
+  # dualstack adds an Amazon-provided /56 to the VPC and a /64 to every subnet; ipv6 also
+  # makes the private subnets IPv6-only, reaching IPv4 services through DNS64 and NAT64
+  enable_ipv6       = var.ip_address_type != "ipv4"
+  ipv6_only_private = var.ip_address_type == "ipv6"
+  ipv6_anywhere     = local.enable_ipv6 ? ["::/0"] : []
+
+  # Subnets are spread over the pinned AZs in order, or over every available AZ when none are pinned
+  availability_zones = length(var.availability_zones) > 0 ? var.availability_zones : data.aws_availability_zones.available.names
+
+  # Auto-calculated subnets are packed from the start of the VPC CIDR in tier order (public,
+  # private, database), each tier sized by its newbits. A tier with explicit CIDRs keeps its
+  # place in the layout, so switching one tier to explicit ranges never moves the others.
+  subnet_newbits = concat(
+    [for index in range(var.public_subnet_count) : var.public_subnet_newbits],
+    [for index in range(var.private_subnet_count) : var.private_subnet_newbits],
+    [for index in range(var.database_subnet_count) : var.database_subnet_newbits]
+  )
+  auto_subnet_cidrs = cidrsubnets(var.vpc_cidr, local.subnet_newbits...)
+
+  public_subnet_cidrs   = length(var.public_subnet_cidrs) > 0 ? var.public_subnet_cidrs : slice(local.auto_subnet_cidrs, 0, var.public_subnet_count)
+  private_subnet_cidrs  = length(var.private_subnet_cidrs) > 0 ? var.private_subnet_cidrs : slice(local.auto_subnet_cidrs, var.public_subnet_count, var.public_subnet_count + var.private_subnet_count)
+  database_subnet_cidrs = length(var.database_subnet_cidrs) > 0 ? var.database_subnet_cidrs : slice(local.auto_subnet_cidrs, var.public_subnet_count + var.private_subnet_count, length(local.auto_subnet_cidrs))
+}
+
 # VPC
 resource "aws_vpc" "main" {
-  cidr_block           = var.vpc_cidr
-  enable_dns_hostnames = true
-  enable_dns_support   = true
+  cidr_block                       = var.vpc_cidr
+  assign_generated_ipv6_cidr_block = local.enable_ipv6
+  enable_dns_hostnames             = var.enable_dns_hostnames
+  enable_dns_support               = var.enable_dns_support
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-vpc"
-    Environment = var.environment
-    Module      = "shared-networking"
+  lifecycle {
+    precondition {
+      condition     = alltrue([for zone in var.availability_zones : contains(data.aws_availability_zones.available.names, zone)])
+      error_message = "Every pinned availability zone must be an available zone in the provider's region."
+    }
   }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-vpc"
+    }
+  )
+}
+
+# DHCP Options (optional) - pushes custom DNS resolvers and domain name to instances
+resource "aws_vpc_dhcp_options" "main" {
+  count = var.dhcp_options != null ? 1 : 0
+
+  domain_name         = var.dhcp_options.domain_name
+  domain_name_servers = var.dhcp_options.domain_name_servers
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-dhcp-options"
+    }
+  )
+}
+
+resource "aws_vpc_dhcp_options_association" "main" {
+  count = var.dhcp_options != null ? 1 : 0
+
+  vpc_id          = aws_vpc.main.id
+  dhcp_options_id = aws_vpc_dhcp_options.main[0].id
 }
 
 # Internet Gateway
 resource "aws_internet_gateway" "main" {
   vpc_id = aws_vpc.main.id
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-igw"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-igw"
+    }
+  )
+}
+
+# Egress-only Internet Gateway - outbound-only IPv6 internet access for the private tier
+resource "aws_egress_only_internet_gateway" "main" {
+  count = local.enable_ipv6 ? 1 : 0
+
+  vpc_id = aws_vpc.main.id
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-eigw"
+    }
+  )
 }
 
 # Public Subnets
+# Subnets wrap around the available AZs, so a tier can request more subnets than
+# the region has AZs (e.g. three subnets in a two-AZ region reuse the first AZ)
 resource "aws_subnet" "public" {
   count = var.public_subnet_count
 
-  vpc_id                  = aws_vpc.main.id
-  cidr_block              = cidrsubnet(var.vpc_cidr, 8, count.index)
-  availability_zone       = data.aws_availability_zones.available.names[count.index]
-  map_public_ip_on_launch = true
-
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-public-${count.index + 1}"
-    Type        = "Public"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  vpc_id                          = aws_vpc.main.id
+  cidr_block                      = local.public_subnet_cidrs[count.index]
+  ipv6_cidr_block                 = local.enable_ipv6 ? cidrsubnet(aws_vpc.main.ipv6_cidr_block, 8, count.index) : null
+  assign_ipv6_address_on_creation = local.enable_ipv6
+  availability_zone               = element(local.availability_zones, count.index)
+  map_public_ip_on_launch         = true
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-public-${count.index + 1}"
+      Type = "Public"
+    }
+  )
 }
 
 # Private Subnets
 resource "aws_subnet" "private" {
   count = var.private_subnet_count
 
-  vpc_id            = aws_vpc.main.id
-  cidr_block        = cidrsubnet(var.vpc_cidr, 8, count.index + var.public_subnet_count)
-  availability_zone = data.aws_availability_zones.available.names[count.index]
-
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-private-${count.index + 1}"
-    Type        = "Private"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  vpc_id                          = aws_vpc.main.id
+  cidr_block                      = local.ipv6_only_private ? null : local.private_subnet_cidrs[count.index]
+  ipv6_cidr_block                 = local.enable_ipv6 ? cidrsubnet(aws_vpc.main.ipv6_cidr_block, 8, count.index + var.public_subnet_count) : null
+  ipv6_native                     = local.ipv6_only_private
+  assign_ipv6_address_on_creation = local.enable_ipv6
+  availability_zone               = element(local.availability_zones, count.index)
+
+  # IPv6-only instances resolve IPv4-only names to NAT64 addresses and get AAAA records
+  enable_dns64                                   = local.ipv6_only_private
+  enable_resource_name_dns_aaaa_record_on_launch = local.ipv6_only_private
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-private-${count.index + 1}"
+      Type = "Private"
+    }
+  )
 }
 
 # Database Subnets
 resource "aws_subnet" "database" {
   count = var.database_subnet_count
 
-  vpc_id            = aws_vpc.main.id
-  cidr_block        = cidrsubnet(var.vpc_cidr, 8, count.index + var.public_subnet_count + var.private_subnet_count)
-  availability_zone = data.aws_availability_zones.available.names[count.index]
-
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-database-${count.index + 1}"
-    Type        = "Database"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  vpc_id                          = aws_vpc.main.id
+  cidr_block                      = local.database_subnet_cidrs[count.index]
+  ipv6_cidr_block                 = local.enable_ipv6 ? cidrsubnet(aws_vpc.main.ipv6_cidr_block, 8, count.index + var.public_subnet_count + var.private_subnet_count) : null
+  assign_ipv6_address_on_creation = local.enable_ipv6
+  availability_zone               = element(local.availability_zones, count.index)
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-database-${count.index + 1}"
+      Type = "Database"
+    }
+  )
 }
 
-# Elastic IPs for NAT Gateways
+# Elastic IPs for NAT Gateways - only created when no pre-allocated Elastic IPs are supplied
 resource "aws_eip" "nat" {
-  count = var.enable_nat_gateway ? var.nat_gateway_count : 0
+  count = var.enable_nat_gateway && length(var.nat_gateway_eip_allocation_ids) == 0 ? var.nat_gateway_count : 0
 
   domain = "vpc"
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-nat-eip-${count.index + 1}"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-nat-eip-${count.index + 1}"
+    }
+  )
 
   depends_on = [aws_internet_gateway.main]
 }
@@ -110,14 +214,15 @@ resource "aws_eip" "nat" {
 resource "aws_nat_gateway" "main" {
   count = var.enable_nat_gateway ? var.nat_gateway_count : 0
 
-  allocation_id = aws_eip.nat[count.index].id
+  allocation_id = length(var.nat_gateway_eip_allocation_ids) > 0 ? var.nat_gateway_eip_allocation_ids[count.index] : aws_eip.nat[count.index].id
   subnet_id     = aws_subnet.public[count.index].id
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-nat-${count.index + 1}"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-nat-${count.index + 1}"
+    }
+  )
 
   depends_on = [aws_internet_gateway.main]
 }
@@ -131,11 +236,20 @@ resource "aws_route_table" "public" {
     gateway_id = aws_internet_gateway.main.id
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-public-rt"
-    Environment = var.environment
-    Module      = "shared-networking"
+  dynamic "route" {
+    for_each = local.enable_ipv6 ? [1] : []
+    content {
+      ipv6_cidr_block = "::/0"
+      gateway_id      = aws_internet_gateway.main.id
+    }
   }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-public-rt"
+    }
+  )
 }
 
 # Route Tables for Private Subnets
@@ -152,22 +266,98 @@ resource "aws_route_table" "private" {
     }
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-private-rt-${count.index + 1}"
-    Environment = var.environment
-    Module      = "shared-networking"
+  # NAT instance egress - declared here because routes are inline; a route written
+  # by the instance itself would be removed by the next apply
+  dynamic "route" {
+    for_each = var.nat_instance_network_interface_id != null ? [1] : []
+    content {
+      cidr_block           = "0.0.0.0/0"
+      network_interface_id = var.nat_instance_network_interface_id
+    }
   }
+
+  dynamic "route" {
+    for_each = local.enable_ipv6 ? [1] : []
+    content {
+      ipv6_cidr_block        = "::/0"
+      egress_only_gateway_id = aws_egress_only_internet_gateway.main[0].id
+    }
+  }
+
+  # NAT64 - DNS64 synthesizes addresses in 64:ff9b::/96 for IPv4-only destinations
+  dynamic "route" {
+    for_each = local.ipv6_only_private && var.enable_nat_gateway ? [1] : []
+    content {
+      ipv6_cidr_block = "64:ff9b::/96"
+      nat_gateway_id  = aws_nat_gateway.main[count.index % length(aws_nat_gateway.main)].id
+    }
+  }
+
+  # Routes are inline, so transit gateway routes must be declared here rather than as aws_route resources
+  dynamic "route" {
+    for_each = var.transit_gateway_id != null ? var.transit_gateway_routes : []
+    content {
+      cidr_block         = route.value
+      transit_gateway_id = var.transit_gateway_id
+    }
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-private-rt-${count.index + 1}"
+    }
+  )
+
+  depends_on = [aws_ec2_transit_gateway_vpc_attachment.main]
+}
+
+# Transit Gateway Attachment (optional) - attaches the VPC through the private subnets.
+# An attachment takes at most one subnet per AZ, so only the first private subnet in each AZ is used.
+resource "aws_ec2_transit_gateway_vpc_attachment" "main" {
+  count = var.transit_gateway_id != null ? 1 : 0
+
+  transit_gateway_id     = var.transit_gateway_id
+  vpc_id                 = aws_vpc.main.id
+  subnet_ids             = [for az, ids in { for subnet in aws_subnet.private : subnet.availability_zone => subnet.id... } : ids[0]]
+  appliance_mode_support = var.appliance_mode_support ? "enable" : "disable"
+  dns_support            = "enable"
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-tgw-attachment"
+    }
+  )
 }
 
 # Route Table for Database Subnets
+# Isolated by default; optionally routes egress through the first NAT Gateway for backups and updates
 resource "aws_route_table" "database" {
   vpc_id = aws_vpc.main.id
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-database-rt"
-    Environment = var.environment
-    Module      = "shared-networking"
+  dynamic "route" {
+    for_each = var.database_subnet_internet_egress && var.enable_nat_gateway ? [1] : []
+    content {
+      cidr_block     = "0.0.0.0/0"
+      nat_gateway_id = aws_nat_gateway.main[0].id
+    }
   }
+
+  dynamic "route" {
+    for_each = var.database_subnet_internet_egress && local.enable_ipv6 ? [1] : []
+    content {
+      ipv6_cidr_block        = "::/0"
+      egress_only_gateway_id = aws_egress_only_internet_gateway.main[0].id
+    }
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-database-rt"
+    }
+  )
 }
 
 # Route Table Associations
@@ -193,30 +383,192 @@ resource "aws_route_table_association" "database" {
 }
 
 # VPC Flow Logs
+locals {
+  # Subnet flow logs share the VPC flow logs destination, so it exists when either is enabled
+  flow_logs_enabled       = var.enable_flow_logs || length(var.subnet_flow_logs) > 0
+  flow_logs_to_cloudwatch = local.flow_logs_enabled && var.flow_logs_destination_type == "cloud-watch-logs"
+  flow_logs_to_s3         = local.flow_logs_enabled && var.flow_logs_destination_type == "s3"
+  create_flow_logs_bucket = local.flow_logs_to_s3 && var.flow_logs_s3_bucket_arn == null
+  flow_logs_log_group     = "/aws/vpc/flowlogs/${var.project_name}-${var.environment}"
+
+  # A supplied key takes precedence; otherwise flow_logs_encrypt creates a dedicated key
+  create_flow_logs_kms_key = local.flow_logs_to_cloudwatch && var.flow_logs_encrypt && var.flow_logs_kms_key_id == null
+  flow_logs_kms_key_arn    = (
+    !local.flow_logs_to_cloudwatch ? null :
+    var.flow_logs_kms_key_id != null ? var.flow_logs_kms_key_id :
+    local.create_flow_logs_kms_key ? aws_kms_key.flow_logs[0].arn : null
+  )
+
+  flow_logs_logs_principal = "logs.${data.aws_region.current.id}.amazonaws.com"
+  flow_logs_kms_key_policy = {
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "Enable IAM User Permissions"
+        Effect = "Allow"
+        Principal = {
+          AWS = "arn:aws:iam::${data.aws_caller_identity.current.account_id}:root"
+        }
+        Action   = ["kms:*"]
+        Resource = "*"
+      },
+      {
+        Sid    = "Allow CloudWatch Logs"
+        Effect = "Allow"
+        Principal = {
+          Service = local.flow_logs_logs_principal
+        }
+        Action = [
+          "kms:Encrypt",
+          "kms:Decrypt",
+          "kms:ReEncrypt*",
+          "kms:GenerateDataKey*",
+          "kms:Describe*"
+        ]
+        Resource = "*"
+        Condition = {
+          ArnEquals = {
+            "kms:EncryptionContext:aws:logs:arn" = "arn:aws:logs:${data.aws_region.current.id}:${data.aws_caller_identity.current.account_id}:log-group:${local.flow_logs_log_group}"
+          }
+        }
+      }
+    ]
+  }
+
+  flow_logs_destination_arn = (
+    local.flow_logs_to_cloudwatch ? aws_cloudwatch_log_group.vpc_flow_log[0].arn :
+    local.create_flow_logs_bucket ? aws_s3_bucket.flow_logs[0].arn :
+    local.flow_logs_to_s3 ? var.flow_logs_s3_bucket_arn : null
+  )
+}
+
 resource "aws_flow_log" "vpc_flow_log" {
   count = var.enable_flow_logs ? 1 : 0
 
-  iam_role_arn    = aws_iam_role.flow_log[0].arn
-  log_destination = aws_cloudwatch_log_group.vpc_flow_log[0].arn
-  traffic_type    = "ALL"
-  vpc_id          = aws_vpc.main.id
+  iam_role_arn         = local.flow_logs_to_cloudwatch ? aws_iam_role.flow_log[0].arn : null
+  log_destination      = local.flow_logs_destination_arn
+  log_destination_type = var.flow_logs_destination_type
+  traffic_type         = var.flow_logs_traffic_type
+  log_format           = var.flow_logs_log_format
+  vpc_id               = aws_vpc.main.id
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-vpc-flow-log"
+    }
+  )
+}
+
+# Subnet Flow Logs - per-subnet logs for the tiers in subnet_flow_logs, keyed "<tier>-<number>"
+# with the same 1-based number as the subnet Name tags and IP address alarms
+locals {
+  subnet_flow_log_subnet_ids = merge(
+    contains(var.subnet_flow_logs, "public") ? { for index, subnet in aws_subnet.public : "public-${index + 1}" => subnet.id } : {},
+    contains(var.subnet_flow_logs, "private") ? { for index, subnet in aws_subnet.private : "private-${index + 1}" => subnet.id } : {},
+    contains(var.subnet_flow_logs, "database") ? { for index, subnet in aws_subnet.database : "database-${index + 1}" => subnet.id } : {}
+  )
+}
+
+resource "aws_flow_log" "subnet" {
+  for_each = local.subnet_flow_log_subnet_ids
+
+  iam_role_arn         = local.flow_logs_to_cloudwatch ? aws_iam_role.flow_log[0].arn : null
+  log_destination      = local.flow_logs_destination_arn
+  log_destination_type = var.flow_logs_destination_type
+  traffic_type         = var.flow_logs_traffic_type
+  log_format           = var.flow_logs_log_format
+  subnet_id            = each.value
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-${each.key}-subnet-flow-log"
+    }
+  )
 }
 
 resource "aws_cloudwatch_log_group" "vpc_flow_log" {
-  count = var.enable_flow_logs ? 1 : 0
+  count = local.flow_logs_to_cloudwatch ? 1 : 0
 
-  name              = "/aws/vpc/flowlogs/${var.project_name}-${var.environment}"
+  name              = local.flow_logs_log_group
   retention_in_days = var.flow_logs_retention_days
+  kms_key_id        = local.flow_logs_kms_key_arn
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-vpc-flow-logs"
+    }
+  )
+}
+
+# KMS key for the flow logs log group - created when encryption is requested
+# without an existing key. CloudWatch Logs must be able to use the key for the
+# log group's encryption context before the log group can reference it.
+resource "aws_kms_key" "flow_logs" {
+  count = local.create_flow_logs_kms_key ? 1 : 0
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-vpc-flow-logs"
-    Environment = var.environment
-    Module      = "shared-networking"
+  description         = "VPC flow logs encryption key for ${var.project_name}-${var.environment}"
+  enable_key_rotation = true
+  policy              = jsonencode(local.flow_logs_kms_key_policy)
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-flow-logs-key"
+    }
+  )
+}
+
+resource "aws_kms_alias" "flow_logs" {
+  count = local.create_flow_logs_kms_key ? 1 : 0
+
+  name          = "alias/${var.project_name}-${var.environment}-flow-logs"
+  target_key_id = aws_kms_key.flow_logs[0].key_id
+}
+
+# S3 bucket for flow logs - created when no existing bucket is supplied.
+# The flow logs service adds its own delivery statement to the bucket policy.
+resource "aws_s3_bucket" "flow_logs" {
+  count = local.create_flow_logs_bucket ? 1 : 0
+
+  bucket_prefix = "${var.project_name}-${var.environment}-flow-logs-"
+  force_destroy = var.flow_logs_bucket_force_destroy
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-vpc-flow-logs"
+    }
+  )
+}
+
+resource "aws_s3_bucket_public_access_block" "flow_logs" {
+  count = local.create_flow_logs_bucket ? 1 : 0
+
+  bucket = aws_s3_bucket.flow_logs[0].id
+
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+resource "aws_s3_bucket_server_side_encryption_configuration" "flow_logs" {
+  count = local.create_flow_logs_bucket ? 1 : 0
+
+  bucket = aws_s3_bucket.flow_logs[0].id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm = "AES256"
+    }
   }
 }
 
 resource "aws_iam_role" "flow_log" {
-  count = var.enable_flow_logs ? 1 : 0
+  count = local.flow_logs_to_cloudwatch ? 1 : 0
 
   name_prefix = "${var.project_name}-${var.environment}-flow-log-"
 
@@ -233,15 +585,16 @@ resource "aws_iam_role" "flow_log" {
     ]
   })
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-flow-log-role"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-flow-log-role"
+    }
+  )
 }
 
 resource "aws_iam_role_policy" "flow_log" {
-  count = var.enable_flow_logs ? 1 : 0
+  count = local.flow_logs_to_cloudwatch ? 1 : 0
 
   name_prefix = "${var.project_name}-${var.environment}-flow-log-"
   role        = aws_iam_role.flow_log[0].id
@@ -271,34 +624,38 @@ resource "aws_security_group" "web" {
   vpc_id      = aws_vpc.main.id
 
   ingress {
-    description = "HTTP"
-    from_port   = 80
-    to_port     = 80
-    protocol    = "tcp"
-    cidr_blocks = ["0.0.0.0/0"]
+    description      = "HTTP"
+    from_port        = 80
+    to_port          = 80
+    protocol         = "tcp"
+    cidr_blocks      = ["0.0.0.0/0"]
+    ipv6_cidr_blocks = local.ipv6_anywhere
   }
 
   ingress {
-    description = "HTTPS"
-    from_port   = 443
-    to_port     = 443
-    protocol    = "tcp"
-    cidr_blocks = ["0.0.0.0/0"]
+    description      = "HTTPS"
+    from_port        = 443
+    to_port          = 443
+    protocol         = "tcp"
+    cidr_blocks      = ["0.0.0.0/0"]
+    ipv6_cidr_blocks = local.ipv6_anywhere
   }
 
   egress {
-    description = "All outbound traffic"
-    from_port   = 0
-    to_port     = 0
-    protocol    = "-1"
-    cidr_blocks = ["0.0.0.0/0"]
+    description      = "All outbound traffic"
+    from_port        = 0
+    to_port          = 0
+    protocol         = "-1"
+    cidr_blocks      = ["0.0.0.0/0"]
+    ipv6_cidr_blocks = local.ipv6_anywhere
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-web-sg"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-sg"
+    }
+  )
 }
 
 resource "aws_security_group" "application" {
@@ -314,19 +671,35 @@ resource "aws_security_group" "application" {
     security_groups = [aws_security_group.web.id]
   }
 
-  egress {
-    description = "All outbound traffic"
-    from_port   = 0
-    to_port     = 0
-    protocol    = "-1"
-    cidr_blocks = ["0.0.0.0/0"]
+  # Explicit egress rules replace the default allow-all egress when provided
+  dynamic "egress" {
+    for_each = length(var.application_egress_rules) > 0 ? var.application_egress_rules : [{
+      description        = "All outbound traffic"
+      from_port          = 0
+      to_port            = 0
+      protocol           = "-1"
+      cidr_blocks        = ["0.0.0.0/0"]
+      security_group_ids = []
+    }]
+    content {
+      description     = egress.value.description
+      from_port       = egress.value.from_port
+      to_port         = egress.value.to_port
+      protocol        = egress.value.protocol
+      cidr_blocks     = egress.value.cidr_blocks
+      security_groups = egress.value.security_group_ids
+
+      # Only the default allow-all rule is mirrored for IPv6; explicit rules are IPv4
+      ipv6_cidr_blocks = length(var.application_egress_rules) > 0 ? [] : local.ipv6_anywhere
+    }
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-app-sg"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-app-sg"
+    }
+  )
 }
 
 resource "aws_security_group" "database" {
@@ -351,18 +724,20 @@ resource "aws_security_group" "database" {
   }
 
   egress {
-    description = "All outbound traffic"
-    from_port   = 0
-    to_port     = 0
-    protocol    = "-1"
-    cidr_blocks = ["0.0.0.0/0"]
+    description      = "All outbound traffic"
+    from_port        = 0
+    to_port          = 0
+    protocol         = "-1"
+    cidr_blocks      = ["0.0.0.0/0"]
+    ipv6_cidr_blocks = local.ipv6_anywhere
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-db-sg"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-db-sg"
+    }
+  )
 }
 
 # DB Subnet Group
@@ -372,11 +747,12 @@ resource "aws_db_subnet_group" "main" {
   name       = "${var.project_name}-${var.environment}-db-subnet-group"
   subnet_ids = aws_subnet.database[*].id
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-db-subnet-group"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-db-subnet-group"
+    }
+  )
 }
 
 # VPC Endpoints for improved security and reduced data transfer costs
@@ -386,11 +762,12 @@ resource "aws_route_table" "vpc_endpoints" {
 
   vpc_id = aws_vpc.main.id
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-vpc-endpoints-rt"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-vpc-endpoints-rt"
+    }
+  )
 }
 
 # Associate VPC Endpoints Route Table with Private Subnets
@@ -434,11 +811,12 @@ resource "aws_vpc_endpoint" "s3" {
     ]
   })
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-s3-endpoint"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-s3-endpoint"
+    }
+  )
 }
 
 # DynamoDB VPC Endpoint (Gateway Endpoint)
@@ -477,16 +855,17 @@ resource "aws_vpc_endpoint" "dynamodb" {
     ]
   })
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-dynamodb-endpoint"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-dynamodb-endpoint"
+    }
+  )
 }
 
 # Security Group for Interface VPC Endpoints
 resource "aws_security_group" "vpc_endpoints" {
-  count = var.enable_vpc_endpoints ? 1 : 0
+  count = var.enable_vpc_endpoints || var.enable_secrets_access_endpoints ? 1 : 0
 
   name_prefix = "${var.project_name}-${var.environment}-vpc-endpoints-"
   vpc_id      = aws_vpc.main.id
@@ -511,11 +890,12 @@ resource "aws_security_group" "vpc_endpoints" {
     create_before_destroy = true
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-vpc-endpoints-sg"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-vpc-endpoints-sg"
+    }
+  )
 }
 
 # EC2 VPC Endpoint (Interface Endpoint)
@@ -551,11 +931,12 @@ resource "aws_vpc_endpoint" "ec2" {
     ]
   })
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-ec2-endpoint"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-ec2-endpoint"
+    }
+  )
 }
 
 # CloudWatch Logs VPC Endpoint (Interface Endpoint)
@@ -592,11 +973,12 @@ resource "aws_vpc_endpoint" "logs" {
     ]
   })
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-logs-endpoint"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-logs-endpoint"
+    }
+  )
 }
 
 # CloudWatch Monitoring VPC Endpoint (Interface Endpoint)
@@ -631,11 +1013,32 @@ resource "aws_vpc_endpoint" "monitoring" {
     ]
   })
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-monitoring-endpoint"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-monitoring-endpoint"
+    }
+  )
+}
+
+# Secrets Manager and KMS VPC Endpoints (Interface Endpoints)
+# Private DNS makes the default regional SDK endpoints resolve to the endpoint ENIs
+resource "aws_vpc_endpoint" "secrets_access" {
+  for_each = var.enable_secrets_access_endpoints ? toset(["secretsmanager", "kms"]) : toset([])
+
+  vpc_id              = aws_vpc.main.id
+  service_name        = "com.amazonaws.${data.aws_region.current.id}.${each.key}"
+  vpc_endpoint_type   = "Interface"
+  subnet_ids          = aws_subnet.private[*].id
+  security_group_ids  = [aws_security_group.vpc_endpoints[0].id]
+  private_dns_enabled = true
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-${each.key}-endpoint"
+    }
+  )
 }
 
 # SNS VPC Endpoint (Interface Endpoint)
@@ -671,11 +1074,12 @@ resource "aws_vpc_endpoint" "sns" {
     ]
   })
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-sns-endpoint"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-sns-endpoint"
+    }
+  )
 }
 
 # Advanced Security Features
@@ -724,6 +1128,19 @@ resource "aws_network_acl" "main" {
     to_port    = 65535
   }
 
+  # Allow SSH to the bastion from its allowed ranges
+  dynamic "ingress" {
+    for_each = local.bastion_network_acl_rules
+    content {
+      protocol   = ingress.value.protocol
+      rule_no    = ingress.value.rule_number
+      action     = ingress.value.action
+      cidr_block = ingress.value.cidr_block
+      from_port  = ingress.value.from_port
+      to_port    = ingress.value.to_port
+    }
+  }
+
   # Allow all outbound traffic
   egress {
     protocol   = "-1"
@@ -734,24 +1151,202 @@ resource "aws_network_acl" "main" {
     to_port    = 0
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-main-nacl"
-    Environment = var.environment
-    Module      = "shared-networking"
+  # IPv6 equivalents of the rules above, numbered one after their IPv4 counterparts
+  dynamic "ingress" {
+    for_each = local.enable_ipv6 ? [
+      { rule_no = 101, from_port = 80, to_port = 80, ipv6_cidr_block = "::/0" },
+      { rule_no = 111, from_port = 443, to_port = 443, ipv6_cidr_block = "::/0" },
+      { rule_no = 121, from_port = 22, to_port = 22, ipv6_cidr_block = aws_vpc.main.ipv6_cidr_block },
+      { rule_no = 131, from_port = 1024, to_port = 65535, ipv6_cidr_block = "::/0" }
+    ] : []
+    content {
+      protocol        = "tcp"
+      rule_no         = ingress.value.rule_no
+      action          = "allow"
+      ipv6_cidr_block = ingress.value.ipv6_cidr_block
+      from_port       = ingress.value.from_port
+      to_port         = ingress.value.to_port
+    }
+  }
+
+  dynamic "egress" {
+    for_each = local.enable_ipv6 ? [1] : []
+    content {
+      protocol        = "-1"
+      rule_no         = 101
+      action          = "allow"
+      ipv6_cidr_block = "::/0"
+      from_port       = 0
+      to_port         = 0
+    }
   }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-main-nacl"
+    }
+  )
 }
 
-# Associate Network ACL with public subnets
+# Associate Network ACL with public subnets (replaced by the tier NACLs when enabled)
 resource "aws_network_acl_association" "public" {
-  count = var.public_subnet_count
+  count = var.enable_network_acls ? 0 : var.public_subnet_count
 
   network_acl_id = aws_network_acl.main.id
   subnet_id      = aws_subnet.public[count.index].id
 }
 
+# Tier Network ACLs
+# Defaults allow the VPC CIDR and ephemeral return traffic so existing flows keep working;
+# the database tier explicitly denies inbound traffic from outside the VPC
+locals {
+  ipv4_default_network_acl_rules = {
+    public = {
+      ingress = [
+        { rule_number = 100, action = "allow", protocol = "tcp", from_port = 80, to_port = 80, cidr_block = "0.0.0.0/0" },
+        { rule_number = 110, action = "allow", protocol = "tcp", from_port = 443, to_port = 443, cidr_block = "0.0.0.0/0" },
+        { rule_number = 120, action = "allow", protocol = "-1", from_port = 0, to_port = 0, cidr_block = var.vpc_cidr },
+        { rule_number = 130, action = "allow", protocol = "tcp", from_port = 1024, to_port = 65535, cidr_block = "0.0.0.0/0" }
+      ]
+      egress = [
+        { rule_number = 100, action = "allow", protocol = "-1", from_port = 0, to_port = 0, cidr_block = "0.0.0.0/0" }
+      ]
+    }
+    private = {
+      ingress = [
+        { rule_number = 100, action = "allow", protocol = "-1", from_port = 0, to_port = 0, cidr_block = var.vpc_cidr },
+        { rule_number = 110, action = "allow", protocol = "tcp", from_port = 1024, to_port = 65535, cidr_block = "0.0.0.0/0" }
+      ]
+      egress = [
+        { rule_number = 100, action = "allow", protocol = "-1", from_port = 0, to_port = 0, cidr_block = "0.0.0.0/0" }
+      ]
+    }
+    database = {
+      ingress = concat(
+        [
+          { rule_number = 100, action = "allow", protocol = "-1", from_port = 0, to_port = 0, cidr_block = var.vpc_cidr }
+        ],
+        var.database_subnet_internet_egress ? [
+          { rule_number = 110, action = "allow", protocol = "tcp", from_port = 1024, to_port = 65535, cidr_block = "0.0.0.0/0" }
+        ] : [],
+        [
+          { rule_number = 200, action = "deny", protocol = "-1", from_port = 0, to_port = 0, cidr_block = "0.0.0.0/0" }
+        ]
+      )
+      egress = [
+        { rule_number = 100, action = "allow", protocol = "-1", from_port = 0, to_port = 0, cidr_block = var.database_subnet_internet_egress ? "0.0.0.0/0" : var.vpc_cidr }
+      ]
+    }
+  }
+
+  # SSH from the bastion's allowed ranges, which are outside the VPC CIDR the
+  # public rules otherwise limit SSH to. Numbered from 140 in both the main and
+  # the public tier NACL.
+  bastion_network_acl_rules = [
+    for index, cidr in (var.enable_bastion ? var.bastion_allowed_cidrs : []) : {
+      rule_number     = 140 + index
+      action          = "allow"
+      protocol        = "tcp"
+      from_port       = 22
+      to_port         = 22
+      cidr_block      = cidr
+      ipv6_cidr_block = null
+    }
+  ]
+
+  # With IPv6 enabled each default rule gets an IPv6 twin numbered one after it,
+  # mapping the VPC CIDR to the VPC's IPv6 block and 0.0.0.0/0 to ::/0. The
+  # IPv4-only bastion rules are added to the public tier afterwards without twins.
+  default_network_acl_rules = {
+    for tier, rules in local.ipv4_default_network_acl_rules : tier => {
+      for direction in ["ingress", "egress"] : direction => concat(
+        [for rule in rules[direction] : merge(rule, { ipv6_cidr_block = null })],
+        local.enable_ipv6 ? [
+          for rule in rules[direction] : merge(rule, {
+            rule_number     = rule.rule_number + 1
+            cidr_block      = null
+            ipv6_cidr_block = rule.cidr_block == var.vpc_cidr ? aws_vpc.main.ipv6_cidr_block : "::/0"
+          })
+        ] : [],
+        tier == "public" && direction == "ingress" ? local.bastion_network_acl_rules : []
+      )
+    }
+  }
+
+  network_acl_rules = merge(local.default_network_acl_rules, var.network_acl_rules)
+
+  network_acl_subnet_ids = {
+    public   = aws_subnet.public[*].id
+    private  = aws_subnet.private[*].id
+    database = aws_subnet.database[*].id
+  }
+
+  network_acl_tiers = var.enable_network_acls ? toset([
+    for tier in ["public", "private", "database"] : tier if length(local.network_acl_subnet_ids[tier]) > 0
+  ]) : toset([])
+}
+
+resource "aws_network_acl" "tier" {
+  for_each = local.network_acl_tiers
+
+  vpc_id = aws_vpc.main.id
+
+  dynamic "ingress" {
+    for_each = local.network_acl_rules[each.key].ingress
+    content {
+      rule_no         = ingress.value.rule_number
+      action          = ingress.value.action
+      protocol        = ingress.value.protocol
+      from_port       = ingress.value.from_port
+      to_port         = ingress.value.to_port
+      cidr_block      = ingress.value.cidr_block
+      ipv6_cidr_block = ingress.value.ipv6_cidr_block
+    }
+  }
+
+  dynamic "egress" {
+    for_each = local.network_acl_rules[each.key].egress
+    content {
+      rule_no         = egress.value.rule_number
+      action          = egress.value.action
+      protocol        = egress.value.protocol
+      from_port       = egress.value.from_port
+      to_port         = egress.value.to_port
+      cidr_block      = egress.value.cidr_block
+      ipv6_cidr_block = egress.value.ipv6_cidr_block
+    }
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-${each.key}-nacl"
+      Tier = title(each.key)
+    }
+  )
+}
+
+resource "aws_network_acl_association" "tier" {
+  for_each = {
+    for association in flatten([
+      for tier in local.network_acl_tiers : [
+        for index, subnet_id in local.network_acl_subnet_ids[tier] : {
+          key       = "${tier}-${index}"
+          tier      = tier
+          subnet_id = subnet_id
+        }
+      ]
+    ]) : association.key => association
+  }
+
+  network_acl_id = aws_network_acl.tier[each.value.tier].id
+  subnet_id      = each.value.subnet_id
+}
+
 # CloudWatch Insights queries for security monitoring
 resource "aws_cloudwatch_query_definition" "vpc_flow_log_security" {
-  count = var.enable_flow_logs ? 1 : 0
+  count = local.flow_logs_to_cloudwatch ? 1 : 0
 
   name = "${var.project_name}-${var.environment}-vpc-security-insights"
 
@@ -769,7 +1364,7 @@ EOF
 }
 
 resource "aws_cloudwatch_query_definition" "vpc_flow_log_top_talkers" {
-  count = var.enable_flow_logs ? 1 : 0
+  count = local.flow_logs_to_cloudwatch ? 1 : 0
 
   name = "${var.project_name}-${var.environment}-vpc-top-talkers"
 
@@ -812,11 +1407,12 @@ resource "aws_cloudwatch_metric_alarm" "vpc_rejected_connections" {
     }
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-vpc-security-alarm"
-    Environment = var.environment
-    Module      = "shared-networking"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-vpc-security-alarm"
+    }
+  )
 }
 
 # Enhanced security group rules for VPC endpoints
@@ -863,9 +1459,379 @@ resource "aws_vpc_endpoint" "cloudtrail" {
     ]
   })
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-cloudtrail-endpoint"
-    Environment = var.environment
-    Module      = "shared-networking"
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-cloudtrail-endpoint"
+    }
+  )
+}
+
+# Subnet IP Address Monitoring
+# EC2 does not publish subnet IP usage, so a scheduled Lambda publishes
+# AvailableIPAddressCount per subnet and an alarm fires below the threshold
+# IPv6-only private subnets have no IPv4 addresses to run out of and are not monitored
+locals {
+  monitored_subnet_ids = merge(
+    { for index, subnet in aws_subnet.public : "public-${index + 1}" => subnet.id },
+    { for index, subnet in aws_subnet.private : "private-${index + 1}" => subnet.id if !local.ipv6_only_private },
+    { for index, subnet in aws_subnet.database : "database-${index + 1}" => subnet.id }
+  )
+}
+
+data "archive_file" "subnet_ip_monitor_lambda" {
+  count = var.enable_subnet_ip_alarm ? 1 : 0
+
+  type        = "zip"
+  output_path = "/tmp/${var.project_name}-${var.environment}-subnet_ip_monitor_lambda.zip"
+  source {
+    content  = file("${path.module}/lambda/subnet_ip_monitor.py")
+    filename = "index.py"
   }
-}
\ No newline at end of file
+}
+
+resource "aws_iam_role" "subnet_ip_monitor_lambda" {
+  count = var.enable_subnet_ip_alarm ? 1 : 0
+
+  name_prefix = "${var.project_name}-${var.environment}-subnet-ip-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "lambda.amazonaws.com"
+        }
+      }
+    ]
+  })
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-subnet-ip-monitor-role"
+    }
+  )
+}
+
+resource "aws_iam_role_policy" "subnet_ip_monitor_lambda" {
+  count = var.enable_subnet_ip_alarm ? 1 : 0
+
+  name_prefix = "${var.project_name}-${var.environment}-subnet-ip-"
+  role        = aws_iam_role.subnet_ip_monitor_lambda[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = [
+          "ec2:DescribeSubnets",
+          "cloudwatch:PutMetricData"
+        ]
+        Effect   = "Allow"
+        Resource = "*"
+      },
+      {
+        Action = [
+          "logs:CreateLogGroup",
+          "logs:CreateLogStream",
+          "logs:PutLogEvents"
+        ]
+        Effect   = "Allow"
+        Resource = "arn:aws:logs:*:*:*"
+      }
+    ]
+  })
+}
+
+resource "aws_lambda_function" "subnet_ip_monitor" {
+  count = var.enable_subnet_ip_alarm ? 1 : 0
+
+  filename         = data.archive_file.subnet_ip_monitor_lambda[0].output_path
+  function_name    = "${var.project_name}-${var.environment}-subnet-ip-monitor"
+  role             = aws_iam_role.subnet_ip_monitor_lambda[0].arn
+  handler          = "index.handler"
+  runtime          = "python3.11"
+  timeout          = 60
+  memory_size      = 128
+  source_code_hash = data.archive_file.subnet_ip_monitor_lambda[0].output_base64sha256
+
+  environment {
+    variables = {
+      VPC_ID           = aws_vpc.main.id
+      METRIC_NAMESPACE = var.subnet_ip_metric_namespace
+    }
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-subnet-ip-monitor"
+    }
+  )
+}
+
+resource "aws_cloudwatch_event_rule" "subnet_ip_monitor_schedule" {
+  count = var.enable_subnet_ip_alarm ? 1 : 0
+
+  name                = "${var.project_name}-${var.environment}-subnet-ip-monitor-schedule"
+  description         = "Trigger subnet IP monitor Lambda function"
+  schedule_expression = "rate(5 minutes)"
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-subnet-ip-monitor-schedule"
+    }
+  )
+}
+
+resource "aws_cloudwatch_event_target" "subnet_ip_monitor_lambda" {
+  count = var.enable_subnet_ip_alarm ? 1 : 0
+
+  rule      = aws_cloudwatch_event_rule.subnet_ip_monitor_schedule[0].name
+  target_id = "SubnetIpMonitorLambdaTarget"
+  arn       = aws_lambda_function.subnet_ip_monitor[0].arn
+}
+
+resource "aws_lambda_permission" "allow_eventbridge_subnet_ip_monitor" {
+  count = var.enable_subnet_ip_alarm ? 1 : 0
+
+  statement_id  = "AllowExecutionFromEventBridge"
+  action        = "lambda:InvokeFunction"
+  function_name = aws_lambda_function.subnet_ip_monitor[0].function_name
+  principal     = "events.amazonaws.com"
+  source_arn    = aws_cloudwatch_event_rule.subnet_ip_monitor_schedule[0].arn
+}
+
+resource "aws_cloudwatch_metric_alarm" "subnet_available_ips" {
+  for_each = var.enable_subnet_ip_alarm ? local.monitored_subnet_ids : {}
+
+  alarm_name          = "${var.project_name}-${var.environment}-${each.key}-available-ips-low"
+  comparison_operator = "LessThanThreshold"
+  evaluation_periods  = 2
+  metric_name         = "AvailableIPAddressCount"
+  namespace           = var.subnet_ip_metric_namespace
+  period              = 300
+  statistic           = "Minimum"
+  threshold           = var.subnet_ip_alarm_threshold
+  alarm_description   = "Subnet ${each.key} has fewer than ${var.subnet_ip_alarm_threshold} available IP addresses"
+  treat_missing_data  = "missing"
+
+  dimensions = {
+    VpcId    = aws_vpc.main.id
+    SubnetId = each.value
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-${each.key}-available-ips-low"
+    }
+  )
+}
+
+# Bastion Host (optional)
+# Hardened jump host in the first public subnet. SSH is limited to
+# bastion_allowed_cidrs and Session Manager access works without SSH at all.
+data "aws_ami" "bastion" {
+  count = var.enable_bastion && var.bastion_ami_id == null ? 1 : 0
+
+  most_recent = true
+  owners      = ["amazon"]
+
+  filter {
+    name   = "name"
+    values = ["al2023-ami-2023.*-x86_64"]
+  }
+}
+
+resource "aws_security_group" "bastion" {
+  count = var.enable_bastion ? 1 : 0
+
+  name_prefix = "${var.project_name}-${var.environment}-bastion-"
+  description = "Security group for the bastion host"
+  vpc_id      = aws_vpc.main.id
+
+  ingress {
+    description = "SSH from allowed ranges"
+    from_port   = 22
+    to_port     = 22
+    protocol    = "tcp"
+    cidr_blocks = var.bastion_allowed_cidrs
+  }
+
+  egress {
+    description = "All outbound traffic"
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-bastion-sg"
+    }
+  )
+}
+
+resource "aws_iam_role" "bastion" {
+  count = var.enable_bastion ? 1 : 0
+
+  name_prefix = "${var.project_name}-${var.environment}-bastion-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "ec2.amazonaws.com"
+        }
+      }
+    ]
+  })
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-bastion-role"
+    }
+  )
+}
+
+resource "aws_iam_role_policy_attachment" "bastion_ssm" {
+  count = var.enable_bastion ? 1 : 0
+
+  role       = aws_iam_role.bastion[0].name
+  policy_arn = "arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"
+}
+
+resource "aws_iam_instance_profile" "bastion" {
+  count = var.enable_bastion ? 1 : 0
+
+  name_prefix = "${var.project_name}-${var.environment}-bastion-"
+  role        = aws_iam_role.bastion[0].name
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-bastion-profile"
+    }
+  )
+}
+
+resource "aws_instance" "bastion" {
+  count = var.enable_bastion ? 1 : 0
+
+  ami                         = var.bastion_ami_id != null ? var.bastion_ami_id : data.aws_ami.bastion[0].id
+  instance_type               = var.bastion_instance_type
+  subnet_id                   = aws_subnet.public[0].id
+  vpc_security_group_ids      = [aws_security_group.bastion[0].id]
+  associate_public_ip_address = true
+  key_name                    = var.bastion_key_pair_name
+  iam_instance_profile        = aws_iam_instance_profile.bastion[0].name
+  monitoring                  = true
+
+  user_data = <<-EOF
+    #!/bin/bash
+    set -euo pipefail
+    systemctl enable --now amazon-ssm-agent
+    # Key-based SSH only
+    sed -i 's/^#\?PasswordAuthentication .*/PasswordAuthentication no/' /etc/ssh/sshd_config
+    sed -i 's/^#\?PermitRootLogin .*/PermitRootLogin no/' /etc/ssh/sshd_config
+    systemctl restart sshd
+  EOF
+
+  root_block_device {
+    volume_type = "gp3"
+    volume_size = 8
+    encrypted   = true
+  }
+
+  metadata_options {
+    http_endpoint               = "enabled"
+    http_tokens                 = "required"
+    http_put_response_hop_limit = 1
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-bastion"
+    }
+  )
+
+  depends_on = [aws_route_table_association.public]
+}
+
+# Bastion Recovery Alarm - the EC2 recover action migrates the instance to healthy
+# hardware, keeping its instance ID, private IP, and Elastic IP or public IP
+resource "aws_cloudwatch_metric_alarm" "bastion_recovery" {
+  count = var.enable_bastion && var.enable_ec2_auto_recovery ? 1 : 0
+
+  alarm_name          = "${var.project_name}-${var.environment}-bastion-recovery"
+  comparison_operator = "GreaterThanThreshold"
+  evaluation_periods  = 2
+  metric_name         = "StatusCheckFailed_System"
+  namespace           = "AWS/EC2"
+  period              = 60
+  statistic           = "Maximum"
+  threshold           = 0
+  alarm_description   = "Bastion host failed its system status check and is being recovered"
+  alarm_actions       = ["arn:aws:automate:${data.aws_region.current.id}:ec2:recover"]
+
+  dimensions = {
+    InstanceId = aws_instance.bastion[0].id
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-bastion-recovery"
+    }
+  )
+}
+
+# SSM Output Export - key outputs as parameters under ssm_parameter_prefix, one per output
+# name, so other stacks can read them without remote state. Subnet ID lists are
+# comma-separated StringList parameters; empty tiers are skipped since SSM rejects empty values.
+locals {
+  ssm_parameter_prefix = var.ssm_parameter_prefix != null ? var.ssm_parameter_prefix : "/${var.project_name}/${var.environment}/shared-networking"
+  ssm_exported_outputs = merge(
+    {
+      vpc_id                        = aws_vpc.main.id
+      vpc_cidr_block                = aws_vpc.main.cidr_block
+      web_security_group_id         = aws_security_group.web.id
+      application_security_group_id = aws_security_group.application.id
+      database_security_group_id    = aws_security_group.database.id
+    },
+    {
+      for name, subnet_ids in {
+        public_subnet_ids   = aws_subnet.public[*].id
+        private_subnet_ids  = aws_subnet.private[*].id
+        database_subnet_ids = aws_subnet.database[*].id
+      } : name => join(",", subnet_ids) if length(subnet_ids) > 0
+    }
+  )
+}
+
+resource "aws_ssm_parameter" "outputs" {
+  for_each = var.export_outputs_to_ssm ? local.ssm_exported_outputs : {}
+
+  name        = "${local.ssm_parameter_prefix}/${each.key}"
+  description = "${each.key} exported by the ${var.project_name}-${var.environment} shared-networking module"
+  type        = endswith(each.key, "_ids") ? "StringList" : "String"
+  value       = each.value
+
+  tags = local.common_tags
+}
@@ -11,6 +11,21 @@ output "vpc_cidr_block" {
   value       = aws_vpc.main.cidr_block
 }
 
+output "vpc_ipv6_cidr_block" {
+  description = "Amazon-provided IPv6 CIDR block of the VPC (null when ip_address_type is ipv4)"
+  value       = local.enable_ipv6 ? aws_vpc.main.ipv6_cidr_block : null
+}
+
+output "ip_address_type" {
+  description = "Address families of the VPC (ipv4, dualstack, or ipv6)"
+  value       = var.ip_address_type
+}
+
+output "dhcp_options_id" {
+  description = "ID of the custom DHCP options set (if configured)"
+  value       = var.dhcp_options != null ? aws_vpc_dhcp_options.main[0].id : null
+}
+
 output "vpc_arn" {
   description = "ARN of the VPC"
   value       = aws_vpc.main.arn
@@ -22,6 +37,11 @@ output "internet_gateway_id" {
   value       = aws_internet_gateway.main.id
 }
 
+output "egress_only_internet_gateway_id" {
+  description = "ID of the egress-only Internet Gateway (null when ip_address_type is ipv4)"
+  value       = local.enable_ipv6 ? aws_egress_only_internet_gateway.main[0].id : null
+}
+
 # Subnet Outputs
 output "public_subnet_ids" {
   description = "IDs of the public subnets"
@@ -38,6 +58,21 @@ output "database_subnet_ids" {
   value       = aws_subnet.database[*].id
 }
 
+output "public_subnet_ipv6_cidr_blocks" {
+  description = "IPv6 CIDR blocks of the public subnets (empty when ip_address_type is ipv4)"
+  value       = local.enable_ipv6 ? aws_subnet.public[*].ipv6_cidr_block : []
+}
+
+output "private_subnet_ipv6_cidr_blocks" {
+  description = "IPv6 CIDR blocks of the private subnets (empty when ip_address_type is ipv4)"
+  value       = local.enable_ipv6 ? aws_subnet.private[*].ipv6_cidr_block : []
+}
+
+output "database_subnet_ipv6_cidr_blocks" {
+  description = "IPv6 CIDR blocks of the database subnets (empty when ip_address_type is ipv4)"
+  value       = local.enable_ipv6 ? aws_subnet.database[*].ipv6_cidr_block : []
+}
+
 output "public_subnet_arns" {
   description = "ARNs of the public subnets"
   value       = aws_subnet.public[*].arn
@@ -60,8 +95,14 @@ output "nat_gateway_ids" {
 }
 
 output "nat_gateway_public_ips" {
-  description = "Public IPs of the NAT Gateways"
-  value       = aws_eip.nat[*].public_ip
+  description = "Public IPs of the NAT Gateways (created or pre-allocated Elastic IPs)"
+  value       = aws_nat_gateway.main[*].public_ip
+}
+
+# Transit Gateway
+output "transit_gateway_attachment_id" {
+  description = "ID of the transit gateway VPC attachment (if transit_gateway_id is set)"
+  value       = var.transit_gateway_id != null ? aws_ec2_transit_gateway_vpc_attachment.main[0].id : null
 }
 
 # Route Table Outputs
@@ -109,8 +150,26 @@ output "db_subnet_group_arn" {
 
 # Availability Zones
 output "availability_zones" {
-  description = "List of availability zones used"
-  value       = data.aws_availability_zones.available.names
+  description = "List of availability zones subnets are distributed over (the pinned zones, or every available zone)"
+  value       = local.availability_zones
+}
+
+output "subnet_availability_zones" {
+  description = "Availability zones of each tier's subnets, in subnet order, keyed by tier (public, private, database)"
+  value = {
+    public   = aws_subnet.public[*].availability_zone
+    private  = aws_subnet.private[*].availability_zone
+    database = aws_subnet.database[*].availability_zone
+  }
+}
+
+output "subnet_cidr_blocks" {
+  description = "IPv4 CIDR blocks of each tier's subnets, in subnet order, keyed by tier (private is empty when ip_address_type is ipv6)"
+  value = {
+    public   = local.public_subnet_cidrs
+    private  = local.ipv6_only_private ? [] : local.private_subnet_cidrs
+    database = local.database_subnet_cidrs
+  }
 }
 
 # VPC Endpoints
@@ -129,9 +188,19 @@ output "dynamodb_vpc_endpoint_id" {
   value       = var.enable_vpc_endpoints ? aws_vpc_endpoint.dynamodb[0].id : null
 }
 
+output "secretsmanager_vpc_endpoint_id" {
+  description = "ID of the Secrets Manager VPC endpoint (if secrets access endpoints are enabled)"
+  value       = var.enable_secrets_access_endpoints ? aws_vpc_endpoint.secrets_access["secretsmanager"].id : null
+}
+
+output "kms_vpc_endpoint_id" {
+  description = "ID of the KMS VPC endpoint (if secrets access endpoints are enabled)"
+  value       = var.enable_secrets_access_endpoints ? aws_vpc_endpoint.secrets_access["kms"].id : null
+}
+
 output "vpc_endpoints_security_group_id" {
   description = "ID of the security group for VPC endpoints"
-  value       = var.enable_vpc_endpoints ? aws_security_group.vpc_endpoints[0].id : null
+  value       = var.enable_vpc_endpoints || var.enable_secrets_access_endpoints ? aws_security_group.vpc_endpoints[0].id : null
 }
 
 output "vpc_endpoints_route_table_id" {
@@ -145,14 +214,39 @@ output "network_acl_id" {
   value       = aws_network_acl.main.id
 }
 
+output "network_acl_ids" {
+  description = "IDs of the tier Network ACLs keyed by tier (public, private, database)"
+  value       = { for tier, acl in aws_network_acl.tier : tier => acl.id }
+}
+
 output "vpc_flow_log_group_name" {
   description = "Name of the VPC Flow Logs CloudWatch Log Group"
-  value       = var.enable_flow_logs ? aws_cloudwatch_log_group.vpc_flow_log[0].name : null
+  value       = local.flow_logs_to_cloudwatch ? aws_cloudwatch_log_group.vpc_flow_log[0].name : null
+}
+
+output "vpc_flow_log_group_arn" {
+  description = "ARN of the VPC Flow Logs CloudWatch Log Group"
+  value       = local.flow_logs_to_cloudwatch ? aws_cloudwatch_log_group.vpc_flow_log[0].arn : null
+}
+
+output "subnet_flow_log_ids" {
+  description = "Map of subnet flow log IDs keyed by subnet ID"
+  value       = { for key, subnet_id in local.subnet_flow_log_subnet_ids : subnet_id => aws_flow_log.subnet[key].id }
+}
+
+output "vpc_flow_logs_kms_key_arn" {
+  description = "ARN of the KMS key encrypting the VPC Flow Logs CloudWatch Log Group (null when unencrypted)"
+  value       = local.flow_logs_kms_key_arn
+}
+
+output "vpc_flow_log_destination_arn" {
+  description = "ARN of the resolved VPC Flow Logs destination (log group or S3 bucket)"
+  value       = local.flow_logs_destination_arn
 }
 
 output "security_insights_query_names" {
   description = "Names of CloudWatch Insights queries for security monitoring"
-  value = var.enable_flow_logs ? [
+  value = local.flow_logs_to_cloudwatch ? [
     aws_cloudwatch_query_definition.vpc_flow_log_security[0].name,
     aws_cloudwatch_query_definition.vpc_flow_log_top_talkers[0].name
   ] : []
@@ -166,4 +260,141 @@ output "security_alarm_arn" {
 output "cloudtrail_vpc_endpoint_id" {
   description = "ID of the CloudTrail VPC endpoint"
   value       = var.enable_vpc_endpoints ? aws_vpc_endpoint.cloudtrail[0].id : null
-}
\ No newline at end of file
+}
+
+# Subnet IP Monitoring
+output "subnet_ip_monitor_lambda_arn" {
+  description = "ARN of the Lambda function publishing subnet available IP counts"
+  value       = var.enable_subnet_ip_alarm ? aws_lambda_function.subnet_ip_monitor[0].arn : null
+}
+
+output "subnet_ip_alarm_arns" {
+  description = "ARNs of the low available IP alarms keyed by subnet (e.g., private-1)"
+  value       = { for key, alarm in aws_cloudwatch_metric_alarm.subnet_available_ips : key => alarm.arn }
+}
+
+# Routing Summary
+# Classifies each tier from its route tables. IPv4 egress is "internet" (default
+# route via the Internet Gateway), "nat_egress" (via a NAT Gateway or the NAT
+# instance interface), "transit_gateway" (default route via the transit gateway)
+# or "isolated". IPv6 egress is "internet", "egress_only" (via the egress-only
+# Internet Gateway) or "none". Tiers whose route tables disagree report "mixed".
+locals {
+  tier_route_tables = {
+    public   = [aws_route_table.public]
+    private  = aws_route_table.private
+    database = [aws_route_table.database]
+  }
+
+  tier_subnet_counts = {
+    public   = var.public_subnet_count
+    private  = var.private_subnet_count
+    database = var.database_subnet_count
+  }
+
+  route_table_egress = {
+    for tier, tables in local.tier_route_tables : tier => distinct([
+      for table in tables : (
+        anytrue([for route in table.route : route.cidr_block == "0.0.0.0/0" && startswith(route.gateway_id, "igw-")]) ? "internet" :
+        anytrue([for route in table.route : route.nat_gateway_id != "" || (route.cidr_block == "0.0.0.0/0" && route.network_interface_id != "")]) ? "nat_egress" :
+        anytrue([for route in table.route : route.cidr_block == "0.0.0.0/0" && route.transit_gateway_id != ""]) ? "transit_gateway" : "isolated"
+      )
+    ])
+  }
+
+  route_table_ipv6_egress = {
+    for tier, tables in local.tier_route_tables : tier => distinct([
+      for table in tables : (
+        anytrue([for route in table.route : route.ipv6_cidr_block == "::/0" && startswith(route.gateway_id, "igw-")]) ? "internet" :
+        anytrue([for route in table.route : route.ipv6_cidr_block == "::/0" && route.egress_only_gateway_id != ""]) ? "egress_only" : "none"
+      )
+    ])
+  }
+
+  route_table_transit_gateway_cidrs = {
+    for tier, tables in local.tier_route_tables : tier => sort(distinct(flatten([
+      for table in tables : [for route in table.route : route.cidr_block if route.transit_gateway_id != ""]
+    ])))
+  }
+}
+
+output "routing_summary" {
+  description = "Per-tier IPv4 egress (internet, nat_egress, transit_gateway, isolated, or mixed), IPv6 egress (internet, egress_only, none, or mixed), and transit gateway destinations, with the route tables they were derived from"
+  value = {
+    for tier, tables in local.tier_route_tables : tier => {
+      egress                = length(local.route_table_egress[tier]) == 1 ? local.route_table_egress[tier][0] : "mixed"
+      ipv6_egress           = length(local.route_table_ipv6_egress[tier]) == 1 ? local.route_table_ipv6_egress[tier][0] : "mixed"
+      transit_gateway_cidrs = local.route_table_transit_gateway_cidrs[tier]
+      route_table_ids       = [for table in tables : table.id]
+      subnet_count          = local.tier_subnet_counts[tier]
+    }
+  }
+}
+
+output "ssm_parameter_names" {
+  description = "Map of exported output name to SSM parameter name (empty unless export_outputs_to_ssm is true)"
+  value       = { for name, parameter in aws_ssm_parameter.outputs : name => parameter.name }
+}
+
+output "common_tags" {
+  description = "Tags applied to every taggable resource in the module"
+  value       = local.common_tags
+}
+
+# Bastion
+output "bastion_instance_id" {
+  description = "ID of the bastion host (if enabled)"
+  value       = var.enable_bastion ? aws_instance.bastion[0].id : null
+}
+
+output "bastion_public_ip" {
+  description = "Public IP address of the bastion host (if enabled)"
+  value       = var.enable_bastion ? aws_instance.bastion[0].public_ip : null
+}
+
+output "bastion_recovery_alarm_arn" {
+  description = "ARN of the bastion host system status check recovery alarm (if enabled)"
+  value       = var.enable_bastion && var.enable_ec2_auto_recovery ? aws_cloudwatch_metric_alarm.bastion_recovery[0].arn : null
+}
+
+output "bastion_security_group_id" {
+  description = "ID of the bastion security group (if enabled)"
+  value       = var.enable_bastion ? aws_security_group.bastion[0].id : null
+}
+
+# Deployment Summary
+output "deployment_summary" {
+  description = "Consolidated map of key networking and security identifiers for CI pipelines"
+  value = {
+    networking = {
+      vpc_id                        = aws_vpc.main.id
+      vpc_cidr_block                = aws_vpc.main.cidr_block
+      vpc_ipv6_cidr_block           = local.enable_ipv6 ? aws_vpc.main.ipv6_cidr_block : null
+      internet_gateway_id           = aws_internet_gateway.main.id
+      egress_only_gateway_id        = local.enable_ipv6 ? aws_egress_only_internet_gateway.main[0].id : null
+      availability_zones            = local.availability_zones
+      public_subnet_ids             = aws_subnet.public[*].id
+      private_subnet_ids            = aws_subnet.private[*].id
+      database_subnet_ids           = aws_subnet.database[*].id
+      nat_gateway_ids               = aws_nat_gateway.main[*].id
+      nat_gateway_public_ips        = aws_nat_gateway.main[*].public_ip
+      public_route_table_id         = aws_route_table.public.id
+      private_route_table_ids       = aws_route_table.private[*].id
+      database_route_table_id       = aws_route_table.database.id
+      db_subnet_group_name          = var.database_subnet_count > 0 ? aws_db_subnet_group.main[0].name : null
+      transit_gateway_attachment_id = var.transit_gateway_id != null ? aws_ec2_transit_gateway_vpc_attachment.main[0].id : null
+    }
+    security = {
+      web_security_group_id           = aws_security_group.web.id
+      application_security_group_id   = aws_security_group.application.id
+      database_security_group_id      = aws_security_group.database.id
+      vpc_endpoints_security_group_id = var.enable_vpc_endpoints ? aws_security_group.vpc_endpoints[0].id : null
+      bastion_security_group_id       = var.enable_bastion ? aws_security_group.bastion[0].id : null
+      network_acl_id                  = aws_network_acl.main.id
+      network_acl_ids                 = { for tier, acl in aws_network_acl.tier : tier => acl.id }
+      vpc_flow_log_group_name         = local.flow_logs_to_cloudwatch ? aws_cloudwatch_log_group.vpc_flow_log[0].name : null
+      vpc_flow_log_destination_arn    = local.flow_logs_destination_arn
+      vpc_flow_logs_kms_key_arn       = local.flow_logs_kms_key_arn
+    }
+  }
+}
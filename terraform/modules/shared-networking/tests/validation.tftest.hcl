@@ -0,0 +1,715 @@
+# Validation Rules - Shared Networking Module
+# Plans against a mocked AWS provider so every custom variable validation can be
+# exercised without credentials or AWS API calls. Each run sets one invalid input
+# on top of the valid defaults below and expects only that validation to fail.
+#
+#   terraform test -filter=tests/validation.tftest.hcl
+
+test {
+  parallel = true
+}
+
+mock_provider "aws" {
+  mock_data "aws_availability_zones" {
+    defaults = {
+      names    = ["us-east-1a", "us-east-1b", "us-east-1c"]
+      zone_ids = ["use1-az1", "use1-az2", "use1-az4"]
+    }
+  }
+}
+
+variables {
+  project_name = "test-epic"
+  environment  = "staging"
+}
+
+run "valid_defaults" {
+  command   = plan
+  state_key = "valid_defaults"
+}
+
+run "invalid_project_name" {
+  command   = plan
+  state_key = "invalid_project_name"
+
+  variables {
+    project_name = "1-epic"
+  }
+
+  expect_failures = [var.project_name]
+}
+
+run "invalid_environment" {
+  command   = plan
+  state_key = "invalid_environment"
+
+  variables {
+    environment = "development"
+  }
+
+  expect_failures = [var.environment]
+}
+
+run "vpc_cidr_prefix_too_short" {
+  command   = plan
+  state_key = "vpc_cidr_prefix_too_short"
+
+  variables {
+    vpc_cidr = "10.0.0.0/12"
+  }
+
+  expect_failures = [var.vpc_cidr]
+}
+
+run "invalid_ip_address_type" {
+  command   = plan
+  state_key = "invalid_ip_address_type"
+
+  variables {
+    ip_address_type = "ipv5"
+  }
+
+  expect_failures = [var.ip_address_type]
+}
+
+run "ipv6_only_private_subnets_with_vpc_endpoints" {
+  command   = plan
+  state_key = "ipv6_only_private_subnets_with_vpc_endpoints"
+
+  variables {
+    ip_address_type = "ipv6"
+  }
+
+  expect_failures = [var.ip_address_type]
+}
+
+run "too_many_dhcp_name_servers" {
+  command   = plan
+  state_key = "too_many_dhcp_name_servers"
+
+  variables {
+    dhcp_options = {
+      domain_name_servers = ["10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"]
+    }
+  }
+
+  expect_failures = [var.dhcp_options]
+}
+
+run "invalid_dhcp_name_server" {
+  command   = plan
+  state_key = "invalid_dhcp_name_server"
+
+  variables {
+    dhcp_options = {
+      domain_name_servers = ["dns.example.com"]
+    }
+  }
+
+  expect_failures = [var.dhcp_options]
+}
+
+run "invalid_availability_zone_name" {
+  command   = plan
+  state_key = "invalid_availability_zone_name"
+
+  variables {
+    availability_zones = ["us-east-1", "us-east-1b", "us-east-1c"]
+  }
+
+  expect_failures = [var.availability_zones]
+}
+
+run "duplicate_availability_zones" {
+  command   = plan
+  state_key = "duplicate_availability_zones"
+
+  variables {
+    availability_zones = ["us-east-1a", "us-east-1a", "us-east-1b"]
+  }
+
+  expect_failures = [var.availability_zones]
+}
+
+run "more_subnets_than_pinned_availability_zones" {
+  command   = plan
+  state_key = "more_subnets_than_pinned_availability_zones"
+
+  variables {
+    availability_zones = ["us-east-1a", "us-east-1b"]
+  }
+
+  expect_failures = [var.availability_zones]
+}
+
+run "public_subnet_count_too_high" {
+  command   = plan
+  state_key = "public_subnet_count_too_high"
+
+  variables {
+    public_subnet_count = 7
+  }
+
+  expect_failures = [var.public_subnet_count]
+}
+
+run "private_subnet_count_zero" {
+  command   = plan
+  state_key = "private_subnet_count_zero"
+
+  variables {
+    private_subnet_count = 0
+  }
+
+  expect_failures = [var.private_subnet_count]
+}
+
+run "database_subnet_count_too_high" {
+  command   = plan
+  state_key = "database_subnet_count_too_high"
+
+  variables {
+    database_subnet_count = 7
+  }
+
+  expect_failures = [var.database_subnet_count]
+}
+
+run "public_subnet_newbits_below_slash_28" {
+  command   = plan
+  state_key = "public_subnet_newbits_below_slash_28"
+
+  variables {
+    public_subnet_newbits = 13
+  }
+
+  expect_failures = [var.public_subnet_newbits]
+}
+
+run "private_subnet_newbits_zero" {
+  command   = plan
+  state_key = "private_subnet_newbits_zero"
+
+  variables {
+    private_subnet_newbits = 0
+  }
+
+  expect_failures = [var.private_subnet_newbits]
+}
+
+run "database_subnet_newbits_below_slash_28" {
+  command   = plan
+  state_key = "database_subnet_newbits_below_slash_28"
+
+  variables {
+    database_subnet_newbits = 13
+  }
+
+  expect_failures = [var.database_subnet_newbits]
+}
+
+run "public_subnet_cidrs_count_mismatch" {
+  command   = plan
+  state_key = "public_subnet_cidrs_count_mismatch"
+
+  variables {
+    public_subnet_cidrs = ["10.0.200.0/24"]
+  }
+
+  expect_failures = [var.public_subnet_cidrs]
+}
+
+run "public_subnet_cidr_outside_vpc" {
+  command   = plan
+  state_key = "public_subnet_cidr_outside_vpc"
+
+  variables {
+    public_subnet_cidrs = ["10.0.200.0/24", "10.0.201.0/24", "10.1.0.0/24"]
+  }
+
+  expect_failures = [var.public_subnet_cidrs]
+}
+
+run "duplicate_public_subnet_cidrs" {
+  command   = plan
+  state_key = "duplicate_public_subnet_cidrs"
+
+  variables {
+    public_subnet_cidrs = ["10.0.200.0/24", "10.0.200.0/24", "10.0.201.0/24"]
+  }
+
+  expect_failures = [var.public_subnet_cidrs]
+}
+
+run "private_subnet_cidrs_count_mismatch" {
+  command   = plan
+  state_key = "private_subnet_cidrs_count_mismatch"
+
+  variables {
+    private_subnet_cidrs = ["10.0.64.0/19"]
+  }
+
+  expect_failures = [var.private_subnet_cidrs]
+}
+
+run "private_subnet_cidr_with_host_bits" {
+  command   = plan
+  state_key = "private_subnet_cidr_with_host_bits"
+
+  variables {
+    private_subnet_cidrs = ["10.0.64.0/19", "10.0.96.0/19", "10.0.128.1/19"]
+  }
+
+  expect_failures = [var.private_subnet_cidrs]
+}
+
+run "duplicate_private_subnet_cidrs" {
+  command   = plan
+  state_key = "duplicate_private_subnet_cidrs"
+
+  variables {
+    private_subnet_cidrs = ["10.0.64.0/19", "10.0.64.0/19", "10.0.96.0/19"]
+  }
+
+  expect_failures = [var.private_subnet_cidrs]
+}
+
+run "private_subnet_cidrs_with_ipv6_only_private_subnets" {
+  command   = plan
+  state_key = "private_subnet_cidrs_with_ipv6_only_private_subnets"
+
+  variables {
+    ip_address_type      = "ipv6"
+    enable_vpc_endpoints = false
+    private_subnet_cidrs = ["10.0.64.0/19", "10.0.96.0/19", "10.0.128.0/19"]
+  }
+
+  expect_failures = [var.private_subnet_cidrs]
+}
+
+run "database_subnet_cidrs_count_mismatch" {
+  command   = plan
+  state_key = "database_subnet_cidrs_count_mismatch"
+
+  variables {
+    database_subnet_cidrs = ["10.0.250.0/26"]
+  }
+
+  expect_failures = [var.database_subnet_cidrs]
+}
+
+run "database_subnet_cidr_smaller_than_slash_28" {
+  command   = plan
+  state_key = "database_subnet_cidr_smaller_than_slash_28"
+
+  variables {
+    database_subnet_cidrs = ["10.0.250.0/26", "10.0.250.64/26", "10.0.250.128/29"]
+  }
+
+  expect_failures = [var.database_subnet_cidrs]
+}
+
+run "duplicate_database_subnet_cidrs" {
+  command   = plan
+  state_key = "duplicate_database_subnet_cidrs"
+
+  variables {
+    database_subnet_cidrs = ["10.0.250.0/26", "10.0.250.0/26", "10.0.250.64/26"]
+  }
+
+  expect_failures = [var.database_subnet_cidrs]
+}
+
+run "nat_gateway_count_zero" {
+  command   = plan
+  state_key = "nat_gateway_count_zero"
+
+  variables {
+    nat_gateway_count = 0
+  }
+
+  expect_failures = [var.nat_gateway_count]
+}
+
+run "invalid_nat_gateway_eip_allocation_id" {
+  command   = plan
+  state_key = "invalid_nat_gateway_eip_allocation_id"
+
+  variables {
+    nat_gateway_eip_allocation_ids = ["eip-0123456789abcdef0", "eipalloc-0123456789abcdef1"]
+  }
+
+  expect_failures = [var.nat_gateway_eip_allocation_ids]
+}
+
+run "duplicate_nat_gateway_eip_allocation_ids" {
+  command   = plan
+  state_key = "duplicate_nat_gateway_eip_allocation_ids"
+
+  variables {
+    nat_gateway_eip_allocation_ids = ["eipalloc-0123456789abcdef0", "eipalloc-0123456789abcdef0"]
+  }
+
+  expect_failures = [var.nat_gateway_eip_allocation_ids]
+}
+
+run "nat_gateway_eip_allocation_count_mismatch" {
+  command   = plan
+  state_key = "nat_gateway_eip_allocation_count_mismatch"
+
+  variables {
+    nat_gateway_eip_allocation_ids = ["eipalloc-0123456789abcdef0"]
+  }
+
+  expect_failures = [var.nat_gateway_eip_allocation_ids]
+}
+
+run "invalid_nat_instance_network_interface_id" {
+  command   = plan
+  state_key = "invalid_nat_instance_network_interface_id"
+
+  variables {
+    enable_nat_gateway                = false
+    nat_instance_network_interface_id = "i-0123456789abcdef0"
+  }
+
+  expect_failures = [var.nat_instance_network_interface_id]
+}
+
+run "nat_instance_network_interface_with_nat_gateway" {
+  command   = plan
+  state_key = "nat_instance_network_interface_with_nat_gateway"
+
+  variables {
+    enable_nat_gateway                = true
+    nat_instance_network_interface_id = "eni-0123456789abcdef0"
+  }
+
+  expect_failures = [var.nat_instance_network_interface_id]
+}
+
+run "database_egress_without_nat_gateway" {
+  command   = plan
+  state_key = "database_egress_without_nat_gateway"
+
+  variables {
+    enable_nat_gateway              = false
+    database_subnet_internet_egress = true
+  }
+
+  expect_failures = [var.database_subnet_internet_egress]
+}
+
+run "invalid_transit_gateway_id" {
+  command   = plan
+  state_key = "invalid_transit_gateway_id"
+
+  variables {
+    transit_gateway_id = "transit-0123456789abcdef0"
+  }
+
+  expect_failures = [var.transit_gateway_id]
+}
+
+run "invalid_transit_gateway_route" {
+  command   = plan
+  state_key = "invalid_transit_gateway_route"
+
+  variables {
+    transit_gateway_id     = "tgw-0123456789abcdef0"
+    transit_gateway_routes = ["172.16.0.0/33"]
+  }
+
+  expect_failures = [var.transit_gateway_routes]
+}
+
+run "transit_gateway_routes_without_attachment" {
+  command   = plan
+  state_key = "transit_gateway_routes_without_attachment"
+
+  variables {
+    transit_gateway_routes = ["172.16.0.0/12"]
+  }
+
+  expect_failures = [var.transit_gateway_routes]
+}
+
+run "transit_gateway_route_overlapping_vpc_cidr" {
+  command   = plan
+  state_key = "transit_gateway_route_overlapping_vpc_cidr"
+
+  variables {
+    transit_gateway_id     = "tgw-0123456789abcdef0"
+    transit_gateway_routes = ["10.0.0.0/8"]
+  }
+
+  expect_failures = [var.transit_gateway_routes]
+}
+
+run "invalid_subnet_flow_logs_tier" {
+  command   = plan
+  state_key = "invalid_subnet_flow_logs_tier"
+
+  variables {
+    subnet_flow_logs = ["isolated"]
+  }
+
+  expect_failures = [var.subnet_flow_logs]
+}
+
+run "duplicate_subnet_flow_logs_tier" {
+  command   = plan
+  state_key = "duplicate_subnet_flow_logs_tier"
+
+  variables {
+    subnet_flow_logs = ["public", "public"]
+  }
+
+  expect_failures = [var.subnet_flow_logs]
+}
+
+run "invalid_flow_logs_retention_days" {
+  command   = plan
+  state_key = "invalid_flow_logs_retention_days"
+
+  variables {
+    flow_logs_retention_days = 10
+  }
+
+  expect_failures = [var.flow_logs_retention_days]
+}
+
+run "invalid_flow_logs_kms_key_id" {
+  command   = plan
+  state_key = "invalid_flow_logs_kms_key_id"
+
+  variables {
+    flow_logs_kms_key_id = "alias/flow-logs"
+  }
+
+  expect_failures = [var.flow_logs_kms_key_id]
+}
+
+run "flow_logs_kms_key_with_s3_destination" {
+  command   = plan
+  state_key = "flow_logs_kms_key_with_s3_destination"
+
+  variables {
+    flow_logs_destination_type = "s3"
+    flow_logs_kms_key_id       = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+  }
+
+  expect_failures = [var.flow_logs_kms_key_id]
+}
+
+run "flow_logs_encrypt_with_s3_destination" {
+  command   = plan
+  state_key = "flow_logs_encrypt_with_s3_destination"
+
+  variables {
+    flow_logs_destination_type = "s3"
+    flow_logs_encrypt          = true
+  }
+
+  expect_failures = [var.flow_logs_encrypt]
+}
+
+run "invalid_flow_logs_destination_type" {
+  command   = plan
+  state_key = "invalid_flow_logs_destination_type"
+
+  variables {
+    flow_logs_destination_type = "kinesis"
+  }
+
+  expect_failures = [var.flow_logs_destination_type]
+}
+
+run "invalid_flow_logs_s3_bucket_arn" {
+  command   = plan
+  state_key = "invalid_flow_logs_s3_bucket_arn"
+
+  variables {
+    flow_logs_destination_type = "s3"
+    flow_logs_s3_bucket_arn    = "flow-logs-bucket"
+  }
+
+  expect_failures = [var.flow_logs_s3_bucket_arn]
+}
+
+run "invalid_flow_logs_traffic_type" {
+  command   = plan
+  state_key = "invalid_flow_logs_traffic_type"
+
+  variables {
+    flow_logs_traffic_type = "DROPPED"
+  }
+
+  expect_failures = [var.flow_logs_traffic_type]
+}
+
+run "invalid_flow_logs_log_format" {
+  command   = plan
+  state_key = "invalid_flow_logs_log_format"
+
+  variables {
+    flow_logs_log_format = "srcaddr dstaddr"
+  }
+
+  expect_failures = [var.flow_logs_log_format]
+}
+
+run "invalid_network_acl_tier" {
+  command   = plan
+  state_key = "invalid_network_acl_tier"
+
+  variables {
+    network_acl_rules = {
+      bastion = {
+        ingress = []
+        egress  = []
+      }
+    }
+  }
+
+  expect_failures = [var.network_acl_rules]
+}
+
+run "duplicate_network_acl_rule_numbers" {
+  command   = plan
+  state_key = "duplicate_network_acl_rule_numbers"
+
+  variables {
+    network_acl_rules = {
+      public = {
+        ingress = [
+          { rule_number = 100, cidr_block = "10.0.0.0/16" },
+          { rule_number = 100, cidr_block = "10.1.0.0/16" }
+        ]
+        egress = []
+      }
+    }
+  }
+
+  expect_failures = [var.network_acl_rules]
+}
+
+run "invalid_network_acl_action" {
+  command   = plan
+  state_key = "invalid_network_acl_action"
+
+  variables {
+    network_acl_rules = {
+      public = {
+        ingress = [{ rule_number = 100, action = "permit", cidr_block = "10.0.0.0/16" }]
+        egress  = []
+      }
+    }
+  }
+
+  expect_failures = [var.network_acl_rules]
+}
+
+run "network_acl_rule_without_cidr" {
+  command   = plan
+  state_key = "network_acl_rule_without_cidr"
+
+  variables {
+    network_acl_rules = {
+      public = {
+        ingress = [{ rule_number = 100 }]
+        egress  = []
+      }
+    }
+  }
+
+  expect_failures = [var.network_acl_rules]
+}
+
+run "application_egress_rule_without_destination" {
+  command   = plan
+  state_key = "application_egress_rule_without_destination"
+
+  variables {
+    application_egress_rules = [{ from_port = 443, to_port = 443 }]
+  }
+
+  expect_failures = [var.application_egress_rules]
+}
+
+run "invalid_application_egress_cidr" {
+  command   = plan
+  state_key = "invalid_application_egress_cidr"
+
+  variables {
+    application_egress_rules = [{ from_port = 443, to_port = 443, cidr_blocks = ["10.0.0.0/33"] }]
+  }
+
+  expect_failures = [var.application_egress_rules]
+}
+
+run "invalid_application_egress_security_group" {
+  command   = plan
+  state_key = "invalid_application_egress_security_group"
+
+  variables {
+    application_egress_rules = [{ from_port = 5432, to_port = 5432, security_group_ids = ["database-sg"] }]
+  }
+
+  expect_failures = [var.application_egress_rules]
+}
+
+run "invalid_application_egress_port_range" {
+  command   = plan
+  state_key = "invalid_application_egress_port_range"
+
+  variables {
+    application_egress_rules = [{ from_port = 443, to_port = 80, cidr_blocks = ["10.0.0.0/8"] }]
+  }
+
+  expect_failures = [var.application_egress_rules]
+}
+
+run "subnet_ip_alarm_threshold_zero" {
+  command   = plan
+  state_key = "subnet_ip_alarm_threshold_zero"
+
+  variables {
+    subnet_ip_alarm_threshold = 0
+  }
+
+  expect_failures = [var.subnet_ip_alarm_threshold]
+}
+
+run "bastion_without_allowed_cidrs" {
+  command   = plan
+  state_key = "bastion_without_allowed_cidrs"
+
+  variables {
+    enable_bastion = true
+  }
+
+  expect_failures = [var.bastion_allowed_cidrs]
+}
+
+run "invalid_bastion_allowed_cidr" {
+  command   = plan
+  state_key = "invalid_bastion_allowed_cidr"
+
+  variables {
+    bastion_allowed_cidrs = ["203.0.113.0"]
+  }
+
+  expect_failures = [var.bastion_allowed_cidrs]
+}
+
+run "invalid_ssm_parameter_prefix" {
+  command   = plan
+  state_key = "invalid_ssm_parameter_prefix"
+
+  variables {
+    ssm_parameter_prefix = "/epic/staging/"
+  }
+
+  expect_failures = [var.ssm_parameter_prefix]
+}
@@ -28,6 +28,72 @@ variable "vpc_cidr" {
   }
 }
 
+variable "ip_address_type" {
+  description = "Address families: ipv4, dualstack (an Amazon-provided IPv6 block on the VPC and every subnet), or ipv6 (dualstack with IPv6-only private subnets using DNS64 and NAT64)"
+  type        = string
+  default     = "ipv4"
+  validation {
+    condition     = contains(["ipv4", "dualstack", "ipv6"], var.ip_address_type)
+    error_message = "IP address type must be one of: ipv4, dualstack, ipv6."
+  }
+  # Interface endpoints and transit gateway attachments in the private subnets need IPv4 addresses
+  validation {
+    condition     = var.ip_address_type != "ipv6" || (!var.enable_vpc_endpoints && !var.enable_secrets_access_endpoints && var.transit_gateway_id == null)
+    error_message = "IPv6-only private subnets cannot host VPC interface endpoints or transit gateway attachments; disable enable_vpc_endpoints and enable_secrets_access_endpoints and leave transit_gateway_id unset."
+  }
+}
+
+variable "enable_dns_hostnames" {
+  description = "Assign public DNS hostnames to instances with public IP addresses"
+  type        = bool
+  default     = true
+}
+
+variable "enable_dns_support" {
+  description = "Enable the Amazon-provided DNS resolver in the VPC"
+  type        = bool
+  default     = true
+}
+
+variable "dhcp_options" {
+  description = "Custom DHCP options (domain name and DNS servers) associated with the VPC; null keeps the AWS defaults"
+  type = object({
+    domain_name         = optional(string)
+    domain_name_servers = optional(list(string), ["AmazonProvidedDNS"])
+  })
+  default = null
+  validation {
+    condition     = var.dhcp_options == null || try(length(var.dhcp_options.domain_name_servers) >= 1 && length(var.dhcp_options.domain_name_servers) <= 4, false)
+    error_message = "DHCP options must specify between 1 and 4 domain name servers."
+  }
+  validation {
+    condition     = var.dhcp_options == null || try(alltrue([for server in var.dhcp_options.domain_name_servers : server == "AmazonProvidedDNS" || can(cidrhost("${server}/32", 0))]), false)
+    error_message = "DHCP domain name servers must be IPv4 addresses or AmazonProvidedDNS."
+  }
+}
+
+variable "availability_zones" {
+  description = "Availability zones to place subnets in, in order (empty uses every available zone in the region)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for zone in var.availability_zones : can(regex("^[a-z]{2}(-[a-z]+)+-[0-9][a-z]$", zone))])
+    error_message = "Availability zones must be zone names such as us-east-1a."
+  }
+  validation {
+    condition     = length(distinct(var.availability_zones)) == length(var.availability_zones)
+    error_message = "Availability zones must be unique."
+  }
+  validation {
+    condition = length(var.availability_zones) == 0 || (
+      var.public_subnet_count <= length(var.availability_zones) &&
+      var.private_subnet_count <= length(var.availability_zones) &&
+      var.database_subnet_count <= length(var.availability_zones)
+    )
+    error_message = "Each subnet tier must request no more subnets than there are pinned availability zones."
+  }
+}
+
 variable "public_subnet_count" {
   description = "Number of public subnets to create"
   type        = number
@@ -58,6 +124,121 @@ variable "database_subnet_count" {
   }
 }
 
+variable "public_subnet_newbits" {
+  description = "Bits added to the VPC prefix length for each auto-calculated public subnet (8 carves a /24 from a /16)"
+  type        = number
+  default     = 8
+  validation {
+    condition     = var.public_subnet_newbits >= 1 && tonumber(split("/", var.vpc_cidr)[1]) + var.public_subnet_newbits <= 28
+    error_message = "Public subnet newbits must be at least 1 and keep the subnet prefix length at /28 or shorter."
+  }
+}
+
+variable "private_subnet_newbits" {
+  description = "Bits added to the VPC prefix length for each auto-calculated private subnet (8 carves a /24 from a /16)"
+  type        = number
+  default     = 8
+  validation {
+    condition     = var.private_subnet_newbits >= 1 && tonumber(split("/", var.vpc_cidr)[1]) + var.private_subnet_newbits <= 28
+    error_message = "Private subnet newbits must be at least 1 and keep the subnet prefix length at /28 or shorter."
+  }
+}
+
+variable "database_subnet_newbits" {
+  description = "Bits added to the VPC prefix length for each auto-calculated database subnet (8 carves a /24 from a /16)"
+  type        = number
+  default     = 8
+  validation {
+    condition     = var.database_subnet_newbits >= 1 && tonumber(split("/", var.vpc_cidr)[1]) + var.database_subnet_newbits <= 28
+    error_message = "Database subnet newbits must be at least 1 and keep the subnet prefix length at /28 or shorter."
+  }
+}
+
+variable "public_subnet_cidrs" {
+  description = "Explicit IPv4 CIDR blocks of the public subnets, one per subnet, replacing the auto-calculated ranges (empty auto-calculates)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = length(var.public_subnet_cidrs) == 0 || length(var.public_subnet_cidrs) == var.public_subnet_count
+    error_message = "Public subnet CIDRs must list exactly public_subnet_count blocks."
+  }
+  # A block is inside the VPC when its prefix is at least as long and its network address matches at the VPC prefix length
+  validation {
+    condition = alltrue([
+      for cidr in var.public_subnet_cidrs : try(
+        tonumber(split("/", cidr)[1]) >= tonumber(split("/", var.vpc_cidr)[1]) &&
+        tonumber(split("/", cidr)[1]) <= 28 &&
+        cidrhost(cidr, 0) == split("/", cidr)[0] &&
+        cidrhost("${split("/", cidr)[0]}/${split("/", var.vpc_cidr)[1]}", 0) == cidrhost(var.vpc_cidr, 0),
+        false
+      )
+    ])
+    error_message = "Public subnet CIDRs must be network addresses of /28 or larger blocks within the VPC CIDR."
+  }
+  validation {
+    condition     = length(distinct(var.public_subnet_cidrs)) == length(var.public_subnet_cidrs)
+    error_message = "Public subnet CIDRs must be unique."
+  }
+}
+
+variable "private_subnet_cidrs" {
+  description = "Explicit IPv4 CIDR blocks of the private subnets, one per subnet, replacing the auto-calculated ranges (empty auto-calculates)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = length(var.private_subnet_cidrs) == 0 || length(var.private_subnet_cidrs) == var.private_subnet_count
+    error_message = "Private subnet CIDRs must list exactly private_subnet_count blocks."
+  }
+  # A block is inside the VPC when its prefix is at least as long and its network address matches at the VPC prefix length
+  validation {
+    condition = alltrue([
+      for cidr in var.private_subnet_cidrs : try(
+        tonumber(split("/", cidr)[1]) >= tonumber(split("/", var.vpc_cidr)[1]) &&
+        tonumber(split("/", cidr)[1]) <= 28 &&
+        cidrhost(cidr, 0) == split("/", cidr)[0] &&
+        cidrhost("${split("/", cidr)[0]}/${split("/", var.vpc_cidr)[1]}", 0) == cidrhost(var.vpc_cidr, 0),
+        false
+      )
+    ])
+    error_message = "Private subnet CIDRs must be network addresses of /28 or larger blocks within the VPC CIDR."
+  }
+  validation {
+    condition     = length(distinct(var.private_subnet_cidrs)) == length(var.private_subnet_cidrs)
+    error_message = "Private subnet CIDRs must be unique."
+  }
+  validation {
+    condition     = length(var.private_subnet_cidrs) == 0 || var.ip_address_type != "ipv6"
+    error_message = "Private subnet CIDRs cannot be set when ip_address_type is ipv6, since the private subnets are IPv6-only."
+  }
+}
+
+variable "database_subnet_cidrs" {
+  description = "Explicit IPv4 CIDR blocks of the database subnets, one per subnet, replacing the auto-calculated ranges (empty auto-calculates)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = length(var.database_subnet_cidrs) == 0 || length(var.database_subnet_cidrs) == var.database_subnet_count
+    error_message = "Database subnet CIDRs must list exactly database_subnet_count blocks."
+  }
+  # A block is inside the VPC when its prefix is at least as long and its network address matches at the VPC prefix length
+  validation {
+    condition = alltrue([
+      for cidr in var.database_subnet_cidrs : try(
+        tonumber(split("/", cidr)[1]) >= tonumber(split("/", var.vpc_cidr)[1]) &&
+        tonumber(split("/", cidr)[1]) <= 28 &&
+        cidrhost(cidr, 0) == split("/", cidr)[0] &&
+        cidrhost("${split("/", cidr)[0]}/${split("/", var.vpc_cidr)[1]}", 0) == cidrhost(var.vpc_cidr, 0),
+        false
+      )
+    ])
+    error_message = "Database subnet CIDRs must be network addresses of /28 or larger blocks within the VPC CIDR."
+  }
+  validation {
+    condition     = length(distinct(var.database_subnet_cidrs)) == length(var.database_subnet_cidrs)
+    error_message = "Database subnet CIDRs must be unique."
+  }
+}
+
 variable "enable_nat_gateway" {
   description = "Enable NAT Gateway for private subnets"
   type        = bool
@@ -74,14 +255,112 @@ variable "nat_gateway_count" {
   }
 }
 
+variable "nat_gateway_eip_allocation_ids" {
+  description = "Allocation IDs of pre-allocated Elastic IPs for the NAT Gateways, one per gateway, so public IPs survive recreation (new Elastic IPs are created when empty)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for id in var.nat_gateway_eip_allocation_ids : can(regex("^eipalloc-[0-9a-f]+$", id))])
+    error_message = "NAT Gateway EIP allocation IDs must be valid allocation IDs (e.g., eipalloc-0123456789abcdef0)."
+  }
+  validation {
+    condition     = length(distinct(var.nat_gateway_eip_allocation_ids)) == length(var.nat_gateway_eip_allocation_ids)
+    error_message = "NAT Gateway EIP allocation IDs must be unique."
+  }
+  validation {
+    condition     = length(var.nat_gateway_eip_allocation_ids) == 0 || length(var.nat_gateway_eip_allocation_ids) == var.nat_gateway_count
+    error_message = "The number of NAT Gateway EIP allocation IDs must equal nat_gateway_count."
+  }
+}
+
+variable "nat_instance_network_interface_id" {
+  description = "Network interface of a nat-instance module to route private subnet egress through (its network_interface_id output); the route is managed here so applies keep it"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.nat_instance_network_interface_id == null || can(regex("^eni-[0-9a-f]+$", var.nat_instance_network_interface_id))
+    error_message = "NAT instance network interface ID must be a valid network interface ID (e.g., eni-0123456789abcdef0)."
+  }
+  validation {
+    condition     = var.nat_instance_network_interface_id == null || !var.enable_nat_gateway
+    error_message = "A NAT instance network interface cannot be combined with enable_nat_gateway."
+  }
+}
+
+variable "database_subnet_internet_egress" {
+  description = "Route database subnet egress through a NAT Gateway (databases are fully isolated when false)"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !var.database_subnet_internet_egress || var.enable_nat_gateway
+    error_message = "Database subnet internet egress requires enable_nat_gateway to be true."
+  }
+}
+
+# Transit Gateway Configuration
+variable "transit_gateway_id" {
+  description = "Transit gateway to attach the VPC to through the private subnets (no attachment when null)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.transit_gateway_id == null || can(regex("^tgw-[0-9a-f]+$", var.transit_gateway_id))
+    error_message = "Transit gateway ID must be a valid transit gateway ID (tgw-...)."
+  }
+}
+
+variable "transit_gateway_routes" {
+  description = "Destination CIDR blocks routed to the transit gateway from the private route tables"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for cidr in var.transit_gateway_routes : can(cidrhost(cidr, 0))])
+    error_message = "Transit gateway routes must be valid IPv4 CIDR blocks."
+  }
+  validation {
+    condition     = length(var.transit_gateway_routes) == 0 || var.transit_gateway_id != null
+    error_message = "Transit gateway routes require transit_gateway_id to be set."
+  }
+  # Two blocks overlap when their network addresses match at the shorter of the two prefix lengths
+  validation {
+    condition = alltrue([
+      for cidr in var.transit_gateway_routes : try(
+        cidrhost("${split("/", cidr)[0]}/${min(tonumber(split("/", cidr)[1]), tonumber(split("/", var.vpc_cidr)[1]))}", 0) !=
+        cidrhost("${split("/", var.vpc_cidr)[0]}/${min(tonumber(split("/", cidr)[1]), tonumber(split("/", var.vpc_cidr)[1]))}", 0),
+        true
+      )
+    ])
+    error_message = "Transit gateway routes must not overlap the VPC CIDR."
+  }
+}
+
+variable "appliance_mode_support" {
+  description = "Enable appliance mode on the transit gateway attachment so flows stay symmetric through inspection appliances"
+  type        = bool
+  default     = false
+}
+
 variable "enable_flow_logs" {
   description = "Enable VPC Flow Logs"
   type        = bool
   default     = true
 }
 
+variable "subnet_flow_logs" {
+  description = "Subnet tiers (public, private, database) that get their own subnet-level flow logs, delivered to the same destination as the VPC flow logs"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for tier in var.subnet_flow_logs : contains(["public", "private", "database"], tier)])
+    error_message = "Subnet flow logs tiers must be from: public, private, database."
+  }
+  validation {
+    condition     = length(distinct(var.subnet_flow_logs)) == length(var.subnet_flow_logs)
+    error_message = "Subnet flow logs tiers must not contain duplicates."
+  }
+}
+
 variable "flow_logs_retention_days" {
-  description = "Number of days to retain VPC Flow Logs"
+  description = "Number of days to retain VPC Flow Logs (cloud-watch-logs destination only)"
   type        = number
   default     = 14
   validation {
@@ -90,10 +369,274 @@ variable "flow_logs_retention_days" {
   }
 }
 
+variable "flow_logs_encrypt" {
+  description = "Encrypt the flow logs CloudWatch Log Group with a module-created KMS key when flow_logs_kms_key_id is not set"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !var.flow_logs_encrypt || var.flow_logs_destination_type == "cloud-watch-logs"
+    error_message = "Flow logs encryption requires the cloud-watch-logs flow logs destination type; S3 flow logs are encrypted by the bucket's default encryption."
+  }
+}
+
+variable "flow_logs_kms_key_id" {
+  description = "ARN of an existing KMS key to encrypt the flow logs CloudWatch Log Group; its key policy must allow the CloudWatch Logs service principal"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.flow_logs_kms_key_id == null || can(regex("^arn:aws[a-z-]*:kms:[a-z0-9-]+:[0-9]{12}:key/[a-zA-Z0-9-]+$", var.flow_logs_kms_key_id))
+    error_message = "Flow logs KMS key ID must be a KMS key ARN (arn:aws:kms:region:account-id:key/key-id)."
+  }
+  validation {
+    condition     = var.flow_logs_kms_key_id == null || var.flow_logs_destination_type == "cloud-watch-logs"
+    error_message = "Flow logs KMS key ID requires the cloud-watch-logs flow logs destination type."
+  }
+}
+
+variable "flow_logs_destination_type" {
+  description = "Destination for VPC Flow Logs (cloud-watch-logs or s3)"
+  type        = string
+  default     = "cloud-watch-logs"
+  validation {
+    condition     = contains(["cloud-watch-logs", "s3"], var.flow_logs_destination_type)
+    error_message = "Flow logs destination type must be one of: cloud-watch-logs, s3."
+  }
+}
+
+variable "flow_logs_s3_bucket_arn" {
+  description = "ARN of an existing S3 bucket (optionally with a /prefix) for flow logs; null creates a bucket when the destination is s3"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.flow_logs_s3_bucket_arn == null || can(regex("^arn:aws[a-z-]*:s3:::[a-z0-9][a-z0-9.-]+(/.*)?$", var.flow_logs_s3_bucket_arn))
+    error_message = "Flow logs S3 bucket ARN must be a valid S3 bucket ARN (arn:aws:s3:::bucket-name)."
+  }
+}
+
+variable "flow_logs_bucket_force_destroy" {
+  description = "Allow the module-created flow logs bucket to be destroyed while it still contains logs"
+  type        = bool
+  default     = false
+}
+
+variable "flow_logs_traffic_type" {
+  description = "Type of traffic captured by VPC Flow Logs (ACCEPT, REJECT, or ALL)"
+  type        = string
+  default     = "ALL"
+  validation {
+    condition     = contains(["ACCEPT", "REJECT", "ALL"], var.flow_logs_traffic_type)
+    error_message = "Flow logs traffic type must be one of: ACCEPT, REJECT, ALL."
+  }
+}
+
+variable "flow_logs_log_format" {
+  description = "Flow log record format; the default extends the AWS default fields with TCP flags and the packet-level source and destination addresses"
+  type        = string
+  default     = "$${version} $${account-id} $${interface-id} $${srcaddr} $${dstaddr} $${srcport} $${dstport} $${protocol} $${packets} $${bytes} $${start} $${end} $${action} $${log-status} $${tcp-flags} $${pkt-srcaddr} $${pkt-dstaddr}"
+  validation {
+    condition     = can(regex("^\\$\\{[a-z0-9-]+\\}( \\$\\{[a-z0-9-]+\\})*$", var.flow_logs_log_format))
+    error_message = "Flow logs log format must be a space-separated list of $${field} names (e.g., \"$${srcaddr} $${dstaddr} $${tcp-flags}\")."
+  }
+}
+
 
 # VPC Endpoints Configuration
 variable "enable_vpc_endpoints" {
   description = "Enable VPC endpoints for AWS services to improve security and reduce data transfer costs"
   type        = bool
   default     = true
-}
\ No newline at end of file
+}
+
+variable "enable_secrets_access_endpoints" {
+  description = "Create Secrets Manager and KMS interface endpoints with private DNS so private instances fetch secrets without NAT (independent of enable_vpc_endpoints)"
+  type        = bool
+  default     = false
+}
+
+# Network ACL Configuration
+variable "enable_network_acls" {
+  description = "Create a dedicated Network ACL per subnet tier (public, private, database)"
+  type        = bool
+  default     = false
+}
+
+variable "network_acl_rules" {
+  description = "Per-tier Network ACL rules keyed by tier (public, private, database); a tier listed here replaces its default rules"
+  type = map(object({
+    ingress = list(object({
+      rule_number     = number
+      action          = optional(string, "allow")
+      protocol        = optional(string, "-1")
+      from_port       = optional(number, 0)
+      to_port         = optional(number, 0)
+      cidr_block      = optional(string)
+      ipv6_cidr_block = optional(string)
+    }))
+    egress = list(object({
+      rule_number     = number
+      action          = optional(string, "allow")
+      protocol        = optional(string, "-1")
+      from_port       = optional(number, 0)
+      to_port         = optional(number, 0)
+      cidr_block      = optional(string)
+      ipv6_cidr_block = optional(string)
+    }))
+  }))
+  default = {}
+  validation {
+    condition     = alltrue([for tier in keys(var.network_acl_rules) : contains(["public", "private", "database"], tier)])
+    error_message = "Network ACL rule tiers must be one of: public, private, database."
+  }
+  validation {
+    condition = alltrue(flatten([
+      for tier in values(var.network_acl_rules) : [
+        for rules in [tier.ingress, tier.egress] : length(distinct([for rule in rules : rule.rule_number])) == length(rules)
+      ]
+    ]))
+    error_message = "Network ACL rule numbers must be unique within each tier and direction."
+  }
+  validation {
+    condition = alltrue(flatten([
+      for tier in values(var.network_acl_rules) : [
+        for rule in concat(tier.ingress, tier.egress) : rule.rule_number >= 1 && rule.rule_number <= 32766 && contains(["allow", "deny"], rule.action)
+      ]
+    ]))
+    error_message = "Network ACL rule numbers must be between 1 and 32766 and actions must be allow or deny."
+  }
+  validation {
+    condition = alltrue(flatten([
+      for tier in values(var.network_acl_rules) : [
+        for rule in concat(tier.ingress, tier.egress) : (rule.cidr_block == null) != (rule.ipv6_cidr_block == null)
+      ]
+    ]))
+    error_message = "Each Network ACL rule must set exactly one of cidr_block or ipv6_cidr_block."
+  }
+}
+
+# Application Security Group Configuration
+variable "application_egress_rules" {
+  description = "Egress rules for the application security group; when non-empty they replace the default allow-all egress"
+  type = list(object({
+    description        = optional(string)
+    from_port          = number
+    to_port            = number
+    protocol           = optional(string, "tcp")
+    cidr_blocks        = optional(list(string), [])
+    security_group_ids = optional(list(string), [])
+  }))
+  default = []
+  validation {
+    condition = alltrue([
+      for rule in var.application_egress_rules : length(rule.cidr_blocks) > 0 || length(rule.security_group_ids) > 0
+    ])
+    error_message = "Each application egress rule must define at least one CIDR block or security group ID."
+  }
+  validation {
+    condition = alltrue(flatten([
+      for rule in var.application_egress_rules : [for cidr in rule.cidr_blocks : can(cidrhost(cidr, 0))]
+    ]))
+    error_message = "Application egress rule CIDR blocks must be valid IPv4 CIDR notation."
+  }
+  validation {
+    condition = alltrue(flatten([
+      for rule in var.application_egress_rules : [for id in rule.security_group_ids : can(regex("^sg-", id))]
+    ]))
+    error_message = "Application egress rule security group IDs must start with sg-."
+  }
+  validation {
+    condition = alltrue([
+      for rule in var.application_egress_rules : rule.from_port >= 0 && rule.to_port <= 65535 && rule.from_port <= rule.to_port
+    ])
+    error_message = "Application egress rule ports must be between 0 and 65535 with from_port no greater than to_port."
+  }
+}
+
+# Subnet IP Monitoring Configuration
+variable "enable_subnet_ip_alarm" {
+  description = "Publish per-subnet available IP counts from a scheduled Lambda and alarm when they run low"
+  type        = bool
+  default     = false
+}
+
+variable "subnet_ip_alarm_threshold" {
+  description = "Alarm when a subnet has fewer than this many available IP addresses"
+  type        = number
+  default     = 20
+  validation {
+    condition     = var.subnet_ip_alarm_threshold >= 1
+    error_message = "Subnet IP alarm threshold must be at least 1."
+  }
+}
+
+variable "subnet_ip_metric_namespace" {
+  description = "CloudWatch namespace for the custom AvailableIPAddressCount metric"
+  type        = string
+  default     = "EPiC/VPC"
+}
+
+variable "tags" {
+  description = "Tags (e.g., cost_center, owner) applied to every taggable resource; module tags such as Environment and Module take precedence"
+  type        = map(string)
+  default     = {}
+}
+
+# Bastion Configuration
+variable "enable_bastion" {
+  description = "Launch a bastion host in the first public subnet for SSH and Session Manager access"
+  type        = bool
+  default     = false
+}
+
+variable "bastion_allowed_cidrs" {
+  description = "CIDR blocks allowed to reach the bastion on port 22"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = !var.enable_bastion || length(var.bastion_allowed_cidrs) > 0
+    error_message = "At least one bastion allowed CIDR must be provided when the bastion is enabled."
+  }
+  validation {
+    condition     = alltrue([for cidr in var.bastion_allowed_cidrs : can(cidrhost(cidr, 0))])
+    error_message = "Bastion allowed CIDRs must be valid IPv4 CIDR blocks."
+  }
+}
+
+variable "bastion_instance_type" {
+  description = "EC2 instance type for the bastion host"
+  type        = string
+  default     = "t3.micro"
+}
+
+variable "bastion_key_pair_name" {
+  description = "EC2 key pair for SSH access to the bastion (null allows Session Manager access only)"
+  type        = string
+  default     = null
+}
+
+variable "bastion_ami_id" {
+  description = "AMI ID for the bastion host (defaults to the latest Amazon Linux 2023)"
+  type        = string
+  default     = null
+}
+
+variable "enable_ec2_auto_recovery" {
+  description = "Create a CloudWatch alarm that recovers the bastion host onto healthy hardware when its system status check fails"
+  type        = bool
+  default     = true
+}
+
+variable "export_outputs_to_ssm" {
+  description = "Write key outputs (VPC, subnet, and security group IDs) to SSM parameters so other stacks can read them without remote state"
+  type        = bool
+  default     = false
+}
+
+variable "ssm_parameter_prefix" {
+  description = "SSM parameter path prefix for exported outputs (defaults to /<project>/<environment>/shared-networking)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.ssm_parameter_prefix == null || can(regex("^(/[a-zA-Z0-9_.-]+)+$", var.ssm_parameter_prefix))
+    error_message = "SSM parameter prefix must be a path such as /epic/staging/networking: it starts with / and has no trailing slash."
+  }
+}
@@ -0,0 +1,117 @@
+# SSM Patching Module
+# Patch baseline, patch group, and a maintenance window that applies patches on a schedule
+
+locals {
+  name_prefix = "${var.project_name}-${var.environment}"
+  patch_group = var.patch_group != null ? var.patch_group : local.name_prefix
+
+  # Windows classifies patches with Microsoft's update categories rather than the Linux ones
+  patch_classifications = var.patch_classifications != null ? var.patch_classifications : (
+    var.operating_system == "WINDOWS" ? ["SecurityUpdates", "CriticalUpdates"] : ["Security", "Bugfix"]
+  )
+
+  tags = merge(
+    {
+      Environment = var.environment
+      Module      = "ssm-patching"
+    },
+    var.additional_tags
+  )
+}
+
+# Patch Baseline
+resource "aws_ssm_patch_baseline" "main" {
+  name             = "${local.name_prefix}-baseline"
+  description      = "Patch baseline for ${local.name_prefix} instances"
+  operating_system = var.operating_system
+
+  approval_rule {
+    approve_after_days = var.approve_after_days
+    compliance_level   = var.compliance_level
+
+    patch_filter {
+      key    = "CLASSIFICATION"
+      values = local.patch_classifications
+    }
+
+    # Windows patches carry Microsoft's MSRC severity instead of the vendor SEVERITY field
+    patch_filter {
+      key    = var.operating_system == "WINDOWS" ? "MSRC_SEVERITY" : "SEVERITY"
+      values = var.patch_severities
+    }
+  }
+
+  tags = merge(
+    local.tags,
+    {
+      Name = "${local.name_prefix}-baseline"
+    }
+  )
+}
+
+# Instances tagged "Patch Group" = patch_group use this baseline
+resource "aws_ssm_patch_group" "main" {
+  baseline_id = aws_ssm_patch_baseline.main.id
+  patch_group = local.patch_group
+}
+
+# Maintenance Window
+resource "aws_ssm_maintenance_window" "main" {
+  name              = "${local.name_prefix}-patching"
+  description       = "Scheduled patching for the ${local.patch_group} patch group"
+  schedule          = var.maintenance_window_schedule
+  schedule_timezone = var.maintenance_window_timezone
+  duration          = var.maintenance_window_duration
+  cutoff            = var.maintenance_window_cutoff
+
+  tags = merge(
+    local.tags,
+    {
+      Name = "${local.name_prefix}-patching"
+    }
+  )
+}
+
+resource "aws_ssm_maintenance_window_target" "main" {
+  window_id     = aws_ssm_maintenance_window.main.id
+  name          = "${local.name_prefix}-patch-group"
+  description   = "Instances in the ${local.patch_group} patch group"
+  resource_type = "INSTANCE"
+
+  targets {
+    key    = "tag:Patch Group"
+    values = [local.patch_group]
+  }
+}
+
+resource "aws_ssm_maintenance_window_task" "patch" {
+  window_id       = aws_ssm_maintenance_window.main.id
+  name            = "${local.name_prefix}-run-patch-baseline"
+  description     = "Install approved patches with AWS-RunPatchBaseline"
+  task_type       = "RUN_COMMAND"
+  task_arn        = "AWS-RunPatchBaseline"
+  priority        = 1
+  max_concurrency = var.max_concurrency
+  max_errors      = var.max_errors
+
+  targets {
+    key    = "WindowTargetIds"
+    values = [aws_ssm_maintenance_window_target.main.id]
+  }
+
+  task_invocation_parameters {
+    run_command_parameters {
+      timeout_seconds = 3600
+
+      parameter {
+        name   = "Operation"
+        values = ["Install"]
+      }
+
+      parameter {
+        name   = "RebootOption"
+        values = [var.reboot_option]
+      }
+    }
+  }
+}
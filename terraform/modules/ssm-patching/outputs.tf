@@ -0,0 +1,26 @@
+# Outputs for SSM Patching Module
+
+output "patch_baseline_id" {
+  description = "ID of the patch baseline"
+  value       = aws_ssm_patch_baseline.main.id
+}
+
+output "patch_baseline_arn" {
+  description = "ARN of the patch baseline"
+  value       = aws_ssm_patch_baseline.main.arn
+}
+
+output "patch_group" {
+  description = "Patch group name to set as the \"Patch Group\" tag on instances"
+  value       = aws_ssm_patch_group.main.patch_group
+}
+
+output "maintenance_window_id" {
+  description = "ID of the patching maintenance window"
+  value       = aws_ssm_maintenance_window.main.id
+}
+
+output "maintenance_window_schedule" {
+  description = "Schedule of the patching maintenance window"
+  value       = aws_ssm_maintenance_window.main.schedule
+}
@@ -0,0 +1,152 @@
+# Variables for SSM Patching Module
+
+variable "project_name" {
+  description = "Name of the project"
+  type        = string
+  validation {
+    condition     = length(var.project_name) > 0 && length(var.project_name) <= 50 && can(regex("^[a-zA-Z0-9-]+$", var.project_name))
+    error_message = "Project name must be 1-50 characters and contain only alphanumeric characters and hyphens."
+  }
+}
+
+variable "environment" {
+  description = "Environment name (shared, staging, production)"
+  type        = string
+  validation {
+    condition     = contains(["shared", "staging", "production"], var.environment)
+    error_message = "Environment must be one of: shared, staging, production."
+  }
+}
+
+# Patch Baseline Configuration
+variable "operating_system" {
+  description = "Operating system the patch baseline applies to"
+  type        = string
+  default     = "AMAZON_LINUX_2"
+  validation {
+    condition     = contains(["AMAZON_LINUX_2", "AMAZON_LINUX_2023", "WINDOWS"], var.operating_system)
+    error_message = "Operating system must be one of: AMAZON_LINUX_2, AMAZON_LINUX_2023, WINDOWS."
+  }
+}
+
+variable "patch_classifications" {
+  description = "Patch classifications approved by the baseline (defaults to Security and Bugfix on Amazon Linux, SecurityUpdates and CriticalUpdates on Windows)"
+  type        = list(string)
+  default     = null
+  validation {
+    condition     = var.patch_classifications == null || length(coalesce(var.patch_classifications, [])) > 0
+    error_message = "At least one patch classification must be provided."
+  }
+  validation {
+    condition = var.patch_classifications == null || alltrue([
+      for classification in coalesce(var.patch_classifications, []) : contains(
+        var.operating_system == "WINDOWS" ?
+        ["CriticalUpdates", "DefinitionUpdates", "Drivers", "FeaturePacks", "SecurityUpdates", "ServicePacks", "Tools", "UpdateRollups", "Updates", "Upgrades"] :
+        ["Security", "Bugfix", "Enhancement", "Recommended", "Newpackage"],
+        classification
+      )
+    ])
+    error_message = "Patch classifications must be valid for the operating system: Security, Bugfix, Enhancement, Recommended, Newpackage on Amazon Linux; CriticalUpdates, DefinitionUpdates, Drivers, FeaturePacks, SecurityUpdates, ServicePacks, Tools, UpdateRollups, Updates, Upgrades on Windows."
+  }
+}
+
+variable "patch_severities" {
+  description = "Patch severities approved by the baseline"
+  type        = list(string)
+  default     = ["Critical", "Important"]
+  validation {
+    condition     = length(var.patch_severities) > 0
+    error_message = "At least one patch severity must be provided."
+  }
+}
+
+variable "approve_after_days" {
+  description = "Days after release before a patch is auto-approved"
+  type        = number
+  default     = 7
+  validation {
+    condition     = var.approve_after_days >= 0 && var.approve_after_days <= 360
+    error_message = "Approve after days must be between 0 and 360."
+  }
+}
+
+variable "compliance_level" {
+  description = "Compliance severity reported for missing approved patches"
+  type        = string
+  default     = "HIGH"
+  validation {
+    condition     = contains(["CRITICAL", "HIGH", "MEDIUM", "LOW", "INFORMATIONAL", "UNSPECIFIED"], var.compliance_level)
+    error_message = "Compliance level must be one of: CRITICAL, HIGH, MEDIUM, LOW, INFORMATIONAL, UNSPECIFIED."
+  }
+}
+
+variable "patch_group" {
+  description = "Patch group name; instances are targeted by their \"Patch Group\" tag (defaults to <project_name>-<environment>)"
+  type        = string
+  default     = null
+}
+
+# Maintenance Window Configuration
+variable "maintenance_window_schedule" {
+  description = "Schedule of the patching maintenance window as a cron or rate expression"
+  type        = string
+  default     = "cron(0 3 ? * SUN *)"
+  validation {
+    condition     = can(regex("^(cron|rate)\\(.+\\)$", var.maintenance_window_schedule))
+    error_message = "Maintenance window schedule must be a cron() or rate() expression."
+  }
+}
+
+variable "maintenance_window_timezone" {
+  description = "IANA time zone the maintenance window schedule is evaluated in"
+  type        = string
+  default     = "UTC"
+}
+
+variable "maintenance_window_duration" {
+  description = "Length of the maintenance window in hours"
+  type        = number
+  default     = 3
+  validation {
+    condition     = var.maintenance_window_duration >= 1 && var.maintenance_window_duration <= 24
+    error_message = "Maintenance window duration must be between 1 and 24 hours."
+  }
+}
+
+variable "maintenance_window_cutoff" {
+  description = "Hours before the end of the window when no new tasks are started"
+  type        = number
+  default     = 1
+  validation {
+    condition     = var.maintenance_window_cutoff >= 0 && var.maintenance_window_cutoff < var.maintenance_window_duration
+    error_message = "Maintenance window cutoff must be at least 0 and less than the window duration."
+  }
+}
+
+variable "max_concurrency" {
+  description = "Maximum number or percentage of instances patched at the same time"
+  type        = string
+  default     = "25%"
+}
+
+variable "max_errors" {
+  description = "Number or percentage of errors allowed before the patching task stops"
+  type        = string
+  default     = "10%"
+}
+
+variable "reboot_option" {
+  description = "Whether instances reboot after patches are installed"
+  type        = string
+  default     = "RebootIfNeeded"
+  validation {
+    condition     = contains(["RebootIfNeeded", "NoReboot"], var.reboot_option)
+    error_message = "Reboot option must be one of: RebootIfNeeded, NoReboot."
+  }
+}
+
+variable "additional_tags" {
+  description = "Additional tags to apply to resources"
+  type        = map(string)
+  default     = {}
+}
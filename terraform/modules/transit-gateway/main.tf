@@ -0,0 +1,47 @@
+# Transit Gateway Module
+# Transit Gateway hub with named route tables for routing between VPC attachments
+
+locals {
+  name_prefix = "${var.project_name}-${var.environment}"
+
+  tags = merge(
+    {
+      Environment = var.environment
+      Module      = "transit-gateway"
+    },
+    var.additional_tags
+  )
+}
+
+# Transit Gateway
+resource "aws_ec2_transit_gateway" "main" {
+  description                     = var.description != null ? var.description : "${local.name_prefix} transit gateway"
+  amazon_side_asn                 = var.amazon_side_asn
+  default_route_table_association = var.default_route_table_association ? "enable" : "disable"
+  default_route_table_propagation = var.default_route_table_propagation ? "enable" : "disable"
+  auto_accept_shared_attachments  = var.auto_accept_shared_attachments ? "enable" : "disable"
+  dns_support                     = var.dns_support ? "enable" : "disable"
+  vpn_ecmp_support                = var.vpn_ecmp_support ? "enable" : "disable"
+
+  tags = merge(
+    local.tags,
+    {
+      Name = "${local.name_prefix}-tgw"
+    }
+  )
+}
+
+# Named Route Tables - attachments are associated with and propagate into these
+# (e.g. separate "shared" and "workloads" tables to isolate environments)
+resource "aws_ec2_transit_gateway_route_table" "main" {
+  for_each = toset(var.route_tables)
+
+  transit_gateway_id = aws_ec2_transit_gateway.main.id
+
+  tags = merge(
+    local.tags,
+    {
+      Name = "${local.name_prefix}-tgw-${each.key}-rt"
+    }
+  )
+}
@@ -0,0 +1,31 @@
+# Outputs for Transit Gateway Module
+
+output "transit_gateway_id" {
+  description = "ID of the transit gateway"
+  value       = aws_ec2_transit_gateway.main.id
+}
+
+output "transit_gateway_arn" {
+  description = "ARN of the transit gateway"
+  value       = aws_ec2_transit_gateway.main.arn
+}
+
+output "amazon_side_asn" {
+  description = "Private ASN of the Amazon side of BGP sessions"
+  value       = aws_ec2_transit_gateway.main.amazon_side_asn
+}
+
+output "route_table_ids" {
+  description = "Map of route table name to transit gateway route table ID"
+  value       = { for name, route_table in aws_ec2_transit_gateway_route_table.main : name => route_table.id }
+}
+
+output "default_association_route_table_id" {
+  description = "ID of the default association route table"
+  value       = aws_ec2_transit_gateway.main.association_default_route_table_id
+}
+
+output "default_propagation_route_table_id" {
+  description = "ID of the default propagation route table"
+  value       = aws_ec2_transit_gateway.main.propagation_default_route_table_id
+}
@@ -0,0 +1,90 @@
+# Variables for Transit Gateway Module
+
+variable "project_name" {
+  description = "Name of the project"
+  type        = string
+  validation {
+    condition     = length(var.project_name) > 0 && length(var.project_name) <= 50 && can(regex("^[a-zA-Z0-9-]+$", var.project_name))
+    error_message = "Project name must be 1-50 characters and contain only alphanumeric characters and hyphens."
+  }
+}
+
+variable "environment" {
+  description = "Environment name (shared, staging, production)"
+  type        = string
+  validation {
+    condition     = contains(["shared", "staging", "production"], var.environment)
+    error_message = "Environment must be one of: shared, staging, production."
+  }
+}
+
+# Transit Gateway Configuration
+variable "description" {
+  description = "Description of the transit gateway (defaults to \"<project>-<environment> transit gateway\")"
+  type        = string
+  default     = null
+}
+
+variable "amazon_side_asn" {
+  description = "Private ASN for the Amazon side of BGP sessions (64512-65534 or 4200000000-4294967294)"
+  type        = number
+  default     = 64512
+  validation {
+    condition = (
+      (var.amazon_side_asn >= 64512 && var.amazon_side_asn <= 65534) ||
+      (var.amazon_side_asn >= 4200000000 && var.amazon_side_asn <= 4294967294)
+    )
+    error_message = "Amazon side ASN must be a private ASN between 64512 and 65534 or between 4200000000 and 4294967294."
+  }
+}
+
+variable "default_route_table_association" {
+  description = "Automatically associate new attachments with the default route table (disable when using named route tables)"
+  type        = bool
+  default     = true
+}
+
+variable "default_route_table_propagation" {
+  description = "Automatically propagate new attachments into the default route table (disable when using named route tables)"
+  type        = bool
+  default     = true
+}
+
+variable "auto_accept_shared_attachments" {
+  description = "Accept attachments from other accounts the transit gateway is shared with without manual approval"
+  type        = bool
+  default     = false
+}
+
+variable "dns_support" {
+  description = "Resolve public DNS names of attached VPCs to private IP addresses"
+  type        = bool
+  default     = true
+}
+
+variable "vpn_ecmp_support" {
+  description = "Use equal-cost multi-path routing across VPN attachments"
+  type        = bool
+  default     = true
+}
+
+# Route Tables
+variable "route_tables" {
+  description = "Names of transit gateway route tables to create (e.g. [\"shared\", \"workloads\"])"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for name in var.route_tables : can(regex("^[a-z0-9-]{1,32}$", name))])
+    error_message = "Route table names must be 1-32 lowercase alphanumeric characters or hyphens."
+  }
+  validation {
+    condition     = length(distinct(var.route_tables)) == length(var.route_tables)
+    error_message = "Route table names must be unique."
+  }
+}
+
+variable "additional_tags" {
+  description = "Additional tags to apply to resources"
+  type        = map(string)
+  default     = {}
+}
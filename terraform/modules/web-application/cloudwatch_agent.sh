@@ -0,0 +1,15 @@
+#!/bin/bash
+# Installs the CloudWatch agent and publishes memory and root disk usage
+# aggregated by Auto Scaling Group for the module's memory and disk alarms
+set -euo pipefail
+
+yum install -y amazon-cloudwatch-agent
+
+mkdir -p /opt/aws/amazon-cloudwatch-agent/etc
+cat > /opt/aws/amazon-cloudwatch-agent/etc/amazon-cloudwatch-agent.json << 'CONFIG'
+${agent_config}
+CONFIG
+
+/opt/aws/amazon-cloudwatch-agent/bin/amazon-cloudwatch-agent-ctl \
+    -a fetch-config -m ec2 -s \
+    -c file:/opt/aws/amazon-cloudwatch-agent/etc/amazon-cloudwatch-agent.json
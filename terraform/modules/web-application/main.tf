@@ -11,6 +11,322 @@ data "aws_ami" "amazon_linux" {
   }
 }
 
+data "aws_region" "current" {}
+
+# Load balancer subnets are looked up only to check they have IPv6 CIDR blocks for dualstack.
+# count keeps the lookup working when the subnet IDs are not known until apply.
+data "aws_subnet" "alb" {
+  count = var.ip_address_type == "dualstack" ? length(local.alb_subnet_ids) : 0
+
+  id = local.alb_subnet_ids[count.index]
+}
+
+locals {
+  # Caller tags are the base layer; module tags take precedence over them,
+  # and additional_tags keeps its existing ability to override everything
+  common_tags = merge(
+    var.tags,
+    {
+      Environment = var.environment
+      Module      = "web-application"
+      Application = var.application_name
+    },
+    var.additional_tags
+  )
+
+  # The default managed rule groups keep the metric names they had before the groups
+  # became configurable, so existing dashboards and alarms keep receiving data
+  waf_managed_rule_metric_prefixes = {
+    AWSManagedRulesCommonRuleSet         = "CommonRuleSet"
+    AWSManagedRulesKnownBadInputsRuleSet = "KnownBadInputs"
+  }
+
+  scaling_metric_name = {
+    cpu               = "CPUUtilization"
+    alb_request_count = "ALBRequestCountPerTarget"
+    network_in        = "ASGAverageNetworkIn"
+  }[var.scaling_metric]
+
+  # The ASG is always attached to the target group, so ELB health checks are the
+  # default and instances failing target health checks are replaced
+  health_check_type = var.health_check_type != null ? var.health_check_type : "ELB"
+
+  # Production load balancers are protected from deletion unless explicitly disabled
+  enable_deletion_protection = var.enable_deletion_protection != null ? var.enable_deletion_protection : var.environment == "production"
+
+  # GRPC is shorthand for an HTTP target group speaking the GRPC protocol version
+  target_group_protocol         = var.target_group_protocol == "GRPC" ? "HTTP" : var.target_group_protocol
+  target_group_protocol_version = var.target_group_protocol == "GRPC" ? "GRPC" : var.target_group_protocol_version
+  health_check_matcher = (
+    var.health_check_matcher != null ? var.health_check_matcher :
+    local.target_group_protocol_version == "GRPC" ? "0" : "200"
+  )
+
+  # Listener rules without a priority take the lowest free priorities from the base
+  # in list order, skipping any priority assigned explicitly to another rule
+  explicit_listener_rule_priorities = [for rule in var.listener_rules : rule.priority if rule.priority != null]
+  auto_listener_rule_indexes        = [for index, rule in var.listener_rules : index if rule.priority == null]
+  available_listener_rule_priorities = [
+    for priority in range(var.listener_rule_priority_base, var.listener_rule_priority_base + length(var.listener_rules)) :
+    priority if !contains(local.explicit_listener_rule_priorities, priority)
+  ]
+  listener_rules = [
+    for index, rule in var.listener_rules : merge(rule, {
+      priority = rule.priority != null ? rule.priority : local.available_listener_rule_priorities[index(local.auto_listener_rule_indexes, index)]
+    })
+  ]
+
+  # Additional listeners keyed by port, each with its own target group. The health check
+  # matcher follows the protocol version: gRPC status 0 for GRPC, HTTP 200 otherwise.
+  additional_listeners = {
+    for listener in var.additional_listeners : tostring(listener.port) => merge(listener, {
+      health_check_path = (
+        listener.health_check_path != null ? listener.health_check_path :
+        listener.protocol_version == "GRPC" ? "/AWS.ALB/healthcheck" : var.health_check_path
+      )
+      health_check_matcher = (
+        listener.health_check_matcher != null ? listener.health_check_matcher :
+        listener.protocol_version == "GRPC" ? "0" : "200"
+      )
+    })
+  }
+
+  # Blue/green: the ASG registers with the active target group, and the HTTPS listener
+  # splits traffic between blue (the module's target group) and green by weight
+  active_target_group_arn = var.enable_blue_green && var.active_target_group == "green" ? aws_lb_target_group.green[0].arn : aws_lb_target_group.web.arn
+
+  # Every target group the ASG registers instances with, keyed by a plan-time name
+  asg_target_group_arns = merge(
+    { active = local.active_target_group_arn },
+    { for port, group in aws_lb_target_group.additional : "additional-${port}" => group.arn },
+    { for name, arn in var.asg_target_group_arns : "external-${name}" => arn }
+  )
+
+  # With CodeDeploy in control the listener starts on blue and CodeDeploy moves it
+  codedeploy_traffic_control = var.enable_blue_green && var.blue_green_traffic_control == "codedeploy"
+  https_listener             = local.codedeploy_traffic_control ? aws_lb_listener.web_https_codedeploy[0] : aws_lb_listener.web_https[0]
+
+  https_weighted_target_groups = (
+    var.enable_mirror_target_group ? [
+      { arn = aws_lb_target_group.web.arn, weight = 100 - var.mirror_traffic_weight },
+      { arn = aws_lb_target_group.mirror[0].arn, weight = var.mirror_traffic_weight }
+    ] :
+    var.enable_blue_green && !local.codedeploy_traffic_control ? [
+      { arn = aws_lb_target_group.web.arn, weight = var.blue_weight },
+      { arn = aws_lb_target_group.green[0].arn, weight = var.green_weight }
+    ] : []
+  )
+
+  alb_subnet_ids    = var.internal_load_balancer ? var.subnet_ids : var.public_subnet_ids
+  alb_ingress_ports = distinct(concat([80, 443], var.additional_listeners[*].port))
+
+  # Instances launch into the module's placement group or an existing one, if any
+  placement_group_name = var.create_placement_group ? aws_placement_group.web[0].name : var.placement_group_name
+  cluster_placement    = (var.create_placement_group || var.placement_group_name != null) && var.placement_strategy == "cluster"
+
+  # Instances use a caller-supplied instance profile, or one created by the module around
+  # a shared role (so several ASGs reuse one role) or around a role the module creates
+  instance_role_name    = var.shared_instance_role_arn != null ? element(split("/", var.shared_instance_role_arn), length(split("/", var.shared_instance_role_arn)) - 1) : null
+  instance_profile_name = var.instance_profile_name != null ? var.instance_profile_name : aws_iam_instance_profile.instance[0].name
+  instance_role_arn = (
+    var.create_instance_role ? aws_iam_role.instance[0].arn :
+    var.shared_instance_role_arn
+  )
+
+  alb_security_group_id = var.alb_security_group_id != null ? var.alb_security_group_id : aws_security_group.alb[0].id
+
+  # WAF logs go to a CloudWatch log group or through Firehose to S3
+  waf_logging_enabled    = var.enable_waf && var.enable_waf_logging
+  waf_logs_to_cloudwatch = local.waf_logging_enabled && var.waf_log_destination == "cloudwatch"
+  waf_logs_to_firehose   = local.waf_logging_enabled && var.waf_log_destination == "firehose"
+
+  # WAF rules as the Web ACL evaluates them (lowest priority first). Managed rule
+  # groups report their override action; "none" means the group's own actions apply.
+  waf_rules = concat(
+    length(var.waf_ip_allowlist) > 0 ? [{ name = "IPAllowlistRule", priority = var.waf_ip_allowlist_priority, action = "allow" }] : [],
+    length(var.waf_ip_blocklist) > 0 ? [{ name = "IPBlocklistRule", priority = var.waf_ip_blocklist_priority, action = "block" }] : [],
+    var.enable_managed_rules ? [for group in var.managed_rule_groups : { name = group.name, priority = group.priority, action = group.override_action }] : [],
+    [{ name = "RateLimitRule", priority = var.waf_rate_limit_priority, action = "block" }],
+    var.require_secret_header ? [{ name = "SecretHeaderRule", priority = var.waf_secret_header_priority, action = "block" }] : [],
+    var.enable_geo_blocking && length(var.blocked_countries) > 0 ? [{ name = "GeoBlockingRule", priority = var.waf_geo_blocking_priority, action = "block" }] : []
+  )
+  waf_rules_by_priority = { for rule in local.waf_rules : format("%010d", rule.priority) => rule... }
+  waf_rules_summary     = flatten([for priority in sort(keys(local.waf_rules_by_priority)) : local.waf_rules_by_priority[priority]])
+
+  # Alarm notifications go to the module's own topic or a caller-supplied one
+  alarm_topic_arn            = var.create_alarm_topic ? aws_sns_topic.alarms[0].arn : var.alarm_sns_topic_arn
+  alarm_notification_actions = local.alarm_topic_arn != null ? [local.alarm_topic_arn] : []
+
+  # user_data is already base64 when it is a single run of base64 characters whose length
+  # is a multiple of four; anything else, including every script starting with "#!" or
+  # "#cloud-config", is raw and gets encoded. Templates are rendered with the environment
+  # and application name available.
+  user_data_is_base64 = var.user_data != null ? (
+    can(regex("^[A-Za-z0-9+/]+={0,2}$", var.user_data)) && length(var.user_data) % 4 == 0
+  ) : false
+
+  custom_user_data = (
+    var.user_data != null ? (local.user_data_is_base64 ? var.user_data : base64encode(var.user_data)) :
+    var.user_data_template_file != null ? base64encode(templatefile(var.user_data_template_file, merge(
+      {
+        application_name = var.application_name
+        environment      = var.environment
+      },
+      var.user_data_vars
+    ))) :
+    null
+  )
+
+  # The CloudWatch agent bootstrap runs as its own cloud-init part ahead of any custom user data
+  user_data = var.enable_cloudwatch_agent ? data.cloudinit_config.web[0].rendered : local.custom_user_data
+
+  # Instance logs land in one group per application with a stream per instance and file
+  instance_log_group_name = "/${var.project_name}/${var.environment}/${var.application_name}/instances"
+  instance_logs_config    = {
+    logs_collected = {
+      files = {
+        collect_list = [for file in var.instance_log_files : {
+          file_path       = file
+          log_group_name  = local.instance_log_group_name
+          log_stream_name = "{instance_id}${file}"
+        }]
+      }
+    }
+  }
+
+  # Lambda destinations are invoked through a resource policy rather than an assumed role
+  log_subscription_to_lambda = can(regex(":lambda:", coalesce(var.log_subscription_destination_arn, "-")))
+
+  # Metrics are aggregated by Auto Scaling Group so one alarm covers the whole fleet;
+  # the logs section is only present when instance logs are enabled
+  cloudwatch_agent_config = jsonencode(merge({
+    agent = {
+      metrics_collection_interval = 60
+    }
+    metrics = {
+      namespace = var.cloudwatch_agent_namespace
+      append_dimensions = {
+        AutoScalingGroupName = "$${aws:AutoScalingGroupName}"
+        InstanceId           = "$${aws:InstanceId}"
+      }
+      aggregation_dimensions = [["AutoScalingGroupName"]]
+      metrics_collected = {
+        mem = {
+          measurement = ["mem_used_percent"]
+        }
+        disk = {
+          measurement = ["used_percent"]
+          resources   = ["/"]
+          drop_device = true
+        }
+      }
+    }
+  }, { for key, config in { logs = local.instance_logs_config } : key => config if var.enable_instance_logs }))
+}
+
+data "cloudinit_config" "web" {
+  count = var.enable_cloudwatch_agent ? 1 : 0
+
+  gzip          = false
+  base64_encode = true
+
+  part {
+    filename     = "cloudwatch-agent.sh"
+    content_type = "text/x-shellscript"
+    content = templatefile("${path.module}/cloudwatch_agent.sh", {
+      agent_config = local.cloudwatch_agent_config
+    })
+  }
+
+  dynamic "part" {
+    for_each = local.custom_user_data != null ? [base64decode(local.custom_user_data)] : []
+    content {
+      filename     = "user-data"
+      content_type = "text/x-shellscript"
+      content      = part.value
+    }
+  }
+}
+
+# Instance Role - created only when neither an instance profile nor a shared role is supplied
+resource "aws_iam_role" "instance" {
+  count = var.create_instance_role ? 1 : 0
+
+  name_prefix = "${var.project_name}-${var.environment}-web-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "ec2.amazonaws.com"
+        }
+      }
+    ]
+  })
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-${var.application_name}-role"
+    }
+  )
+}
+
+resource "aws_iam_role_policy_attachment" "instance_ssm" {
+  count = var.create_instance_role ? 1 : 0
+
+  role       = aws_iam_role.instance[0].name
+  policy_arn = "arn:aws:iam::aws:policy/AmazonSSMManagedInstanceCore"
+}
+
+resource "aws_iam_role_policy_attachment" "instance_cloudwatch" {
+  count = var.create_instance_role ? 1 : 0
+
+  role       = aws_iam_role.instance[0].name
+  policy_arn = "arn:aws:iam::aws:policy/CloudWatchAgentServerPolicy"
+}
+
+# Instance Profile - wraps the shared or created role; each module instance gets its own profile
+resource "aws_iam_instance_profile" "instance" {
+  count = var.instance_profile_name == null ? 1 : 0
+
+  name = "${var.project_name}-${var.environment}-${var.application_name}-profile"
+  role = var.create_instance_role ? aws_iam_role.instance[0].name : local.instance_role_name
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-${var.application_name}-profile"
+    }
+  )
+}
+
+# Placement Group - a created group or an existing one named by placement_group_name.
+# Instance subnets are looked up only to check that a cluster group stays in one AZ;
+# count keeps the lookup working when the subnet IDs are not known until apply.
+data "aws_subnet" "instance" {
+  count = local.cluster_placement ? length(var.subnet_ids) : 0
+
+  id = var.subnet_ids[count.index]
+}
+
+resource "aws_placement_group" "web" {
+  count = var.create_placement_group ? 1 : 0
+
+  name     = var.placement_group_name != null ? var.placement_group_name : "${var.project_name}-${var.environment}-web-pg"
+  strategy = var.placement_strategy
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-pg"
+    }
+  )
+}
+
 # Launch Template
 resource "aws_launch_template" "web" {
   name_prefix   = "${var.project_name}-${var.environment}-web-"
@@ -18,27 +334,63 @@ resource "aws_launch_template" "web" {
   instance_type = var.instance_type
   key_name      = var.key_pair_name
 
-  vpc_security_group_ids = [var.security_group_id]
+  vpc_security_group_ids = distinct(concat([var.security_group_id], var.additional_security_group_ids))
 
   iam_instance_profile {
-    name = var.instance_profile_name
+    name = local.instance_profile_name
   }
 
-  user_data = base64encode(templatefile("${path.module}/user_data.sh", {
-    application_name = var.application_name
-    environment      = var.environment
-  }))
+  user_data = local.user_data
 
   block_device_mappings {
     device_name = "/dev/xvda"
     ebs {
       volume_size           = var.root_volume_size
       volume_type           = "gp3"
-      encrypted             = true
+      encrypted             = var.root_volume_encrypted
+      kms_key_id            = var.root_volume_encrypted ? var.root_volume_kms_key_id : null
       delete_on_termination = true
     }
   }
 
+  dynamic "block_device_mappings" {
+    for_each = var.data_volumes
+    content {
+      device_name = block_device_mappings.value.device_name
+      ebs {
+        volume_size           = block_device_mappings.value.size
+        volume_type           = block_device_mappings.value.type
+        encrypted             = block_device_mappings.value.encrypted
+        kms_key_id            = block_device_mappings.value.encrypted ? (block_device_mappings.value.kms_key_id != null ? block_device_mappings.value.kms_key_id : var.root_volume_kms_key_id) : null
+        delete_on_termination = true
+      }
+    }
+  }
+
+  # Instance store volumes are mapped by virtual name; their data is lost when the instance stops
+  dynamic "block_device_mappings" {
+    for_each = var.instance_store_device_names
+    content {
+      device_name  = block_device_mappings.value
+      virtual_name = "ephemeral${block_device_mappings.key}"
+    }
+  }
+
+  dynamic "placement" {
+    for_each = local.placement_group_name != null ? [1] : []
+    content {
+      group_name = local.placement_group_name
+    }
+  }
+
+  # Credit specification is only valid for burstable (T family) instance types
+  dynamic "credit_specification" {
+    for_each = var.cpu_credits != null && can(regex("^t[0-9]", var.instance_type)) ? [1] : []
+    content {
+      cpu_credits = var.cpu_credits
+    }
+  }
+
   metadata_options {
     http_endpoint               = "enabled"
     http_tokens                 = "required"
@@ -50,40 +402,98 @@ resource "aws_launch_template" "web" {
   }
 
   tag_specifications {
-    resource_type = "instance"
+    resource_type = "volume"
     tags = merge(
+      local.common_tags,
       {
-        Name        = "${var.project_name}-${var.environment}-web"
-        Environment = var.environment
-        Module      = "web-application"
-        Application = var.application_name
-      },
-      var.additional_tags
+        Name = "${var.project_name}-${var.environment}-web-volume"
+      }
     )
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-web-template"
-    Environment = var.environment
-    Module      = "web-application"
+  tag_specifications {
+    resource_type = "instance"
+    tags = merge(
+      local.common_tags,
+      {
+        Name = "${var.project_name}-${var.environment}-web"
+      }
+    )
   }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-template"
+    }
+  )
 }
 
 # Auto Scaling Group
 resource "aws_autoscaling_group" "web" {
   name                      = "${var.project_name}-${var.environment}-web-asg"
   vpc_zone_identifier       = var.subnet_ids
-  target_group_arns         = [aws_lb_target_group.web.arn]
-  health_check_type         = "ELB"
-  health_check_grace_period = 300
+  health_check_type         = local.health_check_type
+  health_check_grace_period = var.health_check_grace_period
+  suspended_processes       = var.suspended_processes
+  termination_policies      = var.termination_policies
+  capacity_rebalance        = var.capacity_rebalance
 
   min_size         = var.min_size
   max_size         = var.max_size
   desired_capacity = var.desired_capacity
 
-  launch_template {
-    id      = aws_launch_template.web.id
-    version = "$Latest"
+  dynamic "launch_template" {
+    for_each = var.enable_mixed_instances ? [] : [1]
+    content {
+      id      = aws_launch_template.web.id
+      version = var.launch_template_version
+    }
+  }
+
+  # Spread capacity across instance types and purchase options; the launch
+  # template instance type is overridden by instance_types
+  dynamic "mixed_instances_policy" {
+    for_each = var.enable_mixed_instances ? [1] : []
+    content {
+      instances_distribution {
+        on_demand_base_capacity                  = var.on_demand_base_capacity
+        on_demand_percentage_above_base_capacity = var.on_demand_percentage_above_base_capacity
+        spot_allocation_strategy                 = var.spot_allocation_strategy
+      }
+
+      launch_template {
+        launch_template_specification {
+          launch_template_id = aws_launch_template.web.id
+          version            = var.launch_template_version
+        }
+
+        dynamic "override" {
+          for_each = var.instance_types
+          content {
+            instance_type = override.value
+          }
+        }
+      }
+    }
+  }
+
+  # Pre-initialized instances shorten scale-out; only created when enabled so
+  # stopped instances and their volumes are not billed by default
+  dynamic "warm_pool" {
+    for_each = var.enable_warm_pool ? [1] : []
+    content {
+      pool_state                  = var.warm_pool_state
+      min_size                    = var.warm_pool_min_size
+      max_group_prepared_capacity = var.warm_pool_max_prepared_capacity
+
+      dynamic "instance_reuse_policy" {
+        for_each = var.warm_pool_reuse_on_scale_in ? [1] : []
+        content {
+          reuse_on_scale_in = true
+        }
+      }
+    }
   }
 
   instance_refresh {
@@ -100,34 +510,165 @@ resource "aws_autoscaling_group" "web" {
     propagate_at_launch = false
   }
 
-  tag {
-    key                 = "Environment"
-    value               = var.environment
-    propagate_at_launch = true
+  # Common tags propagate so launched instances inherit them
+  dynamic "tag" {
+    for_each = { for key, value in local.common_tags : key => value if key != "Name" }
+    content {
+      key                 = tag.key
+      value               = tag.value
+      propagate_at_launch = true
+    }
   }
 
-  tag {
-    key                 = "Module"
-    value               = "web-application"
-    propagate_at_launch = true
+  # Target groups are attached through aws_autoscaling_attachment below
+  lifecycle {
+    ignore_changes = [target_group_arns]
+
+    # A cluster placement group lives in a single AZ; instances launched in other AZs fail
+    precondition {
+      condition     = length(distinct(data.aws_subnet.instance[*].availability_zone)) <= 1
+      error_message = "Cluster placement groups are limited to one Availability Zone; use subnet_ids from a single AZ, or a spread or partition placement group with placement_strategy set to match."
+    }
   }
+}
 
-  tag {
-    key                 = "Application"
-    value               = var.application_name
-    propagate_at_launch = true
+# Target Group Attachments - the active, additional listener, and caller-supplied target
+# groups. A replacement attachment is created before the old one is removed, so switching
+# the active target group never leaves the ASG detached.
+resource "aws_autoscaling_attachment" "web" {
+  for_each = local.asg_target_group_arns
+
+  autoscaling_group_name = aws_autoscaling_group.web.id
+  lb_target_group_arn    = each.value
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+# Instances in the Auto Scaling Group - read once the group has reached its desired
+# capacity, so the result is a snapshot of membership at apply time
+data "aws_instances" "web" {
+  count = var.desired_capacity > 0 ? 1 : 0
+
+  instance_tags = {
+    "aws:autoscaling:groupName" = aws_autoscaling_group.web.name
+  }
+
+  instance_state_names = ["pending", "running"]
+}
+
+# Lifecycle hooks pause instances in Pending:Wait / Terminating:Wait so the
+# application can bootstrap or drain in-flight work before the transition completes
+resource "aws_autoscaling_lifecycle_hook" "web" {
+  for_each = { for hook in var.lifecycle_hooks : hook.name => hook }
+
+  name                    = each.value.name
+  autoscaling_group_name  = aws_autoscaling_group.web.name
+  lifecycle_transition    = each.value.lifecycle_transition
+  heartbeat_timeout       = each.value.heartbeat_timeout
+  default_result          = each.value.default_result
+  notification_target_arn = each.value.notification_target_arn
+  role_arn                = each.value.role_arn
+}
+
+# ALB Security Group - created when no existing security group is supplied
+resource "aws_security_group" "alb" {
+  count = var.alb_security_group_id == null ? 1 : 0
+
+  name_prefix = "${var.project_name}-${var.environment}-alb-"
+  description = "Security group for the web application load balancer"
+  vpc_id      = var.vpc_id
+
+  egress {
+    description = "All outbound traffic"
+    from_port   = 0
+    to_port     = 0
+    protocol    = "-1"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+
+  lifecycle {
+    create_before_destroy = true
   }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-alb-sg"
+    }
+  )
+}
+
+resource "aws_vpc_security_group_ingress_rule" "alb_cidr" {
+  for_each = var.alb_security_group_id == null ? {
+    for pair in setproduct(local.alb_ingress_ports, var.alb_ingress_cidr_blocks) : "${pair[0]}-${pair[1]}" => {
+      port       = pair[0]
+      cidr_block = pair[1]
+    }
+  } : {}
+
+  security_group_id = aws_security_group.alb[0].id
+  description       = "Port ${each.value.port} from ${each.value.cidr_block}"
+  ip_protocol       = "tcp"
+  from_port         = each.value.port
+  to_port           = each.value.port
+  cidr_ipv4         = each.value.cidr_block
+
+  tags = local.common_tags
+}
+
+resource "aws_vpc_security_group_ingress_rule" "alb_cidr_ipv6" {
+  for_each = var.alb_security_group_id == null && var.ip_address_type == "dualstack" ? {
+    for pair in setproduct(local.alb_ingress_ports, var.alb_ingress_ipv6_cidr_blocks) : "${pair[0]}-${pair[1]}" => {
+      port       = pair[0]
+      cidr_block = pair[1]
+    }
+  } : {}
+
+  security_group_id = aws_security_group.alb[0].id
+  description       = "Port ${each.value.port} from ${each.value.cidr_block}"
+  ip_protocol       = "tcp"
+  from_port         = each.value.port
+  to_port           = each.value.port
+  cidr_ipv6         = each.value.cidr_block
+
+  tags = local.common_tags
+}
+
+resource "aws_vpc_security_group_ingress_rule" "alb_prefix_list" {
+  for_each = var.alb_security_group_id == null ? {
+    for pair in setproduct(local.alb_ingress_ports, var.alb_ingress_prefix_list_ids) : "${pair[0]}-${pair[1]}" => {
+      port           = pair[0]
+      prefix_list_id = pair[1]
+    }
+  } : {}
+
+  security_group_id = aws_security_group.alb[0].id
+  description       = "Port ${each.value.port} from ${each.value.prefix_list_id}"
+  ip_protocol       = "tcp"
+  from_port         = each.value.port
+  to_port           = each.value.port
+  prefix_list_id    = each.value.prefix_list_id
+
+  tags = local.common_tags
 }
 
 # Application Load Balancer
+# Internal load balancers are placed in the instance (private) subnets and get no public IPs
 resource "aws_lb" "web" {
   name               = "${var.project_name}-${var.environment}-web-alb"
-  internal           = false
+  internal           = var.internal_load_balancer
   load_balancer_type = "application"
-  security_groups    = [var.alb_security_group_id]
-  subnets            = var.public_subnet_ids
+  security_groups    = [local.alb_security_group_id]
+  subnets            = local.alb_subnet_ids
+  ip_address_type    = var.ip_address_type
+  idle_timeout       = var.idle_timeout
 
-  enable_deletion_protection = var.enable_deletion_protection
+  enable_deletion_protection       = local.enable_deletion_protection
+  enable_cross_zone_load_balancing = var.enable_cross_zone_load_balancing
+  drop_invalid_header_fields       = var.drop_invalid_header_fields
+  desync_mitigation_mode           = var.desync_mitigation_mode
 
   access_logs {
     bucket  = var.access_logs_bucket
@@ -135,14 +676,199 @@ resource "aws_lb" "web" {
     enabled = var.enable_access_logs
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-web-alb"
-    Environment = var.environment
-    Module      = "web-application"
+  lifecycle {
+    precondition {
+      condition     = alltrue([for subnet in data.aws_subnet.alb : subnet.ipv6_cidr_block != null && subnet.ipv6_cidr_block != ""])
+      error_message = "Dualstack load balancers require every load balancer subnet to have an IPv6 CIDR block."
+    }
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-alb"
+    }
+  )
+}
+
+# Network Load Balancer - fronts the ALB in nlb_mode so it can back a PrivateLink endpoint
+# service. TLS passes through to the ALB's HTTPS listener, so WAF and listener rules still apply.
+resource "aws_lb" "nlb" {
+  count = var.nlb_mode ? 1 : 0
+
+  name               = "${var.project_name}-${var.environment}-web-nlb"
+  internal           = true
+  load_balancer_type = "network"
+  subnets            = var.subnet_ids
+
+  enable_deletion_protection       = local.enable_deletion_protection
+  enable_cross_zone_load_balancing = var.enable_cross_zone_load_balancing
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-nlb"
+    }
+  )
+}
+
+resource "aws_lb_target_group" "alb" {
+  count = var.nlb_mode ? 1 : 0
+
+  name        = "${var.project_name}-${var.environment}-nlb-tg"
+  port        = 443
+  protocol    = "TCP"
+  target_type = "alb"
+  vpc_id      = var.vpc_id
+
+  health_check {
+    protocol = "HTTPS"
+    path     = var.health_check_path
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_lb_target_group_attachment" "alb" {
+  count = var.nlb_mode ? 1 : 0
+
+  target_group_arn = aws_lb_target_group.alb[0].arn
+  target_id        = aws_lb.web.arn
+  port             = 443
+
+  # An ALB can only be registered on a port it already listens on
+  depends_on = [aws_lb_listener.web_https, aws_lb_listener.web_https_codedeploy]
+}
+
+resource "aws_lb_listener" "nlb" {
+  count = var.nlb_mode ? 1 : 0
+
+  load_balancer_arn = aws_lb.nlb[0].arn
+  port              = "443"
+  protocol          = "TCP"
+
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.alb[0].arn
+  }
+
+  tags = local.common_tags
+}
+
+# The NLB has no security group, so the ALB sees connections from the NLB's private
+# addresses in the VPC
+data "aws_vpc" "web" {
+  count = var.nlb_mode && var.alb_security_group_id == null ? 1 : 0
+
+  id = var.vpc_id
+}
+
+resource "aws_vpc_security_group_ingress_rule" "alb_nlb" {
+  count = var.nlb_mode && var.alb_security_group_id == null ? 1 : 0
+
+  security_group_id = aws_security_group.alb[0].id
+  description       = "Port 443 from the PrivateLink NLB"
+  ip_protocol       = "tcp"
+  from_port         = 443
+  to_port           = 443
+  cidr_ipv4         = data.aws_vpc.web[0].cidr_block
+
+  tags = local.common_tags
+}
+
+# PrivateLink Endpoint Service - lets allowed principals in other accounts create
+# interface endpoints to the NLB
+resource "aws_vpc_endpoint_service" "web" {
+  count = var.enable_endpoint_service ? 1 : 0
+
+  acceptance_required        = var.endpoint_service_acceptance_required
+  allowed_principals         = var.endpoint_service_allowed_principals
+  network_load_balancer_arns = [aws_lb.nlb[0].arn]
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-endpoint-service"
+    }
+  )
+}
+
+# Route53 Alias Records - A and AAAA records pointing each hostname (apex or subdomain) at the ALB
+resource "aws_route53_record" "alb" {
+  for_each = var.route53_zone_id != null ? {
+    for pair in setproduct(var.dns_records, ["A", "AAAA"]) : "${pair[0]}-${pair[1]}" => {
+      name = pair[0]
+      type = pair[1]
+    }
+  } : {}
+
+  zone_id        = var.route53_zone_id
+  name           = each.value.name
+  type           = each.value.type
+  set_identifier = var.routing_policy != "simple" ? var.set_identifier : null
+
+  dynamic "weighted_routing_policy" {
+    for_each = var.routing_policy == "weighted" ? [1] : []
+    content {
+      weight = var.routing_weight
+    }
+  }
+
+  dynamic "latency_routing_policy" {
+    for_each = var.routing_policy == "latency" ? [1] : []
+    content {
+      region = var.routing_region != null ? var.routing_region : data.aws_region.current.id
+    }
+  }
+
+  dynamic "failover_routing_policy" {
+    for_each = var.routing_policy == "failover" ? [1] : []
+    content {
+      type = var.failover_role
+    }
+  }
+
+  alias {
+    name                   = aws_lb.web.dns_name
+    zone_id                = aws_lb.web.zone_id
+    evaluate_target_health = true
   }
 }
 
 # WAF Web ACL for Application Load Balancer Protection
+# WAF IP Sets - addresses that are always allowed or always blocked
+resource "aws_wafv2_ip_set" "allowlist" {
+  count = var.enable_waf && length(var.waf_ip_allowlist) > 0 ? 1 : 0
+
+  name               = "${var.project_name}-${var.environment}-web-allowlist"
+  scope              = "REGIONAL"
+  ip_address_version = "IPV4"
+  addresses          = var.waf_ip_allowlist
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-allowlist"
+    }
+  )
+}
+
+resource "aws_wafv2_ip_set" "blocklist" {
+  count = var.enable_waf && length(var.waf_ip_blocklist) > 0 ? 1 : 0
+
+  name               = "${var.project_name}-${var.environment}-web-blocklist"
+  scope              = "REGIONAL"
+  ip_address_version = "IPV4"
+  addresses          = var.waf_ip_blocklist
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-blocklist"
+    }
+  )
+}
+
 resource "aws_wafv2_web_acl" "web_acl" {
   count = var.enable_waf ? 1 : 0
 
@@ -153,56 +879,107 @@ resource "aws_wafv2_web_acl" "web_acl" {
     allow {}
   }
 
-  # AWS Managed Rule Set - Common Rule Set
-  rule {
-    name     = "AWSManagedRulesCommonRuleSet"
-    priority = 1
+  # IP Allowlist Rule - allow terminates evaluation, so listed addresses skip
+  # rate limiting and every later rule
+  dynamic "rule" {
+    for_each = length(var.waf_ip_allowlist) > 0 ? [1] : []
+    content {
+      name     = "IPAllowlistRule"
+      priority = var.waf_ip_allowlist_priority
 
-    override_action {
-      none {}
-    }
+      action {
+        allow {}
+      }
 
-    statement {
-      managed_rule_group_statement {
-        name        = "AWSManagedRulesCommonRuleSet"
-        vendor_name = "AWS"
+      statement {
+        ip_set_reference_statement {
+          arn = aws_wafv2_ip_set.allowlist[0].arn
+        }
       }
-    }
 
-    visibility_config {
-      cloudwatch_metrics_enabled = true
-      metric_name                = "${var.project_name}${var.environment}CommonRuleSetMetric"
-      sampled_requests_enabled   = true
+      visibility_config {
+        cloudwatch_metrics_enabled = true
+        metric_name                = "${var.project_name}${var.environment}IPAllowlistMetric"
+        sampled_requests_enabled   = true
+      }
     }
   }
 
-  # AWS Managed Rule Set - Known Bad Inputs
-  rule {
-    name     = "AWSManagedRulesKnownBadInputsRuleSet"
-    priority = 2
+  # IP Blocklist Rule
+  dynamic "rule" {
+    for_each = length(var.waf_ip_blocklist) > 0 ? [1] : []
+    content {
+      name     = "IPBlocklistRule"
+      priority = var.waf_ip_blocklist_priority
 
-    override_action {
-      none {}
-    }
+      action {
+        block {}
+      }
 
-    statement {
-      managed_rule_group_statement {
-        name        = "AWSManagedRulesKnownBadInputsRuleSet"
-        vendor_name = "AWS"
+      statement {
+        ip_set_reference_statement {
+          arn = aws_wafv2_ip_set.blocklist[0].arn
+        }
+      }
+
+      visibility_config {
+        cloudwatch_metrics_enabled = true
+        metric_name                = "${var.project_name}${var.environment}IPBlocklistMetric"
+        sampled_requests_enabled   = true
       }
     }
+  }
 
-    visibility_config {
-      cloudwatch_metrics_enabled = true
-      metric_name                = "${var.project_name}${var.environment}KnownBadInputsMetric"
-      sampled_requests_enabled   = true
+  # AWS Managed Rule Groups
+  dynamic "rule" {
+    for_each = var.enable_managed_rules ? var.managed_rule_groups : []
+    content {
+      name     = rule.value.name
+      priority = rule.value.priority
+
+      override_action {
+        dynamic "none" {
+          for_each = rule.value.override_action == "none" ? [1] : []
+          content {}
+        }
+
+        dynamic "count" {
+          for_each = rule.value.override_action == "count" ? [1] : []
+          content {}
+        }
+      }
+
+      statement {
+        managed_rule_group_statement {
+          name        = rule.value.name
+          vendor_name = rule.value.vendor_name
+
+          # Excluded rules are switched to count so they are logged but never block
+          dynamic "rule_action_override" {
+            for_each = lookup(var.managed_rule_exclusions, rule.value.name, [])
+            content {
+              name = rule_action_override.value
+
+              action_to_use {
+                count {}
+              }
+            }
+          }
+        }
+      }
+
+      visibility_config {
+        cloudwatch_metrics_enabled = true
+        metric_name                = rule.value.metric_name != null ? rule.value.metric_name : "${var.project_name}${var.environment}${lookup(local.waf_managed_rule_metric_prefixes, rule.value.name, rule.value.name)}Metric"
+        sampled_requests_enabled   = true
+      }
     }
   }
 
   # Rate Limiting Rule
   rule {
     name     = "RateLimitRule"
-    priority = 3
+    priority = var.waf_rate_limit_priority
 
     action {
       block {}
@@ -222,85 +999,483 @@ resource "aws_wafv2_web_acl" "web_acl" {
     }
   }
 
+  # Secret Header Rule - blocks requests that did not come through the CDN, which
+  # adds the shared secret header to every origin request
+  dynamic "rule" {
+    for_each = var.require_secret_header ? [1] : []
+    content {
+      name     = "SecretHeaderRule"
+      priority = var.waf_secret_header_priority
+
+      action {
+        block {}
+      }
+
+      statement {
+        not_statement {
+          statement {
+            byte_match_statement {
+              search_string         = var.secret_header_value
+              positional_constraint = "EXACTLY"
+
+              # WAF requires lowercase header names and matches them case-insensitively
+              field_to_match {
+                single_header {
+                  name = lower(var.secret_header_name)
+                }
+              }
+
+              text_transformation {
+                priority = 0
+                type     = "NONE"
+              }
+            }
+          }
+        }
+      }
+
+      visibility_config {
+        cloudwatch_metrics_enabled = true
+        metric_name                = "${var.project_name}${var.environment}SecretHeaderMetric"
+        sampled_requests_enabled   = true
+      }
+    }
+  }
+
   # Geo Blocking Rule (if enabled)
   dynamic "rule" {
     for_each = var.enable_geo_blocking && length(var.blocked_countries) > 0 ? [1] : []
     content {
-      name     = "GeoBlockingRule"
-      priority = 4
+      name     = "GeoBlockingRule"
+      priority = var.waf_geo_blocking_priority
+
+      action {
+        block {}
+      }
+
+      statement {
+        geo_match_statement {
+          country_codes = var.blocked_countries
+        }
+      }
+
+      visibility_config {
+        cloudwatch_metrics_enabled = true
+        metric_name                = "${var.project_name}${var.environment}GeoBlockingMetric"
+        sampled_requests_enabled   = true
+      }
+    }
+  }
+
+  visibility_config {
+    cloudwatch_metrics_enabled = true
+    metric_name                = "${var.project_name}${var.environment}WebACLMetric"
+    sampled_requests_enabled   = true
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-waf"
+    }
+  )
+
+  # Only the rules that are actually enabled compete for priorities
+  lifecycle {
+    precondition {
+      condition     = length(distinct(local.waf_rules[*].priority)) == length(local.waf_rules)
+      error_message = "WAF rule priorities must be unique across the enabled IP allowlist, IP blocklist, managed rule group, rate limiting, secret header, and geo blocking rules."
+    }
+
+    precondition {
+      condition     = length(var.waf_ip_allowlist) == 0 || alltrue([for rule in local.waf_rules : rule.priority > var.waf_ip_allowlist_priority if rule.name != "IPAllowlistRule"])
+      error_message = "WAF IP allowlist priority must be lower than every other enabled WAF rule priority so allowed IPs bypass rate limiting."
+    }
+  }
+}
+
+# Associate WAF with Application Load Balancer
+resource "aws_wafv2_web_acl_association" "web_acl_association" {
+  count = var.enable_waf ? 1 : 0
+
+  resource_arn = aws_lb.web.arn
+  web_acl_arn  = aws_wafv2_web_acl.web_acl[0].arn
+}
+
+# WAF Logging - WAF requires log group and delivery stream names to start with aws-waf-logs-
+resource "aws_cloudwatch_log_group" "waf" {
+  count = local.waf_logs_to_cloudwatch ? 1 : 0
+
+  name              = "aws-waf-logs-${var.project_name}-${var.environment}"
+  retention_in_days = var.waf_log_retention_days
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "aws-waf-logs-${var.project_name}-${var.environment}"
+    }
+  )
+}
+
+# S3 bucket receiving WAF logs from Firehose, expired after waf_log_retention_days
+resource "aws_s3_bucket" "waf_logs" {
+  count = local.waf_logs_to_firehose ? 1 : 0
+
+  bucket_prefix = "${var.project_name}-${var.environment}-waf-logs-"
+  force_destroy = var.waf_log_bucket_force_destroy
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-waf-logs"
+    }
+  )
+}
+
+resource "aws_s3_bucket_public_access_block" "waf_logs" {
+  count = local.waf_logs_to_firehose ? 1 : 0
+
+  bucket = aws_s3_bucket.waf_logs[0].id
+
+  block_public_acls       = true
+  block_public_policy     = true
+  ignore_public_acls      = true
+  restrict_public_buckets = true
+}
+
+resource "aws_s3_bucket_server_side_encryption_configuration" "waf_logs" {
+  count = local.waf_logs_to_firehose ? 1 : 0
+
+  bucket = aws_s3_bucket.waf_logs[0].id
+
+  rule {
+    apply_server_side_encryption_by_default {
+      sse_algorithm     = var.waf_log_kms_key_arn != null ? "aws:kms" : "AES256"
+      kms_master_key_id = var.waf_log_kms_key_arn
+    }
+    bucket_key_enabled = var.waf_log_kms_key_arn != null
+  }
+}
+
+resource "aws_s3_bucket_lifecycle_configuration" "waf_logs" {
+  count = local.waf_logs_to_firehose ? 1 : 0
+
+  bucket = aws_s3_bucket.waf_logs[0].id
+
+  rule {
+    id     = "expire-waf-logs"
+    status = "Enabled"
+
+    filter {}
+
+    expiration {
+      days = var.waf_log_retention_days
+    }
+
+    abort_incomplete_multipart_upload {
+      days_after_initiation = 1
+    }
+  }
+}
+
+resource "aws_iam_role" "waf_firehose" {
+  count = local.waf_logs_to_firehose ? 1 : 0
+
+  name_prefix = "${var.project_name}-${var.environment}-waf-fh-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "firehose.amazonaws.com"
+        }
+      }
+    ]
+  })
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-waf-firehose-role"
+    }
+  )
+}
+
+resource "aws_iam_role_policy" "waf_firehose" {
+  count = local.waf_logs_to_firehose ? 1 : 0
+
+  name = "${var.project_name}-${var.environment}-waf-firehose-s3"
+  role = aws_iam_role.waf_firehose[0].id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = concat(
+      [
+        {
+          Effect = "Allow"
+          Action = [
+            "s3:AbortMultipartUpload",
+            "s3:GetBucketLocation",
+            "s3:GetObject",
+            "s3:ListBucket",
+            "s3:ListBucketMultipartUploads",
+            "s3:PutObject"
+          ]
+          Resource = [
+            aws_s3_bucket.waf_logs[0].arn,
+            "${aws_s3_bucket.waf_logs[0].arn}/*"
+          ]
+        }
+      ],
+      var.waf_log_kms_key_arn != null ? [
+        {
+          Effect = "Allow"
+          Action = [
+            "kms:Decrypt",
+            "kms:GenerateDataKey"
+          ]
+          Resource = var.waf_log_kms_key_arn
+        }
+      ] : []
+    )
+  })
+}
+
+resource "aws_kinesis_firehose_delivery_stream" "waf" {
+  count = local.waf_logs_to_firehose ? 1 : 0
+
+  name        = "aws-waf-logs-${var.project_name}-${var.environment}"
+  destination = "extended_s3"
+
+  extended_s3_configuration {
+    role_arn            = aws_iam_role.waf_firehose[0].arn
+    bucket_arn          = aws_s3_bucket.waf_logs[0].arn
+    prefix              = var.waf_firehose_s3_prefix
+    error_output_prefix = "${var.waf_firehose_s3_prefix}errors/!{firehose:error-output-type}/"
+    buffering_size      = var.waf_firehose_buffering_size
+    buffering_interval  = var.waf_firehose_buffering_interval
+    compression_format  = var.waf_firehose_compression_format
+    kms_key_arn         = var.waf_log_kms_key_arn
+  }
+
+  server_side_encryption {
+    enabled  = true
+    key_type = var.waf_log_kms_key_arn != null ? "CUSTOMER_MANAGED_CMK" : "AWS_OWNED_CMK"
+    key_arn  = var.waf_log_kms_key_arn
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "aws-waf-logs-${var.project_name}-${var.environment}"
+    }
+  )
+
+  depends_on = [aws_iam_role_policy.waf_firehose]
+}
+
+resource "aws_wafv2_web_acl_logging_configuration" "web_acl" {
+  count = local.waf_logging_enabled ? 1 : 0
+
+  resource_arn            = aws_wafv2_web_acl.web_acl[0].arn
+  log_destination_configs = [local.waf_logs_to_firehose ? aws_kinesis_firehose_delivery_stream.waf[0].arn : aws_cloudwatch_log_group.waf[0].arn]
+
+  # Keep only requests whose terminating action matches the filter; everything else is dropped
+  dynamic "logging_filter" {
+    for_each = var.waf_log_filter != "all" ? [1] : []
+    content {
+      default_behavior = "DROP"
 
-      action {
-        block {}
-      }
+      filter {
+        behavior    = "KEEP"
+        requirement = "MEETS_ANY"
 
-      statement {
-        geo_match_statement {
-          country_codes = var.blocked_countries
+        condition {
+          action_condition {
+            action = var.waf_log_filter == "blocked" ? "BLOCK" : "COUNT"
+          }
         }
       }
+    }
+  }
+}
 
-      visibility_config {
-        cloudwatch_metrics_enabled = true
-        metric_name                = "${var.project_name}${var.environment}GeoBlockingMetric"
-        sampled_requests_enabled   = true
-      }
+# Target Group
+resource "aws_lb_target_group" "web" {
+  name     = "${var.project_name}-${var.environment}-web-tg"
+  port     = var.target_port
+  protocol = local.target_group_protocol
+  vpc_id   = var.vpc_id
+
+  protocol_version = local.target_group_protocol_version
+
+  deregistration_delay = var.deregistration_delay
+  slow_start           = var.slow_start
+
+  health_check {
+    enabled             = true
+    healthy_threshold   = var.healthy_threshold
+    interval            = var.health_check_interval
+    matcher             = local.health_check_matcher
+    path                = var.health_check_path
+    port                = "traffic-port"
+    protocol            = local.target_group_protocol
+    timeout             = var.health_check_timeout
+    unhealthy_threshold = var.unhealthy_threshold
+  }
+
+  # Fewer healthy targets than the minimum marks the target group unhealthy for
+  # DNS failover and makes the load balancer route to all targets (fail open)
+  target_group_health {
+    dns_failover {
+      minimum_healthy_targets_count      = tostring(var.unhealthy_state_routing_min_healthy_count)
+      minimum_healthy_targets_percentage = var.unhealthy_state_routing_min_healthy_percentage
+    }
+
+    unhealthy_state_routing {
+      minimum_healthy_targets_count      = var.unhealthy_state_routing_min_healthy_count
+      minimum_healthy_targets_percentage = var.unhealthy_state_routing_min_healthy_percentage
     }
   }
 
-  visibility_config {
-    cloudwatch_metrics_enabled = true
-    metric_name                = "${var.project_name}${var.environment}WebACLMetric"
-    sampled_requests_enabled   = true
+  # Kept even when disabled so turning stickiness off updates the target group instead of leaving it on
+  stickiness {
+    enabled         = var.enable_stickiness
+    type            = var.enable_stickiness ? var.stickiness_type : "lb_cookie"
+    cookie_duration = var.stickiness_duration
+    cookie_name     = var.enable_stickiness && var.stickiness_type == "app_cookie" ? var.stickiness_cookie_name : null
   }
 
   tags = merge(
+    local.common_tags,
     {
-      Name        = "${var.project_name}-${var.environment}-web-waf"
-      Environment = var.environment
-      Module      = "web-application"
-    },
-    var.additional_tags
+      Name = "${var.project_name}-${var.environment}-web-tg"
+    }
   )
 }
 
-# Associate WAF with Application Load Balancer
-resource "aws_wafv2_web_acl_association" "web_acl_association" {
-  count = var.enable_waf ? 1 : 0
+# Mirror Target Group - receives a weighted share of HTTPS traffic for shadow testing
+resource "aws_lb_target_group" "mirror" {
+  count = var.enable_mirror_target_group ? 1 : 0
 
-  resource_arn = aws_lb.web.arn
-  web_acl_arn  = aws_wafv2_web_acl.web_acl[0].arn
+  name     = var.mirror_target_group_name != null ? var.mirror_target_group_name : "${var.project_name}-${var.environment}-mirror-tg"
+  port     = var.target_port
+  protocol = local.target_group_protocol
+  vpc_id   = var.vpc_id
+
+  protocol_version = local.target_group_protocol_version
+
+  health_check {
+    enabled             = true
+    healthy_threshold   = var.healthy_threshold
+    interval            = var.health_check_interval
+    matcher             = local.health_check_matcher
+    path                = var.health_check_path
+    port                = "traffic-port"
+    protocol            = local.target_group_protocol
+    timeout             = var.health_check_timeout
+    unhealthy_threshold = var.unhealthy_threshold
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = var.mirror_target_group_name != null ? var.mirror_target_group_name : "${var.project_name}-${var.environment}-mirror-tg"
+    }
+  )
 }
 
-# Target Group
-resource "aws_lb_target_group" "web" {
-  name     = "${var.project_name}-${var.environment}-web-tg"
+# Green Target Group - the replacement environment for CodeDeploy blue/green deployments.
+# CodeDeploy shifts the HTTPS listener between the web (blue) and green target groups.
+resource "aws_lb_target_group" "green" {
+  count = var.enable_blue_green ? 1 : 0
+
+  name     = var.green_target_group_name != null ? var.green_target_group_name : "${var.project_name}-${var.environment}-green-tg"
   port     = var.target_port
-  protocol = "HTTP"
+  protocol = local.target_group_protocol
   vpc_id   = var.vpc_id
 
+  protocol_version = local.target_group_protocol_version
+
+  deregistration_delay = var.deregistration_delay
+  slow_start           = var.slow_start
+
   health_check {
     enabled             = true
-    healthy_threshold   = 2
-    interval            = 30
-    matcher             = "200"
+    healthy_threshold   = var.healthy_threshold
+    interval            = var.health_check_interval
+    matcher             = local.health_check_matcher
     path                = var.health_check_path
     port                = "traffic-port"
-    protocol            = "HTTP"
-    timeout             = 5
-    unhealthy_threshold = 2
+    protocol            = local.target_group_protocol
+    timeout             = var.health_check_timeout
+    unhealthy_threshold = var.unhealthy_threshold
+  }
+
+  # Fewer healthy targets than the minimum marks the target group unhealthy for
+  # DNS failover and makes the load balancer route to all targets (fail open)
+  target_group_health {
+    dns_failover {
+      minimum_healthy_targets_count      = tostring(var.unhealthy_state_routing_min_healthy_count)
+      minimum_healthy_targets_percentage = var.unhealthy_state_routing_min_healthy_percentage
+    }
+
+    unhealthy_state_routing {
+      minimum_healthy_targets_count      = var.unhealthy_state_routing_min_healthy_count
+      minimum_healthy_targets_percentage = var.unhealthy_state_routing_min_healthy_percentage
+    }
   }
 
+  # Kept even when disabled so turning stickiness off updates the target group instead of leaving it on
   stickiness {
-    type            = "lb_cookie"
-    cookie_duration = 86400
     enabled         = var.enable_stickiness
+    type            = var.enable_stickiness ? var.stickiness_type : "lb_cookie"
+    cookie_duration = var.stickiness_duration
+    cookie_name     = var.enable_stickiness && var.stickiness_type == "app_cookie" ? var.stickiness_cookie_name : null
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-web-tg"
-    Environment = var.environment
-    Module      = "web-application"
+  tags = merge(
+    local.common_tags,
+    {
+      Name = var.green_target_group_name != null ? var.green_target_group_name : "${var.project_name}-${var.environment}-green-tg"
+    }
+  )
+}
+
+# Additional Target Groups - one per additional listener, registered with the Auto Scaling Group
+resource "aws_lb_target_group" "additional" {
+  for_each = local.additional_listeners
+
+  name     = "${var.project_name}-${var.environment}-${each.key}-tg"
+  port     = each.value.target_port
+  protocol = "HTTP"
+  vpc_id   = var.vpc_id
+
+  protocol_version = each.value.protocol_version
+
+  deregistration_delay = var.deregistration_delay
+
+  health_check {
+    enabled             = true
+    healthy_threshold   = var.healthy_threshold
+    interval            = var.health_check_interval
+    matcher             = each.value.health_check_matcher
+    path                = each.value.health_check_path
+    port                = "traffic-port"
+    protocol            = "HTTP"
+    timeout             = var.health_check_timeout
+    unhealthy_threshold = var.unhealthy_threshold
   }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-${each.key}-tg"
+    }
+  )
 }
 
 # HTTP Listener - Always redirect to HTTPS for security
@@ -318,6 +1493,8 @@ resource "aws_lb_listener" "web_http" {
       status_code = "HTTP_301"
     }
   }
+
+  tags = local.common_tags
 }
 
 # Alternative HTTP Listener for environments without HTTPS (only if explicitly disabled)
@@ -332,10 +1509,48 @@ resource "aws_lb_listener" "web_http_forward" {
     type             = "forward"
     target_group_arn = aws_lb_target_group.web.arn
   }
+
+  tags = local.common_tags
 }
 
 # HTTPS Listener (required for security)
 resource "aws_lb_listener" "web_https" {
+  count = local.codedeploy_traffic_control ? 0 : 1
+
+  load_balancer_arn = aws_lb.web.arn
+  port              = "443"
+  protocol          = "HTTPS"
+  ssl_policy        = var.ssl_policy
+  certificate_arn   = var.ssl_certificate_arn != null ? var.ssl_certificate_arn : aws_acm_certificate.default[0].arn
+
+  default_action {
+    type             = "forward"
+    target_group_arn = length(local.https_weighted_target_groups) > 0 ? null : aws_lb_target_group.web.arn
+
+    # Weighted forward splitting traffic with the mirror or green target group
+    dynamic "forward" {
+      for_each = length(local.https_weighted_target_groups) > 0 ? [1] : []
+      content {
+        dynamic "target_group" {
+          for_each = local.https_weighted_target_groups
+          content {
+            arn    = target_group.value.arn
+            weight = target_group.value.weight
+          }
+        }
+      }
+    }
+  }
+
+  tags = local.common_tags
+}
+
+# HTTPS Listener (CodeDeploy blue/green) - CodeDeploy rewrites the default action on
+# every deployment, so Terraform only sets the initial forward to blue. lifecycle
+# arguments cannot be conditional, hence the separate resource.
+resource "aws_lb_listener" "web_https_codedeploy" {
+  count = local.codedeploy_traffic_control ? 1 : 0
+
   load_balancer_arn = aws_lb.web.arn
   port              = "443"
   protocol          = "HTTPS"
@@ -346,6 +1561,85 @@ resource "aws_lb_listener" "web_https" {
     type             = "forward"
     target_group_arn = aws_lb_target_group.web.arn
   }
+
+  tags = local.common_tags
+
+  lifecycle {
+    ignore_changes = [default_action]
+  }
+}
+
+moved {
+  from = aws_lb_listener.web_https
+  to   = aws_lb_listener.web_https[0]
+}
+
+# Additional Listeners - extra ports (e.g. a gRPC port) forwarding to their own target groups
+resource "aws_lb_listener" "additional" {
+  for_each = local.additional_listeners
+
+  load_balancer_arn = aws_lb.web.arn
+  port              = each.value.port
+  protocol          = each.value.protocol
+  ssl_policy        = each.value.protocol == "HTTPS" ? var.ssl_policy : null
+  certificate_arn = each.value.protocol != "HTTPS" ? null : (
+    each.value.certificate_arn != null ? each.value.certificate_arn :
+    var.ssl_certificate_arn != null ? var.ssl_certificate_arn : aws_acm_certificate.default[0].arn
+  )
+
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.additional[each.key].arn
+  }
+
+  tags = local.common_tags
+}
+
+# Listener Rules - path- and host-based routing on the HTTPS listener
+resource "aws_lb_listener_rule" "web" {
+  for_each = { for rule in local.listener_rules : tostring(rule.priority) => rule }
+
+  listener_arn = local.https_listener.arn
+  priority     = each.value.priority
+
+  action {
+    type             = "forward"
+    target_group_arn = each.value.target_group_arn != null ? each.value.target_group_arn : aws_lb_target_group.web.arn
+  }
+
+  dynamic "condition" {
+    for_each = length(each.value.path_patterns) > 0 ? [1] : []
+    content {
+      path_pattern {
+        values = each.value.path_patterns
+      }
+    }
+  }
+
+  dynamic "condition" {
+    for_each = length(each.value.host_headers) > 0 ? [1] : []
+    content {
+      host_header {
+        values = each.value.host_headers
+      }
+    }
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-rule-${each.key}"
+    }
+  )
+}
+
+# SNI Certificates - the listener picks the certificate matching the client's
+# requested hostname and falls back to the default certificate
+resource "aws_lb_listener_certificate" "additional" {
+  count = length(var.additional_certificate_arns)
+
+  listener_arn    = local.https_listener.arn
+  certificate_arn = var.additional_certificate_arns[count.index]
 }
 
 # Default self-signed certificate if none provided (for development)
@@ -360,17 +1654,21 @@ resource "aws_acm_certificate" "default" {
   }
 
   tags = merge(
+    local.common_tags,
     {
-      Name        = "${var.project_name}-${var.environment}-default-cert"
-      Environment = var.environment
-      Module      = "web-application"
-    },
-    var.additional_tags
+      Name = "${var.project_name}-${var.environment}-default-cert"
+    }
   )
 }
 
 # Auto Scaling Policies
+# CPU scaling uses step policies driven by the CPU alarms below; the other
+# metrics use target tracking policies that manage their own alarms.
+# Scaling policies are not taggable in the Auto Scaling API, so ownership
+# tags are carried by the alarms and the Auto Scaling Group instead.
 resource "aws_autoscaling_policy" "scale_up" {
+  count = var.scaling_metric == "cpu" ? 1 : 0
+
   name                   = "${var.project_name}-${var.environment}-web-scale-up"
   scaling_adjustment     = 1
   adjustment_type        = "ChangeInCapacity"
@@ -379,6 +1677,8 @@ resource "aws_autoscaling_policy" "scale_up" {
 }
 
 resource "aws_autoscaling_policy" "scale_down" {
+  count = var.scaling_metric == "cpu" ? 1 : 0
+
   name                   = "${var.project_name}-${var.environment}-web-scale-down"
   scaling_adjustment     = -1
   adjustment_type        = "ChangeInCapacity"
@@ -386,49 +1686,238 @@ resource "aws_autoscaling_policy" "scale_down" {
   autoscaling_group_name = aws_autoscaling_group.web.name
 }
 
+resource "aws_autoscaling_policy" "target_tracking" {
+  count = var.scaling_metric != "cpu" ? 1 : 0
+
+  name                   = "${var.project_name}-${var.environment}-web-${replace(var.scaling_metric, "_", "-")}-tracking"
+  policy_type            = "TargetTrackingScaling"
+  autoscaling_group_name = aws_autoscaling_group.web.name
+
+  target_tracking_configuration {
+    predefined_metric_specification {
+      predefined_metric_type = local.scaling_metric_name
+      resource_label         = var.scaling_metric == "alb_request_count" ? "${aws_lb.web.arn_suffix}/${aws_lb_target_group.web.arn_suffix}" : null
+    }
+
+    target_value = var.scaling_metric == "alb_request_count" ? var.target_requests_per_instance : var.target_network_in_bytes
+  }
+}
+
+moved {
+  from = aws_autoscaling_policy.scale_up
+  to   = aws_autoscaling_policy.scale_up[0]
+}
+
+moved {
+  from = aws_autoscaling_policy.scale_down
+  to   = aws_autoscaling_policy.scale_down[0]
+}
+
+# Alarm Notifications
+resource "aws_sns_topic" "alarms" {
+  count = var.create_alarm_topic ? 1 : 0
+
+  name = "${var.project_name}-${var.environment}-web-alarms"
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-alarms"
+    }
+  )
+}
+
+resource "aws_sns_topic_policy" "alarms" {
+  count = var.create_alarm_topic ? 1 : 0
+
+  arn = aws_sns_topic.alarms[0].arn
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid    = "AllowCloudWatchAlarmsToPublish"
+        Effect = "Allow"
+        Principal = {
+          Service = "cloudwatch.amazonaws.com"
+        }
+        Action   = "SNS:Publish"
+        Resource = aws_sns_topic.alarms[0].arn
+      }
+    ]
+  })
+}
+
+resource "aws_sns_topic_subscription" "alarm_email" {
+  for_each = var.create_alarm_topic ? toset(var.alarm_email_endpoints) : toset([])
+
+  topic_arn = aws_sns_topic.alarms[0].arn
+  protocol  = "email"
+  endpoint  = each.value
+}
+
 # CloudWatch Alarms
 resource "aws_cloudwatch_metric_alarm" "cpu_high" {
+  count = var.scaling_metric == "cpu" ? 1 : 0
+
   alarm_name          = "${var.project_name}-${var.environment}-web-cpu-high"
   comparison_operator = "GreaterThanThreshold"
-  evaluation_periods  = "2"
+  evaluation_periods  = var.alarm_evaluation_periods
+  datapoints_to_alarm = var.alarm_datapoints_to_alarm
   metric_name         = "CPUUtilization"
   namespace           = "AWS/EC2"
-  period              = "120"
+  period              = var.alarm_period
   statistic           = "Average"
   threshold           = var.scale_up_threshold
   alarm_description   = "This metric monitors ec2 cpu utilization"
-  alarm_actions       = [aws_autoscaling_policy.scale_up.arn]
+  treat_missing_data  = var.alarm_treat_missing_data
+  alarm_actions       = concat([aws_autoscaling_policy.scale_up[0].arn], local.alarm_notification_actions)
+  ok_actions          = local.alarm_notification_actions
 
   dimensions = {
     AutoScalingGroupName = aws_autoscaling_group.web.name
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-web-cpu-high"
-    Environment = var.environment
-    Module      = "web-application"
-  }
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-cpu-high"
+    }
+  )
 }
 
 resource "aws_cloudwatch_metric_alarm" "cpu_low" {
+  count = var.scaling_metric == "cpu" ? 1 : 0
+
   alarm_name          = "${var.project_name}-${var.environment}-web-cpu-low"
   comparison_operator = "LessThanThreshold"
-  evaluation_periods  = "2"
+  evaluation_periods  = var.alarm_evaluation_periods
+  datapoints_to_alarm = var.alarm_datapoints_to_alarm
   metric_name         = "CPUUtilization"
   namespace           = "AWS/EC2"
-  period              = "120"
+  period              = var.alarm_period
   statistic           = "Average"
   threshold           = var.scale_down_threshold
   alarm_description   = "This metric monitors ec2 cpu utilization"
-  alarm_actions       = [aws_autoscaling_policy.scale_down.arn]
+  treat_missing_data  = var.alarm_treat_missing_data
+  alarm_actions       = concat([aws_autoscaling_policy.scale_down[0].arn], local.alarm_notification_actions)
+  ok_actions          = local.alarm_notification_actions
+
+  dimensions = {
+    AutoScalingGroupName = aws_autoscaling_group.web.name
+  }
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-cpu-low"
+    }
+  )
+}
+
+# Memory and disk alarms use CloudWatch agent metrics. Without target tracking,
+# high memory also triggers the step scale-up policy since the application can
+# run out of memory well before CPU rises.
+resource "aws_cloudwatch_metric_alarm" "memory_high" {
+  count = var.enable_memory_alarms ? 1 : 0
+
+  alarm_name          = "${var.project_name}-${var.environment}-web-memory-high"
+  comparison_operator = "GreaterThanThreshold"
+  evaluation_periods  = "2"
+  metric_name         = "mem_used_percent"
+  namespace           = var.cloudwatch_agent_namespace
+  period              = "120"
+  statistic           = "Average"
+  threshold           = var.memory_alarm_threshold
+  alarm_description   = "This metric monitors ec2 memory utilization"
+  treat_missing_data  = var.alarm_treat_missing_data
+  alarm_actions       = concat(var.scaling_metric == "cpu" ? [aws_autoscaling_policy.scale_up[0].arn] : [], local.alarm_notification_actions)
+  ok_actions          = local.alarm_notification_actions
 
   dimensions = {
     AutoScalingGroupName = aws_autoscaling_group.web.name
   }
 
-  tags = {
-    Name        = "${var.project_name}-${var.environment}-web-cpu-low"
-    Environment = var.environment
-    Module      = "web-application"
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-memory-high"
+    }
+  )
+}
+
+resource "aws_cloudwatch_metric_alarm" "disk_high" {
+  count = var.enable_disk_alarms ? 1 : 0
+
+  alarm_name          = "${var.project_name}-${var.environment}-web-disk-high"
+  comparison_operator = "GreaterThanThreshold"
+  evaluation_periods  = "2"
+  metric_name         = "disk_used_percent"
+  namespace           = var.cloudwatch_agent_namespace
+  period              = "300"
+  statistic           = "Maximum"
+  threshold           = var.disk_alarm_threshold
+  alarm_description   = "This metric monitors root volume disk utilization"
+  treat_missing_data  = var.alarm_treat_missing_data
+  alarm_actions       = local.alarm_notification_actions
+  ok_actions          = local.alarm_notification_actions
+
+  dimensions = {
+    AutoScalingGroupName = aws_autoscaling_group.web.name
   }
-}
\ No newline at end of file
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = "${var.project_name}-${var.environment}-web-disk-high"
+    }
+  )
+}
+
+# Instance logs shipped by the CloudWatch agent
+resource "aws_cloudwatch_log_group" "instance" {
+  count = var.enable_instance_logs ? 1 : 0
+
+  name              = local.instance_log_group_name
+  retention_in_days = var.instance_log_retention_days
+
+  tags = merge(
+    local.common_tags,
+    {
+      Name = local.instance_log_group_name
+    }
+  )
+}
+
+# Forward instance logs matching the filter pattern to a central aggregator
+resource "aws_cloudwatch_log_subscription_filter" "instance" {
+  count = var.log_subscription_destination_arn != null ? 1 : 0
+
+  name            = "${var.project_name}-${var.environment}-instance-logs"
+  log_group_name  = aws_cloudwatch_log_group.instance[0].name
+  filter_pattern  = var.log_subscription_filter_pattern
+  destination_arn = var.log_subscription_destination_arn
+  role_arn        = local.log_subscription_to_lambda ? null : var.log_subscription_role_arn
+
+  depends_on = [aws_lambda_permission.log_subscription]
+}
+
+resource "aws_lambda_permission" "log_subscription" {
+  count = local.log_subscription_to_lambda ? 1 : 0
+
+  statement_id  = "${var.project_name}-${var.environment}-instance-logs"
+  action        = "lambda:InvokeFunction"
+  function_name = var.log_subscription_destination_arn
+  principal     = "logs.${data.aws_region.current.id}.amazonaws.com"
+  source_arn    = "${aws_cloudwatch_log_group.instance[0].arn}:*"
+}
+
+moved {
+  from = aws_cloudwatch_metric_alarm.cpu_high
+  to   = aws_cloudwatch_metric_alarm.cpu_high[0]
+}
+
+moved {
+  from = aws_cloudwatch_metric_alarm.cpu_low
+  to   = aws_cloudwatch_metric_alarm.cpu_low[0]
+}
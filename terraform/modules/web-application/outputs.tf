@@ -16,6 +16,42 @@ output "autoscaling_group_arn" {
   value       = aws_autoscaling_group.web.arn
 }
 
+output "health_check_type" {
+  description = "Resolved health check type of the Auto Scaling Group (EC2 or ELB)"
+  value       = aws_autoscaling_group.web.health_check_type
+}
+
+# Membership is read at apply time; scaling activity after apply is not reflected
+output "autoscaling_group_instance_ids" {
+  description = "IDs of the instances in the Auto Scaling Group at apply time"
+  value       = var.desired_capacity > 0 ? data.aws_instances.web[0].ids : []
+}
+
+output "autoscaling_group_private_ips" {
+  description = "Private IP addresses of the instances in the Auto Scaling Group at apply time"
+  value       = var.desired_capacity > 0 ? data.aws_instances.web[0].private_ips : []
+}
+
+output "suspended_processes" {
+  description = "Auto Scaling processes suspended on the Auto Scaling Group"
+  value       = var.suspended_processes
+}
+
+output "termination_policies" {
+  description = "Termination policies applied in order when the Auto Scaling Group scales in"
+  value       = aws_autoscaling_group.web.termination_policies
+}
+
+output "warm_pool_enabled" {
+  description = "Whether a warm pool is attached to the Auto Scaling Group"
+  value       = var.enable_warm_pool
+}
+
+output "lifecycle_hook_names" {
+  description = "Names of the lifecycle hooks attached to the Auto Scaling Group"
+  value       = [for hook in aws_autoscaling_lifecycle_hook.web : hook.name]
+}
+
 # Launch Template
 output "launch_template_id" {
   description = "ID of the Launch Template"
@@ -27,6 +63,36 @@ output "launch_template_latest_version" {
   value       = aws_launch_template.web.latest_version
 }
 
+output "launch_template_default_version" {
+  description = "Default version of the Launch Template"
+  value       = aws_launch_template.web.default_version
+}
+
+output "placement_group_name" {
+  description = "Name of the placement group the launch template places instances in (null when none)"
+  value       = local.placement_group_name
+}
+
+output "launch_template_version" {
+  description = "Launch template version referenced by the Auto Scaling Group"
+  value       = var.launch_template_version
+}
+
+output "instance_profile_name" {
+  description = "Name of the instance profile used by the launch template"
+  value       = local.instance_profile_name
+}
+
+output "instance_role_arn" {
+  description = "ARN of the instance role (the shared or module-created role; null when an existing instance profile is supplied)"
+  value       = local.instance_role_arn
+}
+
+output "data_volume_device_names" {
+  description = "Device names of the additional EBS data volumes"
+  value       = [for volume in var.data_volumes : volume.device_name]
+}
+
 # Application Load Balancer
 output "load_balancer_id" {
   description = "ID of the Application Load Balancer"
@@ -43,11 +109,68 @@ output "load_balancer_dns_name" {
   value       = aws_lb.web.dns_name
 }
 
+output "load_balancer_full_name" {
+  description = "ARN suffix of the Application Load Balancer (app/<name>/<id>), the LoadBalancer dimension for ALB metrics"
+  value       = aws_lb.web.arn_suffix
+}
+
 output "load_balancer_zone_id" {
   description = "Canonical hosted zone ID of the load balancer"
   value       = aws_lb.web.zone_id
 }
 
+output "load_balancer_scheme" {
+  description = "Scheme of the load balancer (internal or internet-facing)"
+  value       = aws_lb.web.internal ? "internal" : "internet-facing"
+}
+
+output "load_balancer_deletion_protection_enabled" {
+  description = "Whether deletion protection is enabled on the load balancer (resolved from the environment when not set)"
+  value       = aws_lb.web.enable_deletion_protection
+}
+
+output "load_balancer_idle_timeout" {
+  description = "Idle timeout of the load balancer in seconds"
+  value       = aws_lb.web.idle_timeout
+}
+
+output "load_balancer_ip_address_type" {
+  description = "IP address type of the load balancer (ipv4 or dualstack)"
+  value       = aws_lb.web.ip_address_type
+}
+
+output "alb_security_group_id" {
+  description = "ID of the security group attached to the load balancer"
+  value       = local.alb_security_group_id
+}
+
+# PrivateLink
+output "nlb_arn" {
+  description = "ARN of the Network Load Balancer fronting the ALB (nlb_mode only)"
+  value       = var.nlb_mode ? aws_lb.nlb[0].arn : null
+}
+
+output "nlb_dns_name" {
+  description = "DNS name of the Network Load Balancer fronting the ALB (nlb_mode only)"
+  value       = var.nlb_mode ? aws_lb.nlb[0].dns_name : null
+}
+
+output "endpoint_service_id" {
+  description = "ID of the PrivateLink VPC endpoint service (if enabled)"
+  value       = var.enable_endpoint_service ? aws_vpc_endpoint_service.web[0].id : null
+}
+
+output "endpoint_service_name" {
+  description = "Service name consumers use to create interface endpoints to the application (if enabled)"
+  value       = var.enable_endpoint_service ? aws_vpc_endpoint_service.web[0].service_name : null
+}
+
+# DNS
+output "dns_record_fqdns" {
+  description = "Fully qualified names of the Route53 alias records pointing at the ALB"
+  value       = distinct([for record in aws_route53_record.alb : record.fqdn])
+}
+
 # Target Group
 output "target_group_id" {
   description = "ID of the Target Group"
@@ -59,6 +182,88 @@ output "target_group_arn" {
   value       = aws_lb_target_group.web.arn
 }
 
+output "target_group_full_name" {
+  description = "ARN suffix of the Target Group (targetgroup/<name>/<id>), the TargetGroup dimension for RequestCountPerTarget and other per-target-group metrics"
+  value       = aws_lb_target_group.web.arn_suffix
+}
+
+output "target_group_protocol" {
+  description = "Resolved protocol of the target group"
+  value       = aws_lb_target_group.web.protocol
+}
+
+output "target_group_protocol_version" {
+  description = "Resolved protocol version of the target group"
+  value       = aws_lb_target_group.web.protocol_version
+}
+
+output "stickiness_enabled" {
+  description = "Whether session stickiness is enabled on the target group"
+  value       = var.enable_stickiness
+}
+
+output "target_group_deregistration_delay" {
+  description = "Effective deregistration delay of the Target Group in seconds"
+  value       = aws_lb_target_group.web.deregistration_delay
+}
+
+output "mirror_target_group_arn" {
+  description = "ARN of the mirror target group"
+  value       = var.enable_mirror_target_group ? aws_lb_target_group.mirror[0].arn : null
+}
+
+output "mirror_traffic_weight" {
+  description = "Percentage of HTTPS traffic forwarded to the mirror target group"
+  value       = var.enable_mirror_target_group ? var.mirror_traffic_weight : 0
+}
+
+output "target_group_name" {
+  description = "Name of the Target Group (the blue target group for blue/green deployments)"
+  value       = aws_lb_target_group.web.name
+}
+
+output "green_target_group_arn" {
+  description = "ARN of the green target group (if blue/green is enabled)"
+  value       = var.enable_blue_green ? aws_lb_target_group.green[0].arn : null
+}
+
+output "green_target_group_name" {
+  description = "Name of the green target group (if blue/green is enabled)"
+  value       = var.enable_blue_green ? aws_lb_target_group.green[0].name : null
+}
+
+output "active_target_group_arn" {
+  description = "ARN of the target group the Auto Scaling Group registers instances with"
+  value       = local.active_target_group_arn
+}
+
+output "blue_green_weights" {
+  description = "HTTPS traffic weights for the blue and green target groups (if blue/green is enabled with weights traffic control)"
+  value       = var.enable_blue_green && !local.codedeploy_traffic_control ? { blue = var.blue_weight, green = var.green_weight } : null
+}
+
+# Shaped like the aws_codedeploy_deployment_group load_balancer_info block
+output "codedeploy_ready" {
+  description = "Target group pair (production listener plus blue and green target groups) for a CodeDeploy blue/green deployment group (if enabled)"
+  value = var.enable_blue_green ? {
+    target_group_pair_info = {
+      prod_traffic_route = {
+        listener_arns = [local.https_listener.arn]
+      }
+      target_groups = [
+        {
+          name = aws_lb_target_group.web.name
+          arn  = aws_lb_target_group.web.arn
+        },
+        {
+          name = aws_lb_target_group.green[0].name
+          arn  = aws_lb_target_group.green[0].arn
+        }
+      ]
+    }
+  } : null
+}
+
 # Listeners
 output "http_listener_arn" {
   description = "ARN of the HTTP listener"
@@ -67,29 +272,103 @@ output "http_listener_arn" {
 
 output "https_listener_arn" {
   description = "ARN of the HTTPS listener"
-  value       = aws_lb_listener.web_https.arn
+  value       = local.https_listener.arn
+}
+
+output "additional_certificate_arns" {
+  description = "ARNs of the additional SNI certificates attached to the HTTPS listener"
+  value       = aws_lb_listener_certificate.additional[*].certificate_arn
+}
+
+output "listener_rule_priorities" {
+  description = "Resolved priority of each listener rule, in listener_rules order"
+  value       = local.listener_rules[*].priority
+}
+
+output "listener_rule_arns" {
+  description = "Map of listener rule priority to listener rule ARN"
+  value       = { for priority, rule in aws_lb_listener_rule.web : priority => rule.arn }
+}
+
+output "additional_listener_arns" {
+  description = "Map of additional listener port to listener ARN"
+  value       = { for port, listener in aws_lb_listener.additional : port => listener.arn }
+}
+
+output "additional_target_group_arns" {
+  description = "Map of additional listener port to target group ARN"
+  value       = { for port, group in aws_lb_target_group.additional : port => group.arn }
+}
+
+output "asg_target_group_arns" {
+  description = "ARNs of every target group attached to the Auto Scaling Group"
+  value       = [for attachment in aws_autoscaling_attachment.web : attachment.lb_target_group_arn]
 }
 
 # Auto Scaling Policies
 output "scale_up_policy_arn" {
   description = "ARN of the scale up policy"
-  value       = aws_autoscaling_policy.scale_up.arn
+  value       = var.scaling_metric == "cpu" ? aws_autoscaling_policy.scale_up[0].arn : null
 }
 
 output "scale_down_policy_arn" {
   description = "ARN of the scale down policy"
-  value       = aws_autoscaling_policy.scale_down.arn
+  value       = var.scaling_metric == "cpu" ? aws_autoscaling_policy.scale_down[0].arn : null
+}
+
+output "target_tracking_policy_arn" {
+  description = "ARN of the target tracking scaling policy (request count or network in scaling)"
+  value       = var.scaling_metric != "cpu" ? aws_autoscaling_policy.target_tracking[0].arn : null
+}
+
+output "scaling_metric_name" {
+  description = "Name of the metric the Auto Scaling Group scales on"
+  value       = local.scaling_metric_name
 }
 
 # CloudWatch Alarms
 output "cpu_high_alarm_arn" {
   description = "ARN of the CPU high alarm"
-  value       = aws_cloudwatch_metric_alarm.cpu_high.arn
+  value       = var.scaling_metric == "cpu" ? aws_cloudwatch_metric_alarm.cpu_high[0].arn : null
 }
 
 output "cpu_low_alarm_arn" {
   description = "ARN of the CPU low alarm"
-  value       = aws_cloudwatch_metric_alarm.cpu_low.arn
+  value       = var.scaling_metric == "cpu" ? aws_cloudwatch_metric_alarm.cpu_low[0].arn : null
+}
+
+output "cpu_alarm_evaluation" {
+  description = "Period (seconds), evaluation periods, and datapoints to alarm used by the CPU high and CPU low alarms (datapoints to alarm equals evaluation periods when alarm_datapoints_to_alarm is unset)"
+  value = {
+    period              = var.alarm_period
+    evaluation_periods  = var.alarm_evaluation_periods
+    datapoints_to_alarm = coalesce(var.alarm_datapoints_to_alarm, var.alarm_evaluation_periods)
+  }
+}
+
+output "alarm_sns_topic_arn" {
+  description = "ARN of the SNS topic notified by the module's alarms (null when notifications are disabled)"
+  value       = local.alarm_topic_arn
+}
+
+output "memory_high_alarm_arn" {
+  description = "ARN of the memory high alarm (if enabled)"
+  value       = var.enable_memory_alarms ? aws_cloudwatch_metric_alarm.memory_high[0].arn : null
+}
+
+output "disk_high_alarm_arn" {
+  description = "ARN of the disk high alarm (if enabled)"
+  value       = var.enable_disk_alarms ? aws_cloudwatch_metric_alarm.disk_high[0].arn : null
+}
+
+output "instance_log_group_name" {
+  description = "Name of the log group the CloudWatch agent ships instance logs to (if enabled)"
+  value       = var.enable_instance_logs ? aws_cloudwatch_log_group.instance[0].name : null
+}
+
+output "log_subscription_filter_name" {
+  description = "Name of the subscription filter forwarding instance logs (if configured)"
+  value       = var.log_subscription_destination_arn != null ? aws_cloudwatch_log_subscription_filter.instance[0].name : null
 }
 
 # WAF Outputs
@@ -106,4 +385,74 @@ output "waf_web_acl_id" {
 output "waf_web_acl_name" {
   description = "Name of the WAF Web ACL"
   value       = var.enable_waf ? aws_wafv2_web_acl.web_acl[0].name : null
-}
\ No newline at end of file
+}
+
+output "waf_ip_allowlist_arn" {
+  description = "ARN of the WAF IP set of always-allowed addresses (if an allowlist is configured)"
+  value       = var.enable_waf && length(var.waf_ip_allowlist) > 0 ? aws_wafv2_ip_set.allowlist[0].arn : null
+}
+
+output "waf_ip_blocklist_arn" {
+  description = "ARN of the WAF IP set of always-blocked addresses (if a blocklist is configured)"
+  value       = var.enable_waf && length(var.waf_ip_blocklist) > 0 ? aws_wafv2_ip_set.blocklist[0].arn : null
+}
+
+output "waf_log_group_name" {
+  description = "Name of the CloudWatch log group receiving WAF logs (if enabled)"
+  value       = local.waf_logs_to_cloudwatch ? aws_cloudwatch_log_group.waf[0].name : null
+}
+
+output "waf_firehose_delivery_stream_arn" {
+  description = "ARN of the Firehose delivery stream receiving WAF logs (if the destination is firehose)"
+  value       = local.waf_logs_to_firehose ? aws_kinesis_firehose_delivery_stream.waf[0].arn : null
+}
+
+output "waf_log_bucket_id" {
+  description = "Name of the S3 bucket storing WAF logs delivered by Firehose (if the destination is firehose)"
+  value       = local.waf_logs_to_firehose ? aws_s3_bucket.waf_logs[0].id : null
+}
+
+output "waf_managed_rule_group_names" {
+  description = "Names of the AWS managed rule groups enabled in the WAF Web ACL"
+  value       = var.enable_waf && var.enable_managed_rules ? [for group in var.managed_rule_groups : group.name] : []
+}
+
+output "waf_rules_summary" {
+  description = "Name, priority, and action of each WAF rule in evaluation order (empty when WAF is disabled)"
+  value       = var.enable_waf ? local.waf_rules_summary : []
+}
+
+output "common_tags" {
+  description = "Tags applied to every taggable resource in the module"
+  value       = local.common_tags
+}
+
+# Deployment Summary
+output "deployment_summary" {
+  description = "Consolidated map of key networking, compute, and security identifiers for CI pipelines"
+  value = {
+    networking = {
+      load_balancer_arn      = aws_lb.web.arn
+      load_balancer_dns_name = aws_lb.web.dns_name
+      load_balancer_zone_id  = aws_lb.web.zone_id
+      target_group_arn       = aws_lb_target_group.web.arn
+      http_listener_arn      = aws_lb_listener.web_http.arn
+      https_listener_arn     = local.https_listener.arn
+      dns_record_fqdns       = distinct([for record in aws_route53_record.alb : record.fqdn])
+      endpoint_service_name  = var.enable_endpoint_service ? aws_vpc_endpoint_service.web[0].service_name : null
+    }
+    compute = {
+      autoscaling_group_name         = aws_autoscaling_group.web.name
+      autoscaling_group_arn          = aws_autoscaling_group.web.arn
+      launch_template_id             = aws_launch_template.web.id
+      launch_template_latest_version = aws_launch_template.web.latest_version
+      scaling_metric_name            = local.scaling_metric_name
+    }
+    security = {
+      alb_security_group_id = local.alb_security_group_id
+      instance_profile_name = local.instance_profile_name
+      certificate_arn       = local.https_listener.certificate_arn
+      waf_web_acl_arn       = var.enable_waf ? aws_wafv2_web_acl.web_acl[0].arn : null
+    }
+  }
+}
@@ -0,0 +1,1778 @@
+# Validation Rules - Web Application Module
+# Plans against a mocked AWS provider so every custom variable validation can be
+# exercised without credentials or AWS API calls. Each run sets one invalid input
+# on top of the valid inputs below and expects only that validation to fail.
+#
+#   terraform test -filter=tests/validation.tftest.hcl
+
+test {
+  parallel = true
+}
+
+mock_provider "aws" {}
+
+variables {
+  project_name          = "test-epic"
+  environment           = "staging"
+  application_name      = "test-app"
+  vpc_id                = "vpc-123"
+  subnet_ids            = ["subnet-123"]
+  public_subnet_ids     = ["subnet-456"]
+  security_group_id     = "sg-123"
+  alb_security_group_id = "sg-456"
+  instance_profile_name = "test-profile"
+}
+
+run "valid_defaults" {
+  command   = plan
+  state_key = "valid_defaults"
+}
+
+run "invalid_project_name" {
+  command   = plan
+  state_key = "invalid_project_name"
+
+  variables {
+    project_name = "-epic"
+  }
+
+  expect_failures = [var.project_name]
+}
+
+run "invalid_environment" {
+  command   = plan
+  state_key = "invalid_environment"
+
+  variables {
+    environment = "shared"
+  }
+
+  expect_failures = [var.environment]
+}
+
+run "empty_application_name" {
+  command   = plan
+  state_key = "empty_application_name"
+
+  variables {
+    application_name = ""
+  }
+
+  expect_failures = [var.application_name]
+}
+
+run "internet_facing_without_public_subnets" {
+  command   = plan
+  state_key = "internet_facing_without_public_subnets"
+
+  variables {
+    public_subnet_ids = []
+  }
+
+  expect_failures = [var.public_subnet_ids]
+}
+
+run "idle_timeout_too_high" {
+  command   = plan
+  state_key = "idle_timeout_too_high"
+
+  variables {
+    idle_timeout = 4001
+  }
+
+  expect_failures = [var.idle_timeout]
+}
+
+run "endpoint_service_without_nlb_mode" {
+  command   = plan
+  state_key = "endpoint_service_without_nlb_mode"
+
+  variables {
+    enable_endpoint_service = true
+  }
+
+  expect_failures = [var.enable_endpoint_service]
+}
+
+run "invalid_endpoint_service_allowed_principal" {
+  command   = plan
+  state_key = "invalid_endpoint_service_allowed_principal"
+
+  variables {
+    endpoint_service_allowed_principals = ["123456789012"]
+  }
+
+  expect_failures = [var.endpoint_service_allowed_principals]
+}
+
+run "invalid_ip_address_type" {
+  command   = plan
+  state_key = "invalid_ip_address_type"
+
+  variables {
+    ip_address_type = "ipv6"
+  }
+
+  expect_failures = [var.ip_address_type]
+}
+
+run "invalid_additional_security_group_id" {
+  command   = plan
+  state_key = "invalid_additional_security_group_id"
+
+  variables {
+    additional_security_group_ids = ["monitoring"]
+  }
+
+  expect_failures = [var.additional_security_group_ids]
+}
+
+run "invalid_alb_ingress_cidr" {
+  command   = plan
+  state_key = "invalid_alb_ingress_cidr"
+
+  variables {
+    alb_ingress_cidr_blocks = ["0.0.0.0"]
+  }
+
+  expect_failures = [var.alb_ingress_cidr_blocks]
+}
+
+run "ipv4_alb_ingress_ipv6_cidr" {
+  command   = plan
+  state_key = "ipv4_alb_ingress_ipv6_cidr"
+
+  variables {
+    alb_ingress_ipv6_cidr_blocks = ["10.0.0.0/8"]
+  }
+
+  expect_failures = [var.alb_ingress_ipv6_cidr_blocks]
+}
+
+run "invalid_alb_ingress_prefix_list_id" {
+  command   = plan
+  state_key = "invalid_alb_ingress_prefix_list_id"
+
+  variables {
+    alb_security_group_id       = null
+    alb_ingress_prefix_list_ids = ["prefix-0123456789abcdef0"]
+  }
+
+  expect_failures = [var.alb_ingress_prefix_list_ids]
+}
+
+run "alb_ingress_prefix_list_with_existing_security_group" {
+  command   = plan
+  state_key = "alb_ingress_prefix_list_with_existing_security_group"
+
+  variables {
+    alb_ingress_prefix_list_ids = ["pl-0123456789abcdef0"]
+  }
+
+  expect_failures = [var.alb_ingress_prefix_list_ids]
+}
+
+run "invalid_shared_instance_role_arn" {
+  command   = plan
+  state_key = "invalid_shared_instance_role_arn"
+
+  variables {
+    instance_profile_name    = null
+    shared_instance_role_arn = "role/shared-web"
+  }
+
+  expect_failures = [var.shared_instance_role_arn]
+}
+
+run "multiple_instance_role_sources" {
+  command   = plan
+  state_key = "multiple_instance_role_sources"
+
+  variables {
+    create_instance_role = true
+  }
+
+  expect_failures = [var.create_instance_role]
+}
+
+run "invalid_instance_type" {
+  command   = plan
+  state_key = "invalid_instance_type"
+
+  variables {
+    instance_type = "t3.huge"
+  }
+
+  expect_failures = [var.instance_type]
+}
+
+run "invalid_cpu_credits" {
+  command   = plan
+  state_key = "invalid_cpu_credits"
+
+  variables {
+    cpu_credits = "burst"
+  }
+
+  expect_failures = [var.cpu_credits]
+}
+
+run "invalid_placement_group_name" {
+  command   = plan
+  state_key = "invalid_placement_group_name"
+
+  variables {
+    placement_group_name = "web pg"
+  }
+
+  expect_failures = [var.placement_group_name]
+}
+
+run "invalid_placement_strategy" {
+  command   = plan
+  state_key = "invalid_placement_strategy"
+
+  variables {
+    placement_strategy = "host"
+  }
+
+  expect_failures = [var.placement_strategy]
+}
+
+run "invalid_launch_template_version" {
+  command   = plan
+  state_key = "invalid_launch_template_version"
+
+  variables {
+    launch_template_version = "latest"
+  }
+
+  expect_failures = [var.launch_template_version]
+}
+
+run "mixed_instances_with_warm_pool" {
+  command   = plan
+  state_key = "mixed_instances_with_warm_pool"
+
+  variables {
+    enable_mixed_instances = true
+    enable_warm_pool       = true
+  }
+
+  expect_failures = [var.enable_mixed_instances]
+}
+
+run "no_mixed_instance_types" {
+  command   = plan
+  state_key = "no_mixed_instance_types"
+
+  variables {
+    instance_types = []
+  }
+
+  expect_failures = [var.instance_types]
+}
+
+run "invalid_mixed_instance_type" {
+  command   = plan
+  state_key = "invalid_mixed_instance_type"
+
+  variables {
+    instance_types = ["t3.micro", "t3.tiny"]
+  }
+
+  expect_failures = [var.instance_types]
+}
+
+run "negative_on_demand_base_capacity" {
+  command   = plan
+  state_key = "negative_on_demand_base_capacity"
+
+  variables {
+    on_demand_base_capacity = -1
+  }
+
+  expect_failures = [var.on_demand_base_capacity]
+}
+
+run "on_demand_percentage_too_high" {
+  command   = plan
+  state_key = "on_demand_percentage_too_high"
+
+  variables {
+    on_demand_percentage_above_base_capacity = 101
+  }
+
+  expect_failures = [var.on_demand_percentage_above_base_capacity]
+}
+
+run "invalid_spot_allocation_strategy" {
+  command   = plan
+  state_key = "invalid_spot_allocation_strategy"
+
+  variables {
+    spot_allocation_strategy = "cheapest"
+  }
+
+  expect_failures = [var.spot_allocation_strategy]
+}
+
+run "user_data_and_template_file" {
+  command   = plan
+  state_key = "user_data_and_template_file"
+
+  variables {
+    user_data               = "#!/bin/bash"
+    user_data_template_file = "user_data.sh.tftpl"
+  }
+
+  expect_failures = [var.user_data_template_file]
+}
+
+run "root_volume_too_small" {
+  command   = plan
+  state_key = "root_volume_too_small"
+
+  variables {
+    root_volume_size = 4
+  }
+
+  expect_failures = [var.root_volume_size]
+}
+
+run "data_volume_size_zero" {
+  command   = plan
+  state_key = "data_volume_size_zero"
+
+  variables {
+    data_volumes = [{ device_name = "/dev/sdf", size = 0 }]
+  }
+
+  expect_failures = [var.data_volumes]
+}
+
+run "invalid_data_volume_type" {
+  command   = plan
+  state_key = "invalid_data_volume_type"
+
+  variables {
+    data_volumes = [{ device_name = "/dev/sdf", size = 100, type = "gp4" }]
+  }
+
+  expect_failures = [var.data_volumes]
+}
+
+run "data_volume_on_root_device" {
+  command   = plan
+  state_key = "data_volume_on_root_device"
+
+  variables {
+    data_volumes = [{ device_name = "/dev/xvda", size = 100 }]
+  }
+
+  expect_failures = [var.data_volumes]
+}
+
+run "duplicate_data_volume_device_names" {
+  command   = plan
+  state_key = "duplicate_data_volume_device_names"
+
+  variables {
+    data_volumes = [
+      { device_name = "/dev/sdf", size = 100 },
+      { device_name = "/dev/sdf", size = 200 }
+    ]
+  }
+
+  expect_failures = [var.data_volumes]
+}
+
+run "too_many_instance_store_devices" {
+  command   = plan
+  state_key = "too_many_instance_store_devices"
+
+  variables {
+    instance_type               = "m5d.large"
+    instance_store_device_names = ["/dev/sdb", "/dev/sdc", "/dev/sdd", "/dev/sde", "/dev/sdf", "/dev/sdg", "/dev/sdh", "/dev/sdi", "/dev/sdj", "/dev/sdk", "/dev/sdl", "/dev/sdm", "/dev/sdn", "/dev/sdo", "/dev/sdp", "/dev/sdq", "/dev/sdr", "/dev/sds", "/dev/sdt", "/dev/sdu", "/dev/sdv", "/dev/sdw", "/dev/sdx", "/dev/sdy", "/dev/sdz"]
+  }
+
+  expect_failures = [var.instance_store_device_names]
+}
+
+run "duplicate_instance_store_devices" {
+  command   = plan
+  state_key = "duplicate_instance_store_devices"
+
+  variables {
+    instance_type               = "m5d.large"
+    instance_store_device_names = ["/dev/sdb", "/dev/sdb"]
+  }
+
+  expect_failures = [var.instance_store_device_names]
+}
+
+run "instance_store_on_root_device" {
+  command   = plan
+  state_key = "instance_store_on_root_device"
+
+  variables {
+    instance_type               = "m5d.large"
+    instance_store_device_names = ["/dev/xvda"]
+  }
+
+  expect_failures = [var.instance_store_device_names]
+}
+
+run "instance_store_without_instance_storage" {
+  command   = plan
+  state_key = "instance_store_without_instance_storage"
+
+  variables {
+    instance_store_device_names = ["/dev/sdb"]
+  }
+
+  expect_failures = [var.instance_store_device_names]
+}
+
+run "min_size_too_high" {
+  command   = plan
+  state_key = "min_size_too_high"
+
+  variables {
+    min_size = 101
+  }
+
+  expect_failures = [var.min_size]
+}
+
+run "max_size_zero" {
+  command   = plan
+  state_key = "max_size_zero"
+
+  variables {
+    max_size = 0
+  }
+
+  expect_failures = [var.max_size]
+}
+
+run "desired_capacity_too_high" {
+  command   = plan
+  state_key = "desired_capacity_too_high"
+
+  variables {
+    desired_capacity = 1001
+  }
+
+  expect_failures = [var.desired_capacity]
+}
+
+run "invalid_health_check_type" {
+  command   = plan
+  state_key = "invalid_health_check_type"
+
+  variables {
+    health_check_type = "HTTP"
+  }
+
+  expect_failures = [var.health_check_type]
+}
+
+run "invalid_suspended_process" {
+  command   = plan
+  state_key = "invalid_suspended_process"
+
+  variables {
+    suspended_processes = ["Reboot"]
+  }
+
+  expect_failures = [var.suspended_processes]
+}
+
+run "invalid_termination_policy" {
+  command   = plan
+  state_key = "invalid_termination_policy"
+
+  variables {
+    termination_policies = ["YoungestInstance"]
+  }
+
+  expect_failures = [var.termination_policies]
+}
+
+run "duplicate_termination_policies" {
+  command   = plan
+  state_key = "duplicate_termination_policies"
+
+  variables {
+    termination_policies = ["OldestInstance", "OldestInstance"]
+  }
+
+  expect_failures = [var.termination_policies]
+}
+
+run "capacity_rebalance_without_mixed_instances" {
+  command   = plan
+  state_key = "capacity_rebalance_without_mixed_instances"
+
+  variables {
+    capacity_rebalance = true
+  }
+
+  expect_failures = [var.capacity_rebalance]
+}
+
+run "health_check_grace_period_too_long" {
+  command   = plan
+  state_key = "health_check_grace_period_too_long"
+
+  variables {
+    health_check_grace_period = 7201
+  }
+
+  expect_failures = [var.health_check_grace_period]
+}
+
+run "invalid_scaling_metric" {
+  command   = plan
+  state_key = "invalid_scaling_metric"
+
+  variables {
+    scaling_metric = "memory"
+  }
+
+  expect_failures = [var.scaling_metric]
+}
+
+run "zero_target_requests_per_instance" {
+  command   = plan
+  state_key = "zero_target_requests_per_instance"
+
+  variables {
+    target_requests_per_instance = 0
+  }
+
+  expect_failures = [var.target_requests_per_instance]
+}
+
+run "zero_target_network_in_bytes" {
+  command   = plan
+  state_key = "zero_target_network_in_bytes"
+
+  variables {
+    target_network_in_bytes = 0
+  }
+
+  expect_failures = [var.target_network_in_bytes]
+}
+
+run "scale_up_threshold_too_high" {
+  command   = plan
+  state_key = "scale_up_threshold_too_high"
+
+  variables {
+    scale_up_threshold = 101
+  }
+
+  expect_failures = [var.scale_up_threshold]
+}
+
+run "scale_down_threshold_zero" {
+  command   = plan
+  state_key = "scale_down_threshold_zero"
+
+  variables {
+    scale_down_threshold = 0
+  }
+
+  expect_failures = [var.scale_down_threshold]
+}
+
+run "memory_alarm_threshold_zero" {
+  command   = plan
+  state_key = "memory_alarm_threshold_zero"
+
+  variables {
+    memory_alarm_threshold = 0
+  }
+
+  expect_failures = [var.memory_alarm_threshold]
+}
+
+run "disk_alarm_threshold_too_high" {
+  command   = plan
+  state_key = "disk_alarm_threshold_too_high"
+
+  variables {
+    disk_alarm_threshold = 101
+  }
+
+  expect_failures = [var.disk_alarm_threshold]
+}
+
+run "instance_logs_without_cloudwatch_agent" {
+  command   = plan
+  state_key = "instance_logs_without_cloudwatch_agent"
+
+  variables {
+    enable_instance_logs = true
+  }
+
+  expect_failures = [var.enable_instance_logs]
+}
+
+run "relative_instance_log_file" {
+  command   = plan
+  state_key = "relative_instance_log_file"
+
+  variables {
+    instance_log_files = ["var/log/messages"]
+  }
+
+  expect_failures = [var.instance_log_files]
+}
+
+run "invalid_instance_log_retention_days" {
+  command   = plan
+  state_key = "invalid_instance_log_retention_days"
+
+  variables {
+    instance_log_retention_days = 45
+  }
+
+  expect_failures = [var.instance_log_retention_days]
+}
+
+run "invalid_log_subscription_destination_arn" {
+  command   = plan
+  state_key = "invalid_log_subscription_destination_arn"
+
+  variables {
+    enable_cloudwatch_agent          = true
+    enable_instance_logs             = true
+    log_subscription_destination_arn = "arn:aws:sqs:us-east-1:123456789012:central-logs"
+  }
+
+  expect_failures = [var.log_subscription_destination_arn]
+}
+
+run "log_subscription_without_instance_logs" {
+  command   = plan
+  state_key = "log_subscription_without_instance_logs"
+
+  variables {
+    log_subscription_destination_arn = "arn:aws:lambda:us-east-1:123456789012:function:log-forwarder"
+  }
+
+  expect_failures = [var.log_subscription_destination_arn]
+}
+
+run "invalid_log_subscription_role_arn" {
+  command   = plan
+  state_key = "invalid_log_subscription_role_arn"
+
+  variables {
+    log_subscription_role_arn = "cwl-to-kinesis"
+  }
+
+  expect_failures = [var.log_subscription_role_arn]
+}
+
+run "kinesis_log_subscription_without_role" {
+  command   = plan
+  state_key = "kinesis_log_subscription_without_role"
+
+  variables {
+    enable_cloudwatch_agent          = true
+    enable_instance_logs             = true
+    log_subscription_destination_arn = "arn:aws:kinesis:us-east-1:123456789012:stream/central-logs"
+  }
+
+  expect_failures = [var.log_subscription_role_arn]
+}
+
+run "invalid_alarm_sns_topic_arn" {
+  command   = plan
+  state_key = "invalid_alarm_sns_topic_arn"
+
+  variables {
+    alarm_sns_topic_arn = "alerts"
+  }
+
+  expect_failures = [var.alarm_sns_topic_arn]
+}
+
+run "alarm_topic_and_existing_topic" {
+  command   = plan
+  state_key = "alarm_topic_and_existing_topic"
+
+  variables {
+    create_alarm_topic  = true
+    alarm_sns_topic_arn = "arn:aws:sns:us-east-1:123456789012:alerts"
+  }
+
+  expect_failures = [var.create_alarm_topic]
+}
+
+run "alarm_emails_without_topic" {
+  command   = plan
+  state_key = "alarm_emails_without_topic"
+
+  variables {
+    alarm_email_endpoints = ["ops@example.com"]
+  }
+
+  expect_failures = [var.alarm_email_endpoints]
+}
+
+run "invalid_alarm_email" {
+  command   = plan
+  state_key = "invalid_alarm_email"
+
+  variables {
+    create_alarm_topic    = true
+    alarm_email_endpoints = ["ops.example.com"]
+  }
+
+  expect_failures = [var.alarm_email_endpoints]
+}
+
+run "invalid_alarm_period" {
+  command   = plan
+  state_key = "invalid_alarm_period"
+
+  variables {
+    alarm_period = 90
+  }
+
+  expect_failures = [var.alarm_period]
+}
+
+run "alarm_evaluation_periods_longer_than_a_day" {
+  command   = plan
+  state_key = "alarm_evaluation_periods_longer_than_a_day"
+
+  variables {
+    alarm_period             = 3600
+    alarm_evaluation_periods = 25
+  }
+
+  expect_failures = [var.alarm_evaluation_periods]
+}
+
+run "alarm_datapoints_to_alarm_exceeding_evaluation_periods" {
+  command   = plan
+  state_key = "alarm_datapoints_to_alarm_exceeding_evaluation_periods"
+
+  variables {
+    alarm_evaluation_periods  = 3
+    alarm_datapoints_to_alarm = 5
+  }
+
+  expect_failures = [var.alarm_datapoints_to_alarm]
+}
+
+run "invalid_alarm_treat_missing_data" {
+  command   = plan
+  state_key = "invalid_alarm_treat_missing_data"
+
+  variables {
+    alarm_treat_missing_data = "zero"
+  }
+
+  expect_failures = [var.alarm_treat_missing_data]
+}
+
+run "invalid_warm_pool_state" {
+  command   = plan
+  state_key = "invalid_warm_pool_state"
+
+  variables {
+    warm_pool_state = "Frozen"
+  }
+
+  expect_failures = [var.warm_pool_state]
+}
+
+run "negative_warm_pool_min_size" {
+  command   = plan
+  state_key = "negative_warm_pool_min_size"
+
+  variables {
+    warm_pool_min_size = -1
+  }
+
+  expect_failures = [var.warm_pool_min_size]
+}
+
+run "warm_pool_max_prepared_below_min" {
+  command   = plan
+  state_key = "warm_pool_max_prepared_below_min"
+
+  variables {
+    warm_pool_min_size              = 2
+    warm_pool_max_prepared_capacity = 1
+  }
+
+  expect_failures = [var.warm_pool_max_prepared_capacity]
+}
+
+run "duplicate_lifecycle_hook_names" {
+  command   = plan
+  state_key = "duplicate_lifecycle_hook_names"
+
+  variables {
+    lifecycle_hooks = [
+      { name = "drain", lifecycle_transition = "autoscaling:EC2_INSTANCE_TERMINATING" },
+      { name = "drain", lifecycle_transition = "autoscaling:EC2_INSTANCE_LAUNCHING" }
+    ]
+  }
+
+  expect_failures = [var.lifecycle_hooks]
+}
+
+run "invalid_lifecycle_transition" {
+  command   = plan
+  state_key = "invalid_lifecycle_transition"
+
+  variables {
+    lifecycle_hooks = [{ name = "reboot", lifecycle_transition = "autoscaling:EC2_INSTANCE_REBOOTING" }]
+  }
+
+  expect_failures = [var.lifecycle_hooks]
+}
+
+run "lifecycle_heartbeat_too_short" {
+  command   = plan
+  state_key = "lifecycle_heartbeat_too_short"
+
+  variables {
+    lifecycle_hooks = [{ name = "drain", lifecycle_transition = "autoscaling:EC2_INSTANCE_TERMINATING", heartbeat_timeout = 10 }]
+  }
+
+  expect_failures = [var.lifecycle_hooks]
+}
+
+run "invalid_lifecycle_default_result" {
+  command   = plan
+  state_key = "invalid_lifecycle_default_result"
+
+  variables {
+    lifecycle_hooks = [{ name = "drain", lifecycle_transition = "autoscaling:EC2_INSTANCE_TERMINATING", default_result = "RETRY" }]
+  }
+
+  expect_failures = [var.lifecycle_hooks]
+}
+
+run "lifecycle_notification_without_role" {
+  command   = plan
+  state_key = "lifecycle_notification_without_role"
+
+  variables {
+    lifecycle_hooks = [{
+      name                    = "drain"
+      lifecycle_transition    = "autoscaling:EC2_INSTANCE_TERMINATING"
+      notification_target_arn = "arn:aws:sqs:us-east-1:123456789012:drain"
+    }]
+  }
+
+  expect_failures = [var.lifecycle_hooks]
+}
+
+run "target_port_zero" {
+  command   = plan
+  state_key = "target_port_zero"
+
+  variables {
+    target_port = 0
+  }
+
+  expect_failures = [var.target_port]
+}
+
+run "invalid_target_group_protocol" {
+  command   = plan
+  state_key = "invalid_target_group_protocol"
+
+  variables {
+    target_group_protocol = "TCP"
+  }
+
+  expect_failures = [var.target_group_protocol]
+}
+
+run "invalid_target_group_protocol_version" {
+  command   = plan
+  state_key = "invalid_target_group_protocol_version"
+
+  variables {
+    target_group_protocol_version = "HTTP3"
+  }
+
+  expect_failures = [var.target_group_protocol_version]
+}
+
+run "grpc_with_force_allow_http" {
+  command   = plan
+  state_key = "grpc_with_force_allow_http"
+
+  variables {
+    target_group_protocol_version = "GRPC"
+    force_allow_http              = true
+  }
+
+  expect_failures = [var.target_group_protocol_version]
+}
+
+run "health_check_interval_too_short" {
+  command   = plan
+  state_key = "health_check_interval_too_short"
+
+  variables {
+    health_check_interval = 4
+    health_check_timeout  = 2
+  }
+
+  expect_failures = [var.health_check_interval]
+}
+
+run "health_check_timeout_too_short" {
+  command   = plan
+  state_key = "health_check_timeout_too_short"
+
+  variables {
+    health_check_timeout = 1
+  }
+
+  expect_failures = [var.health_check_timeout]
+}
+
+run "health_check_timeout_not_below_interval" {
+  command   = plan
+  state_key = "health_check_timeout_not_below_interval"
+
+  variables {
+    health_check_interval = 30
+    health_check_timeout  = 30
+  }
+
+  expect_failures = [var.health_check_timeout]
+}
+
+run "healthy_threshold_too_low" {
+  command   = plan
+  state_key = "healthy_threshold_too_low"
+
+  variables {
+    healthy_threshold = 1
+  }
+
+  expect_failures = [var.healthy_threshold]
+}
+
+run "unhealthy_threshold_too_high" {
+  command   = plan
+  state_key = "unhealthy_threshold_too_high"
+
+  variables {
+    unhealthy_threshold = 11
+  }
+
+  expect_failures = [var.unhealthy_threshold]
+}
+
+run "stickiness_duration_zero" {
+  command   = plan
+  state_key = "stickiness_duration_zero"
+
+  variables {
+    stickiness_duration = 0
+  }
+
+  expect_failures = [var.stickiness_duration]
+}
+
+run "invalid_stickiness_type" {
+  command   = plan
+  state_key = "invalid_stickiness_type"
+
+  variables {
+    stickiness_type = "cookie"
+  }
+
+  expect_failures = [var.stickiness_type]
+}
+
+run "app_cookie_stickiness_without_cookie_name" {
+  command   = plan
+  state_key = "app_cookie_stickiness_without_cookie_name"
+
+  variables {
+    enable_stickiness = true
+    stickiness_type   = "app_cookie"
+  }
+
+  expect_failures = [var.stickiness_cookie_name]
+}
+
+run "invalid_mirror_target_group_name" {
+  command   = plan
+  state_key = "invalid_mirror_target_group_name"
+
+  variables {
+    mirror_target_group_name = "-mirror"
+  }
+
+  expect_failures = [var.mirror_target_group_name]
+}
+
+run "mirror_traffic_weight_too_high" {
+  command   = plan
+  state_key = "mirror_traffic_weight_too_high"
+
+  variables {
+    mirror_traffic_weight = 51
+  }
+
+  expect_failures = [var.mirror_traffic_weight]
+}
+
+run "blue_green_with_mirror_target_group" {
+  command   = plan
+  state_key = "blue_green_with_mirror_target_group"
+
+  variables {
+    enable_blue_green          = true
+    enable_mirror_target_group = true
+  }
+
+  expect_failures = [var.enable_blue_green]
+}
+
+run "blue_weight_out_of_range" {
+  command   = plan
+  state_key = "blue_weight_out_of_range"
+
+  variables {
+    blue_weight  = 101
+    green_weight = -1
+  }
+
+  expect_failures = [var.blue_weight, var.green_weight]
+}
+
+run "weights_not_summing_to_100" {
+  command   = plan
+  state_key = "weights_not_summing_to_100"
+
+  variables {
+    blue_weight  = 60
+    green_weight = 30
+  }
+
+  expect_failures = [var.blue_weight]
+}
+
+run "invalid_active_target_group" {
+  command   = plan
+  state_key = "invalid_active_target_group"
+
+  variables {
+    enable_blue_green   = true
+    active_target_group = "purple"
+  }
+
+  expect_failures = [var.active_target_group]
+}
+
+run "green_active_without_blue_green" {
+  command   = plan
+  state_key = "green_active_without_blue_green"
+
+  variables {
+    active_target_group = "green"
+  }
+
+  expect_failures = [var.active_target_group]
+}
+
+run "invalid_blue_green_traffic_control" {
+  command   = plan
+  state_key = "invalid_blue_green_traffic_control"
+
+  variables {
+    enable_blue_green          = true
+    blue_green_traffic_control = "manual"
+  }
+
+  expect_failures = [var.blue_green_traffic_control]
+}
+
+run "codedeploy_traffic_control_without_blue_green" {
+  command   = plan
+  state_key = "codedeploy_traffic_control_without_blue_green"
+
+  variables {
+    blue_green_traffic_control = "codedeploy"
+  }
+
+  expect_failures = [var.blue_green_traffic_control]
+}
+
+run "codedeploy_traffic_control_with_weights" {
+  command   = plan
+  state_key = "codedeploy_traffic_control_with_weights"
+
+  variables {
+    enable_blue_green          = true
+    blue_green_traffic_control = "codedeploy"
+    blue_weight                = 80
+    green_weight               = 20
+  }
+
+  expect_failures = [var.blue_green_traffic_control]
+}
+
+run "invalid_green_target_group_name" {
+  command   = plan
+  state_key = "invalid_green_target_group_name"
+
+  variables {
+    green_target_group_name = "green_tg"
+  }
+
+  expect_failures = [var.green_target_group_name]
+}
+
+run "fractional_unhealthy_state_routing_count" {
+  command   = plan
+  state_key = "fractional_unhealthy_state_routing_count"
+
+  variables {
+    unhealthy_state_routing_min_healthy_count = 1.5
+  }
+
+  expect_failures = [var.unhealthy_state_routing_min_healthy_count]
+}
+
+run "unhealthy_state_routing_percentage_too_high" {
+  command   = plan
+  state_key = "unhealthy_state_routing_percentage_too_high"
+
+  variables {
+    unhealthy_state_routing_min_healthy_percentage = "101"
+  }
+
+  expect_failures = [var.unhealthy_state_routing_min_healthy_percentage]
+}
+
+run "deregistration_delay_too_long" {
+  command   = plan
+  state_key = "deregistration_delay_too_long"
+
+  variables {
+    deregistration_delay = 3601
+  }
+
+  expect_failures = [var.deregistration_delay]
+}
+
+run "slow_start_too_short" {
+  command   = plan
+  state_key = "slow_start_too_short"
+
+  variables {
+    slow_start = 10
+  }
+
+  expect_failures = [var.slow_start]
+}
+
+run "invalid_desync_mitigation_mode" {
+  command   = plan
+  state_key = "invalid_desync_mitigation_mode"
+
+  variables {
+    desync_mitigation_mode = "off"
+  }
+
+  expect_failures = [var.desync_mitigation_mode]
+}
+
+run "listener_rule_priority_zero" {
+  command   = plan
+  state_key = "listener_rule_priority_zero"
+
+  variables {
+    listener_rules = [{ priority = 0, path_patterns = ["/api/*"] }]
+  }
+
+  expect_failures = [var.listener_rules]
+}
+
+run "duplicate_listener_rule_priorities" {
+  command   = plan
+  state_key = "duplicate_listener_rule_priorities"
+
+  variables {
+    listener_rules = [
+      { priority = 10, path_patterns = ["/api/*"] },
+      { priority = 10, path_patterns = ["/admin/*"] }
+    ]
+  }
+
+  expect_failures = [var.listener_rules]
+}
+
+run "listener_rule_without_conditions" {
+  command   = plan
+  state_key = "listener_rule_without_conditions"
+
+  variables {
+    listener_rules = [{ priority = 10 }]
+  }
+
+  expect_failures = [var.listener_rules]
+}
+
+run "listener_rule_priority_base_zero" {
+  command   = plan
+  state_key = "listener_rule_priority_base_zero"
+
+  variables {
+    listener_rule_priority_base = 0
+  }
+
+  expect_failures = [var.listener_rule_priority_base]
+}
+
+run "listener_rule_priority_base_without_room" {
+  command   = plan
+  state_key = "listener_rule_priority_base_without_room"
+
+  variables {
+    listener_rule_priority_base = 50000
+    listener_rules              = [
+      { path_patterns = ["/api/*"] },
+      { path_patterns = ["/admin/*"] }
+    ]
+  }
+
+  expect_failures = [var.listener_rule_priority_base]
+}
+
+run "invalid_asg_target_group_name" {
+  command   = plan
+  state_key = "invalid_asg_target_group_name"
+
+  variables {
+    asg_target_group_arns = {
+      "internal api" = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/api/1234567890abcdef"
+    }
+  }
+
+  expect_failures = [var.asg_target_group_arns]
+}
+
+run "invalid_asg_target_group_arn" {
+  command   = plan
+  state_key = "invalid_asg_target_group_arn"
+
+  variables {
+    asg_target_group_arns = {
+      web = "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/web/1234567890abcdef"
+    }
+  }
+
+  expect_failures = [var.asg_target_group_arns]
+}
+
+run "duplicate_asg_target_group_arns" {
+  command   = plan
+  state_key = "duplicate_asg_target_group_arns"
+
+  variables {
+    asg_target_group_arns = {
+      api      = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/api/1234567890abcdef"
+      api_copy = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/api/1234567890abcdef"
+    }
+  }
+
+  expect_failures = [var.asg_target_group_arns]
+}
+
+run "additional_listener_port_out_of_range" {
+  command   = plan
+  state_key = "additional_listener_port_out_of_range"
+
+  variables {
+    additional_listeners = [{ port = 70000, target_port = 8443 }]
+  }
+
+  expect_failures = [var.additional_listeners]
+}
+
+run "additional_listener_on_reserved_port" {
+  command   = plan
+  state_key = "additional_listener_on_reserved_port"
+
+  variables {
+    additional_listeners = [{ port = 443, target_port = 8443 }]
+  }
+
+  expect_failures = [var.additional_listeners]
+}
+
+run "duplicate_additional_listener_ports" {
+  command   = plan
+  state_key = "duplicate_additional_listener_ports"
+
+  variables {
+    additional_listeners = [
+      { port = 8443, target_port = 8443 },
+      { port = 8443, target_port = 9443 }
+    ]
+  }
+
+  expect_failures = [var.additional_listeners]
+}
+
+run "invalid_additional_listener_protocol" {
+  command   = plan
+  state_key = "invalid_additional_listener_protocol"
+
+  variables {
+    additional_listeners = [{ port = 9000, target_port = 9000, protocol = "TCP" }]
+  }
+
+  expect_failures = [var.additional_listeners]
+}
+
+run "invalid_additional_listener_protocol_version" {
+  command   = plan
+  state_key = "invalid_additional_listener_protocol_version"
+
+  variables {
+    additional_listeners = [{ port = 9000, target_port = 9000, protocol_version = "HTTP3" }]
+  }
+
+  expect_failures = [var.additional_listeners]
+}
+
+run "http2_additional_listener_over_http" {
+  command   = plan
+  state_key = "http2_additional_listener_over_http"
+
+  variables {
+    additional_listeners = [{ port = 9000, target_port = 9000, protocol = "HTTP", protocol_version = "HTTP2" }]
+  }
+
+  expect_failures = [var.additional_listeners]
+}
+
+run "hosted_zone_without_dns_records" {
+  command   = plan
+  state_key = "hosted_zone_without_dns_records"
+
+  variables {
+    route53_zone_id = "Z0123456789ABCDEFGHIJ"
+  }
+
+  expect_failures = [var.dns_records]
+}
+
+run "invalid_routing_policy" {
+  command   = plan
+  state_key = "invalid_routing_policy"
+
+  variables {
+    routing_policy = "geolocation"
+    set_identifier = "primary"
+  }
+
+  expect_failures = [var.routing_policy]
+}
+
+run "weighted_routing_without_set_identifier" {
+  command   = plan
+  state_key = "weighted_routing_without_set_identifier"
+
+  variables {
+    routing_policy = "weighted"
+  }
+
+  expect_failures = [var.set_identifier]
+}
+
+run "routing_weight_too_high" {
+  command   = plan
+  state_key = "routing_weight_too_high"
+
+  variables {
+    routing_weight = 256
+  }
+
+  expect_failures = [var.routing_weight]
+}
+
+run "invalid_failover_role" {
+  command   = plan
+  state_key = "invalid_failover_role"
+
+  variables {
+    failover_role = "TERTIARY"
+  }
+
+  expect_failures = [var.failover_role]
+}
+
+run "non_acm_additional_certificate" {
+  command   = plan
+  state_key = "non_acm_additional_certificate"
+
+  variables {
+    ssl_certificate_arn         = "arn:aws:acm:us-east-1:123456789012:certificate/00000000-0000-0000-0000-000000000001"
+    additional_certificate_arns = ["arn:aws:iam::123456789012:server-certificate/legacy"]
+  }
+
+  expect_failures = [var.additional_certificate_arns]
+}
+
+run "duplicate_additional_certificates" {
+  command   = plan
+  state_key = "duplicate_additional_certificates"
+
+  variables {
+    ssl_certificate_arn         = "arn:aws:acm:us-east-1:123456789012:certificate/00000000-0000-0000-0000-000000000001"
+    additional_certificate_arns = ["arn:aws:acm:us-east-1:123456789012:certificate/00000000-0000-0000-0000-000000000002", "arn:aws:acm:us-east-1:123456789012:certificate/00000000-0000-0000-0000-000000000002"]
+  }
+
+  expect_failures = [var.additional_certificate_arns]
+}
+
+run "additional_certificates_without_default_certificate" {
+  command   = plan
+  state_key = "additional_certificates_without_default_certificate"
+
+  variables {
+    additional_certificate_arns = ["arn:aws:acm:us-east-1:123456789012:certificate/00000000-0000-0000-0000-000000000002"]
+  }
+
+  expect_failures = [var.additional_certificate_arns]
+}
+
+run "negative_waf_rate_limit_priority" {
+  command   = plan
+  state_key = "negative_waf_rate_limit_priority"
+
+  variables {
+    waf_rate_limit_priority = -1
+  }
+
+  expect_failures = [var.waf_rate_limit_priority]
+}
+
+run "invalid_managed_rule_override_action" {
+  command   = plan
+  state_key = "invalid_managed_rule_override_action"
+
+  variables {
+    managed_rule_groups = [{ name = "AWSManagedRulesCommonRuleSet", priority = 1, override_action = "block" }]
+  }
+
+  expect_failures = [var.managed_rule_groups]
+}
+
+run "duplicate_managed_rule_priorities" {
+  command   = plan
+  state_key = "duplicate_managed_rule_priorities"
+
+  variables {
+    managed_rule_groups = [
+      { name = "AWSManagedRulesCommonRuleSet", priority = 1 },
+      { name = "AWSManagedRulesKnownBadInputsRuleSet", priority = 1 }
+    ]
+  }
+
+  expect_failures = [aws_wafv2_web_acl.web_acl]
+}
+
+run "managed_rule_priority_colliding_with_rate_limit" {
+  command   = plan
+  state_key = "managed_rule_priority_colliding_with_rate_limit"
+
+  variables {
+    managed_rule_groups = [{ name = "AWSManagedRulesCommonRuleSet", priority = 3 }]
+  }
+
+  expect_failures = [aws_wafv2_web_acl.web_acl]
+}
+
+# Disabled rules keep their default priorities but do not take part in the check
+run "managed_rule_priority_matching_disabled_geo_blocking" {
+  command   = plan
+  state_key = "managed_rule_priority_matching_disabled_geo_blocking"
+
+  variables {
+    managed_rule_groups = [{ name = "AWSManagedRulesCommonRuleSet", priority = 4 }]
+  }
+}
+
+run "negative_waf_geo_blocking_priority" {
+  command   = plan
+  state_key = "negative_waf_geo_blocking_priority"
+
+  variables {
+    waf_geo_blocking_priority = -1
+  }
+
+  expect_failures = [var.waf_geo_blocking_priority]
+}
+
+run "invalid_blocked_country" {
+  command   = plan
+  state_key = "invalid_blocked_country"
+
+  variables {
+    blocked_countries = ["USA"]
+  }
+
+  expect_failures = [var.blocked_countries]
+}
+
+run "invalid_waf_ip_allowlist_entry" {
+  command   = plan
+  state_key = "invalid_waf_ip_allowlist_entry"
+
+  variables {
+    waf_ip_allowlist = ["203.0.113.0"]
+  }
+
+  expect_failures = [var.waf_ip_allowlist]
+}
+
+run "waf_ip_allowlist_priority_not_lowest" {
+  command   = plan
+  state_key = "waf_ip_allowlist_priority_not_lowest"
+
+  variables {
+    waf_ip_allowlist          = ["203.0.113.0/24"]
+    waf_ip_allowlist_priority = 10
+  }
+
+  expect_failures = [aws_wafv2_web_acl.web_acl]
+}
+
+run "negative_waf_ip_allowlist_priority" {
+  command   = plan
+  state_key = "negative_waf_ip_allowlist_priority"
+
+  variables {
+    waf_ip_allowlist_priority = -1
+  }
+
+  expect_failures = [var.waf_ip_allowlist_priority]
+}
+
+run "waf_ip_allowlist_priority_above_disabled_blocklist" {
+  command   = plan
+  state_key = "waf_ip_allowlist_priority_above_disabled_blocklist"
+
+  variables {
+    waf_ip_allowlist          = ["203.0.113.0/24"]
+    waf_ip_allowlist_priority = 0
+    waf_ip_blocklist_priority = 0
+  }
+}
+
+run "invalid_waf_ip_blocklist_entry" {
+  command   = plan
+  state_key = "invalid_waf_ip_blocklist_entry"
+
+  variables {
+    waf_ip_blocklist = ["198.51.100.0"]
+  }
+
+  expect_failures = [var.waf_ip_blocklist]
+}
+
+run "waf_ip_blocklist_overlapping_allowlist" {
+  command   = plan
+  state_key = "waf_ip_blocklist_overlapping_allowlist"
+
+  variables {
+    waf_ip_allowlist = ["203.0.113.0/24"]
+    waf_ip_blocklist = ["203.0.113.128/25"]
+  }
+
+  expect_failures = [var.waf_ip_blocklist]
+}
+
+run "negative_waf_ip_blocklist_priority" {
+  command   = plan
+  state_key = "negative_waf_ip_blocklist_priority"
+
+  variables {
+    waf_ip_blocklist_priority = -1
+  }
+
+  expect_failures = [var.waf_ip_blocklist_priority]
+}
+
+run "waf_ip_blocklist_priority_colliding_with_rate_limit" {
+  command   = plan
+  state_key = "waf_ip_blocklist_priority_colliding_with_rate_limit"
+
+  variables {
+    waf_ip_blocklist          = ["198.51.100.0/24"]
+    waf_ip_blocklist_priority = 3
+  }
+
+  expect_failures = [aws_wafv2_web_acl.web_acl]
+}
+
+run "waf_ip_blocklist_priority_matching_disabled_geo_blocking" {
+  command   = plan
+  state_key = "waf_ip_blocklist_priority_matching_disabled_geo_blocking"
+
+  variables {
+    waf_ip_blocklist          = ["198.51.100.0/24"]
+    waf_ip_blocklist_priority = 4
+  }
+}
+
+run "secret_header_without_waf" {
+  command   = plan
+  state_key = "secret_header_without_waf"
+
+  variables {
+    enable_waf            = false
+    require_secret_header = true
+    secret_header_value   = "origin-secret"
+  }
+
+  expect_failures = [var.require_secret_header]
+}
+
+run "invalid_secret_header_name" {
+  command   = plan
+  state_key = "invalid_secret_header_name"
+
+  variables {
+    secret_header_name = "X Origin Verify"
+  }
+
+  expect_failures = [var.secret_header_name]
+}
+
+run "secret_header_without_value" {
+  command   = plan
+  state_key = "secret_header_without_value"
+
+  variables {
+    require_secret_header = true
+  }
+
+  expect_failures = [var.secret_header_value]
+}
+
+run "negative_waf_secret_header_priority" {
+  command   = plan
+  state_key = "negative_waf_secret_header_priority"
+
+  variables {
+    waf_secret_header_priority = -1
+  }
+
+  expect_failures = [var.waf_secret_header_priority]
+}
+
+run "waf_secret_header_priority_colliding_with_rate_limit" {
+  command   = plan
+  state_key = "waf_secret_header_priority_colliding_with_rate_limit"
+
+  variables {
+    require_secret_header      = true
+    secret_header_value        = "origin-secret"
+    waf_secret_header_priority = 3
+  }
+
+  expect_failures = [aws_wafv2_web_acl.web_acl]
+}
+
+run "invalid_waf_log_destination" {
+  command   = plan
+  state_key = "invalid_waf_log_destination"
+
+  variables {
+    waf_log_destination = "s3"
+  }
+
+  expect_failures = [var.waf_log_destination]
+}
+
+run "invalid_waf_log_filter" {
+  command   = plan
+  state_key = "invalid_waf_log_filter"
+
+  variables {
+    waf_log_filter = "allowed"
+  }
+
+  expect_failures = [var.waf_log_filter]
+}
+
+run "invalid_waf_log_retention_days" {
+  command   = plan
+  state_key = "invalid_waf_log_retention_days"
+
+  variables {
+    waf_log_retention_days = 10
+  }
+
+  expect_failures = [var.waf_log_retention_days]
+}
+
+run "waf_firehose_buffering_size_zero" {
+  command   = plan
+  state_key = "waf_firehose_buffering_size_zero"
+
+  variables {
+    waf_firehose_buffering_size = 0
+  }
+
+  expect_failures = [var.waf_firehose_buffering_size]
+}
+
+run "waf_firehose_buffering_interval_too_long" {
+  command   = plan
+  state_key = "waf_firehose_buffering_interval_too_long"
+
+  variables {
+    waf_firehose_buffering_interval = 901
+  }
+
+  expect_failures = [var.waf_firehose_buffering_interval]
+}
+
+run "invalid_waf_firehose_compression_format" {
+  command   = plan
+  state_key = "invalid_waf_firehose_compression_format"
+
+  variables {
+    waf_firehose_compression_format = "BZIP2"
+  }
+
+  expect_failures = [var.waf_firehose_compression_format]
+}
+
+run "waf_firehose_s3_prefix_without_trailing_slash" {
+  command   = plan
+  state_key = "waf_firehose_s3_prefix_without_trailing_slash"
+
+  variables {
+    waf_firehose_s3_prefix = "waf-logs"
+  }
+
+  expect_failures = [var.waf_firehose_s3_prefix]
+}
@@ -38,8 +38,71 @@ variable "subnet_ids" {
 }
 
 variable "public_subnet_ids" {
-  description = "List of public subnet IDs for the Application Load Balancer"
+  description = "List of public subnet IDs for the Application Load Balancer (may be empty when the load balancer is internal)"
   type        = list(string)
+  default     = []
+  validation {
+    condition     = var.internal_load_balancer || length(var.public_subnet_ids) > 0
+    error_message = "Public subnet IDs are required unless internal_load_balancer is true."
+  }
+}
+
+variable "internal_load_balancer" {
+  description = "Create an internal load balancer in subnet_ids instead of an internet-facing one in public_subnet_ids"
+  type        = bool
+  default     = false
+}
+
+variable "nlb_mode" {
+  description = "Front the ALB with an internal Network Load Balancer in subnet_ids that forwards TCP 443 to it (PrivateLink endpoint services only accept NLBs)"
+  type        = bool
+  default     = false
+}
+
+variable "enable_endpoint_service" {
+  description = "Expose the NLB as a PrivateLink VPC endpoint service so other accounts can reach the application privately"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !var.enable_endpoint_service || var.nlb_mode
+    error_message = "Endpoint service requires nlb_mode to be enabled."
+  }
+}
+
+variable "endpoint_service_allowed_principals" {
+  description = "IAM principal ARNs (e.g. arn:aws:iam::123456789012:root) allowed to create endpoints to the endpoint service"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for principal in var.endpoint_service_allowed_principals : principal == "*" || can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:(root|role/.+|user/.+)$", principal))])
+    error_message = "Endpoint service allowed principals must be IAM account root, role, or user ARNs, or \"*\"."
+  }
+}
+
+variable "endpoint_service_acceptance_required" {
+  description = "Require each endpoint connection to the endpoint service to be accepted manually"
+  type        = bool
+  default     = true
+}
+
+variable "idle_timeout" {
+  description = "Seconds a load balancer connection may be idle before it is closed (raise for SSE and long polling)"
+  type        = number
+  default     = 60
+  validation {
+    condition     = var.idle_timeout >= 1 && var.idle_timeout <= 4000
+    error_message = "Idle timeout must be between 1 and 4000 seconds."
+  }
+}
+
+variable "ip_address_type" {
+  description = "IP address type of the load balancer: ipv4 or dualstack (dualstack requires every load balancer subnet to have an IPv6 CIDR block)"
+  type        = string
+  default     = "ipv4"
+  validation {
+    condition     = contains(["ipv4", "dualstack"], var.ip_address_type)
+    error_message = "IP address type must be one of: ipv4, dualstack."
+  }
 }
 
 variable "security_group_id" {
@@ -47,14 +110,80 @@ variable "security_group_id" {
   type        = string
 }
 
+variable "additional_security_group_ids" {
+  description = "Additional security group IDs attached to EC2 instances alongside security_group_id (e.g., a monitoring security group)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for id in var.additional_security_group_ids : can(regex("^sg-", id))])
+    error_message = "Additional security group IDs must start with sg-."
+  }
+}
+
 variable "alb_security_group_id" {
-  description = "Security group ID for the Application Load Balancer"
+  description = "Security group ID for the Application Load Balancer (a dedicated security group is created when null)"
   type        = string
+  default     = null
+}
+
+variable "alb_ingress_cidr_blocks" {
+  description = "IPv4 CIDR blocks allowed to reach the load balancer on ports 80 and 443 (only used when the module creates the ALB security group)"
+  type        = list(string)
+  default     = ["0.0.0.0/0"]
+  validation {
+    condition     = alltrue([for cidr in var.alb_ingress_cidr_blocks : can(cidrhost(cidr, 0))])
+    error_message = "ALB ingress CIDR blocks must be valid CIDR blocks."
+  }
+}
+
+variable "alb_ingress_ipv6_cidr_blocks" {
+  description = "IPv6 CIDR blocks allowed to reach a dualstack load balancer on its listener ports (only used when the module creates the ALB security group; no IPv6 ingress when empty)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for cidr in var.alb_ingress_ipv6_cidr_blocks : can(cidrhost(cidr, 0)) && strcontains(cidr, ":")])
+    error_message = "ALB ingress IPv6 CIDR blocks must be valid IPv6 CIDR blocks."
+  }
+}
+
+variable "alb_ingress_prefix_list_ids" {
+  description = "Managed prefix list IDs allowed to reach the load balancer on ports 80 and 443 (only used when the module creates the ALB security group)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for id in var.alb_ingress_prefix_list_ids : can(regex("^pl-[0-9a-f]+$", id))])
+    error_message = "ALB ingress prefix list IDs must be valid prefix list IDs (e.g., pl-0123456789abcdef0)."
+  }
+  validation {
+    condition     = length(var.alb_ingress_prefix_list_ids) == 0 || var.alb_security_group_id == null
+    error_message = "ALB ingress prefix lists can only be used when the module creates the ALB security group (alb_security_group_id must be null)."
+  }
 }
 
 variable "instance_profile_name" {
-  description = "Name of the IAM instance profile"
+  description = "Name of an existing IAM instance profile for the instances (null uses shared_instance_role_arn or create_instance_role)"
+  type        = string
+  default     = null
+}
+
+variable "shared_instance_role_arn" {
+  description = "ARN of an existing IAM role shared by several module instances; the module wraps it in its own instance profile"
   type        = string
+  default     = null
+  validation {
+    condition     = var.shared_instance_role_arn == null || can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:role/", var.shared_instance_role_arn))
+    error_message = "Shared instance role ARN must be an IAM role ARN."
+  }
+}
+
+variable "create_instance_role" {
+  description = "Create a dedicated instance role (with SSM and CloudWatch agent policies) when no instance profile or shared role is supplied"
+  type        = bool
+  default     = false
+  validation {
+    condition     = length([for source in [var.instance_profile_name != null, var.shared_instance_role_arn != null, var.create_instance_role] : source if source]) == 1
+    error_message = "Exactly one of instance_profile_name, shared_instance_role_arn, or create_instance_role must be set."
+  }
 }
 
 # Instance Configuration
@@ -74,12 +203,134 @@ variable "instance_type" {
   }
 }
 
+variable "cpu_credits" {
+  description = "CPU credit option for burstable (T family) instance types: standard or unlimited (null keeps the account default; ignored for other families)"
+  type        = string
+  default     = null
+  validation {
+    condition     = contains(["standard", "unlimited"], coalesce(var.cpu_credits, "standard"))
+    error_message = "CPU credits must be one of: standard, unlimited."
+  }
+}
+
+variable "create_placement_group" {
+  description = "Create a placement group for the instances using placement_strategy"
+  type        = bool
+  default     = false
+}
+
+variable "placement_group_name" {
+  description = "Name of an existing placement group to launch instances into, or the name of the created group when create_placement_group is true (defaults to <project>-<environment>-web-pg)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.placement_group_name == null || can(regex("^[a-zA-Z0-9._-]{1,255}$", var.placement_group_name))
+    error_message = "Placement group name must be 1-255 characters of letters, numbers, periods, hyphens, and underscores."
+  }
+}
+
+variable "placement_strategy" {
+  description = "Strategy of the placement group: cluster (low latency, single AZ), spread, or partition. With an existing placement_group_name, set this to that group's strategy"
+  type        = string
+  default     = "cluster"
+  validation {
+    condition     = contains(["cluster", "spread", "partition"], var.placement_strategy)
+    error_message = "Placement strategy must be one of: cluster, spread, partition."
+  }
+}
+
+variable "launch_template_version" {
+  description = "Launch template version used by the Auto Scaling Group ($Latest, $Default, or a version number)"
+  type        = string
+  default     = "$Latest"
+  validation {
+    condition     = contains(["$Latest", "$Default"], var.launch_template_version) || can(regex("^[1-9][0-9]*$", var.launch_template_version))
+    error_message = "Launch template version must be $Latest, $Default, or a positive version number."
+  }
+}
+
+variable "enable_mixed_instances" {
+  description = "Use a mixed instances policy to combine On-Demand and Spot capacity across instance_types"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !(var.enable_mixed_instances && var.enable_warm_pool)
+    error_message = "Warm pools are not supported with mixed instances policies."
+  }
+}
+
+variable "instance_types" {
+  description = "Instance types used by the mixed instances policy (more types improve Spot availability)"
+  type        = list(string)
+  default     = ["t3.micro", "t3a.micro"]
+  validation {
+    condition     = length(var.instance_types) >= 1 && length(var.instance_types) <= 40
+    error_message = "Between 1 and 40 instance types must be provided."
+  }
+  validation {
+    condition     = alltrue([for type in var.instance_types : can(regex("^[a-z][0-9][a-z]*\\.(nano|micro|small|medium|large|xlarge|[0-9]+xlarge|metal)$", type))])
+    error_message = "Instance types must be valid EC2 instance types (e.g., t3.micro, m5.large)."
+  }
+}
+
+variable "on_demand_base_capacity" {
+  description = "Number of instances always fulfilled with On-Demand capacity (0 for Spot-only)"
+  type        = number
+  default     = 0
+  validation {
+    condition     = var.on_demand_base_capacity >= 0
+    error_message = "On-Demand base capacity must be 0 or greater."
+  }
+}
+
+variable "on_demand_percentage_above_base_capacity" {
+  description = "Percentage of capacity above the base fulfilled with On-Demand instances (0 for Spot-only)"
+  type        = number
+  default     = 0
+  validation {
+    condition     = var.on_demand_percentage_above_base_capacity >= 0 && var.on_demand_percentage_above_base_capacity <= 100
+    error_message = "On-Demand percentage above base capacity must be between 0 and 100."
+  }
+}
+
+variable "spot_allocation_strategy" {
+  description = "Spot allocation strategy (capacity-optimized, lowest-price, price-capacity-optimized)"
+  type        = string
+  default     = "price-capacity-optimized"
+  validation {
+    condition     = contains(["capacity-optimized", "lowest-price", "price-capacity-optimized"], var.spot_allocation_strategy)
+    error_message = "Spot allocation strategy must be one of: capacity-optimized, lowest-price, price-capacity-optimized."
+  }
+}
+
 variable "key_pair_name" {
   description = "Name of the EC2 Key Pair for SSH access"
   type        = string
   default     = null
 }
 
+variable "user_data" {
+  description = "User data for EC2 instances, either raw or already base64-encoded (auto-detected: a single run of base64 characters whose length is a multiple of four is used as is, anything else is encoded)"
+  type        = string
+  default     = null
+}
+
+variable "user_data_template_file" {
+  description = "Path to a user data template rendered with templatefile (environment, application_name, and user_data_vars are available)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.user_data_template_file == null || var.user_data == null
+    error_message = "Only one of user_data or user_data_template_file can be set."
+  }
+}
+
+variable "user_data_vars" {
+  description = "Additional variables passed to the user data template"
+  type        = map(string)
+  default     = {}
+}
+
 variable "root_volume_size" {
   description = "Size of the root EBS volume in GB"
   type        = number
@@ -90,6 +341,81 @@ variable "root_volume_size" {
   }
 }
 
+variable "root_volume_encrypted" {
+  description = "Encrypt the root EBS volume"
+  type        = bool
+  default     = true
+}
+
+variable "root_volume_kms_key_id" {
+  description = "KMS key ARN for EBS encryption (defaults to the account's default EBS key). Also used for data volumes without their own key"
+  type        = string
+  default     = null
+}
+
+variable "data_volumes" {
+  description = "Additional EBS data volumes attached to each instance"
+  type = list(object({
+    device_name = string
+    size        = number
+    type        = optional(string, "gp3")
+    encrypted   = optional(bool, true)
+    kms_key_id  = optional(string)
+  }))
+  default = []
+  validation {
+    condition = alltrue([
+      for volume in var.data_volumes : volume.size >= 1 && volume.size <= 16384
+    ])
+    error_message = "Data volume size must be between 1 and 16384 GB."
+  }
+  validation {
+    condition = alltrue([
+      for volume in var.data_volumes : contains(["gp2", "gp3", "io1", "io2", "st1", "sc1", "standard"], volume.type)
+    ])
+    error_message = "Data volume type must be one of: gp2, gp3, io1, io2, st1, sc1, standard."
+  }
+  validation {
+    condition = alltrue([
+      for volume in var.data_volumes : volume.device_name != "/dev/xvda"
+    ])
+    error_message = "Data volume device names must not collide with the root device (/dev/xvda)."
+  }
+  validation {
+    condition     = length(distinct([for volume in var.data_volumes : volume.device_name])) == length(var.data_volumes)
+    error_message = "Data volume device names must be unique."
+  }
+}
+
+variable "instance_store_device_names" {
+  description = "Device names for the instance store (ephemeral NVMe) volumes, mapped in order to ephemeral0, ephemeral1, ...; requires an instance type with instance storage"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = length(var.instance_store_device_names) <= 24
+    error_message = "At most 24 instance store device names (ephemeral0 to ephemeral23) may be provided."
+  }
+  validation {
+    condition     = length(distinct(var.instance_store_device_names)) == length(var.instance_store_device_names)
+    error_message = "Instance store device names must be unique."
+  }
+  validation {
+    condition = alltrue([
+      for device_name in var.instance_store_device_names : !contains(concat(["/dev/xvda"], [for volume in var.data_volumes : volume.device_name]), device_name)
+    ])
+    error_message = "Instance store device names must not collide with the root device (/dev/xvda) or data volume device names."
+  }
+  # Instance storage is offered by the "d" variants (m5d, c6gd, g4dn, ...) and the
+  # storage-optimized and other NVMe-backed families
+  validation {
+    condition = length(var.instance_store_device_names) == 0 || alltrue([
+      for type in concat([var.instance_type], var.enable_mixed_instances ? var.instance_types : []) :
+      can(regex("^([a-z]+[0-9]+[a-z]*d[a-z]*|i[0-9][a-z]*|d[0-9][a-z]*|h1|x1e?|f1|g5|p5)$", split(".", type)[0]))
+    ])
+    error_message = "Instance store volumes require instance types with instance storage (e.g., m5d, c5d, r5d, i3, i4i, d3)."
+  }
+}
+
 variable "enable_detailed_monitoring" {
   description = "Enable detailed CloudWatch monitoring"
   type        = bool
@@ -127,6 +453,100 @@ variable "desired_capacity" {
   }
 }
 
+variable "health_check_type" {
+  description = "Auto Scaling health check type: EC2 or ELB (defaults to ELB since the group is attached to the target group)"
+  type        = string
+  default     = null
+  validation {
+    condition     = contains(["EC2", "ELB"], coalesce(var.health_check_type, "ELB"))
+    error_message = "Health check type must be one of: EC2, ELB."
+  }
+}
+
+variable "suspended_processes" {
+  description = "Auto Scaling processes to suspend (e.g. Terminate, ReplaceUnhealthy) without removing the scaling policies"
+  type        = list(string)
+  default     = []
+  validation {
+    condition = alltrue([
+      for process in var.suspended_processes : contains([
+        "Launch", "Terminate", "AddToLoadBalancer", "AlarmNotification", "AZRebalance",
+        "HealthCheck", "InstanceRefresh", "ReplaceUnhealthy", "ScheduledActions"
+      ], process)
+    ])
+    error_message = "Suspended processes must be from: Launch, Terminate, AddToLoadBalancer, AlarmNotification, AZRebalance, HealthCheck, InstanceRefresh, ReplaceUnhealthy, ScheduledActions."
+  }
+}
+
+variable "termination_policies" {
+  description = "Termination policies applied in order when the Auto Scaling Group scales in (empty uses the AWS Default policy)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition = alltrue([
+      for policy in var.termination_policies : contains([
+        "OldestInstance", "NewestInstance", "OldestLaunchConfiguration", "OldestLaunchTemplate",
+        "ClosestToNextInstanceHour", "AllocationStrategy", "Default"
+      ], policy)
+    ])
+    error_message = "Termination policies must be from: OldestInstance, NewestInstance, OldestLaunchConfiguration, OldestLaunchTemplate, ClosestToNextInstanceHour, AllocationStrategy, Default."
+  }
+  validation {
+    condition     = length(distinct(var.termination_policies)) == length(var.termination_policies)
+    error_message = "Termination policies must be unique."
+  }
+}
+
+variable "capacity_rebalance" {
+  description = "Proactively replace Spot instances that receive a rebalance recommendation before they are interrupted"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !var.capacity_rebalance || var.enable_mixed_instances
+    error_message = "Capacity rebalancing requires enable_mixed_instances, since only Spot instances receive rebalance recommendations."
+  }
+}
+
+variable "health_check_grace_period" {
+  description = "Seconds after an instance launches before Auto Scaling starts checking its health"
+  type        = number
+  default     = 300
+  validation {
+    condition     = var.health_check_grace_period >= 0 && var.health_check_grace_period <= 7200
+    error_message = "Health check grace period must be between 0 and 7200 seconds."
+  }
+}
+
+variable "scaling_metric" {
+  description = "Metric driving Auto Scaling: cpu (step scaling on CPU alarms), alb_request_count, or network_in (target tracking)"
+  type        = string
+  default     = "cpu"
+  validation {
+    condition     = contains(["cpu", "alb_request_count", "network_in"], var.scaling_metric)
+    error_message = "Scaling metric must be one of: cpu, alb_request_count, network_in."
+  }
+}
+
+variable "target_requests_per_instance" {
+  description = "Target ALB request count per instance per minute (used when scaling_metric is alb_request_count)"
+  type        = number
+  default     = 1000
+  validation {
+    condition     = var.target_requests_per_instance > 0
+    error_message = "Target requests per instance must be greater than 0."
+  }
+}
+
+variable "target_network_in_bytes" {
+  description = "Target average inbound network bytes per instance (used when scaling_metric is network_in)"
+  type        = number
+  default     = 50000000
+  validation {
+    condition     = var.target_network_in_bytes > 0
+    error_message = "Target network in bytes must be greater than 0."
+  }
+}
+
 variable "scale_up_threshold" {
   description = "CPU utilization threshold for scaling up"
   type        = number
@@ -147,35 +567,561 @@ variable "scale_down_threshold" {
   }
 }
 
-# Load Balancer Configuration
-variable "target_port" {
-  description = "Port for the target group"
+variable "alarm_period" {
+  description = "Length in seconds of each datapoint evaluated by the CPU high and CPU low alarms"
   type        = number
-  default     = 80
+  default     = 120
   validation {
-    condition     = var.target_port >= 1 && var.target_port <= 65535
-    error_message = "Target port must be between 1 and 65535."
+    condition     = var.alarm_period >= 60 && var.alarm_period % 60 == 0
+    error_message = "Alarm period must be a multiple of 60 seconds."
   }
 }
 
-variable "health_check_path" {
-  description = "Health check path"
-  type        = string
-  default     = "/health"
+variable "alarm_evaluation_periods" {
+  description = "Number of most recent periods the CPU high and CPU low alarms evaluate"
+  type        = number
+  default     = 2
+  validation {
+    condition     = var.alarm_evaluation_periods >= 1 && var.alarm_evaluation_periods * var.alarm_period <= 86400
+    error_message = "Alarm evaluation periods must be at least 1 and cover no more than one day (evaluation periods x alarm period <= 86400 seconds)."
+  }
 }
 
-variable "enable_stickiness" {
-  description = "Enable session stickiness"
-  type        = bool
-  default     = false
+variable "alarm_datapoints_to_alarm" {
+  description = "Breaching datapoints within the evaluation periods that trigger the CPU high and CPU low alarms (null requires every evaluated datapoint to breach)"
+  type        = number
+  default     = null
+  validation {
+    condition     = var.alarm_datapoints_to_alarm == null || try(var.alarm_datapoints_to_alarm >= 1 && var.alarm_datapoints_to_alarm <= var.alarm_evaluation_periods, false)
+    error_message = "Alarm datapoints to alarm must be between 1 and alarm_evaluation_periods."
+  }
 }
 
-variable "enable_deletion_protection" {
-  description = "Enable deletion protection for the load balancer"
+variable "enable_memory_alarms" {
+  description = "Create a high memory alarm on the CloudWatch agent mem_used_percent metric"
   type        = bool
   default     = false
 }
 
+variable "memory_alarm_threshold" {
+  description = "Memory utilization percentage that triggers the memory alarm"
+  type        = number
+  default     = 80
+  validation {
+    condition     = var.memory_alarm_threshold >= 1 && var.memory_alarm_threshold <= 100
+    error_message = "Memory alarm threshold must be between 1 and 100 percent."
+  }
+}
+
+variable "enable_disk_alarms" {
+  description = "Create a high disk alarm on the CloudWatch agent disk_used_percent metric for the root volume"
+  type        = bool
+  default     = false
+}
+
+variable "disk_alarm_threshold" {
+  description = "Root volume disk utilization percentage that triggers the disk alarm"
+  type        = number
+  default     = 85
+  validation {
+    condition     = var.disk_alarm_threshold >= 1 && var.disk_alarm_threshold <= 100
+    error_message = "Disk alarm threshold must be between 1 and 100 percent."
+  }
+}
+
+variable "enable_cloudwatch_agent" {
+  description = "Install and configure the CloudWatch agent through user data to publish the memory and disk metrics"
+  type        = bool
+  default     = false
+}
+
+variable "cloudwatch_agent_namespace" {
+  description = "CloudWatch namespace the agent publishes memory and disk metrics to"
+  type        = string
+  default     = "CWAgent"
+}
+
+variable "enable_instance_logs" {
+  description = "Create an instance log group and have the CloudWatch agent ship instance_log_files to it (requires enable_cloudwatch_agent)"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !var.enable_instance_logs || var.enable_cloudwatch_agent
+    error_message = "Instance logs are shipped by the CloudWatch agent, so enable_instance_logs requires enable_cloudwatch_agent."
+  }
+}
+
+variable "instance_log_files" {
+  description = "Files on the instances the CloudWatch agent ships to the instance log group, one log stream per instance and file"
+  type        = list(string)
+  default     = ["/var/log/messages"]
+  validation {
+    condition     = alltrue([for file in var.instance_log_files : startswith(file, "/")])
+    error_message = "Instance log files must be absolute paths."
+  }
+}
+
+variable "instance_log_retention_days" {
+  description = "Number of days to retain logs in the instance log group"
+  type        = number
+  default     = 30
+  validation {
+    condition     = contains([1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1827, 3653], var.instance_log_retention_days)
+    error_message = "Instance log retention days must be a valid CloudWatch Logs retention period."
+  }
+}
+
+variable "log_subscription_destination_arn" {
+  description = "Kinesis stream, Firehose delivery stream, or Lambda function that a subscription filter on the instance log group forwards events to"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.log_subscription_destination_arn == null || can(regex("^arn:aws[a-z-]*:(kinesis:[a-z0-9-]+:[0-9]{12}:stream/|firehose:[a-z0-9-]+:[0-9]{12}:deliverystream/|lambda:[a-z0-9-]+:[0-9]{12}:function:).+$", var.log_subscription_destination_arn))
+    error_message = "Log subscription destination must be a Kinesis stream, Firehose delivery stream, or Lambda function ARN."
+  }
+  validation {
+    condition     = var.log_subscription_destination_arn == null || var.enable_instance_logs
+    error_message = "A log subscription filter needs the instance log group, so log_subscription_destination_arn requires enable_instance_logs."
+  }
+}
+
+variable "log_subscription_filter_pattern" {
+  description = "CloudWatch Logs filter pattern selecting the events forwarded to log_subscription_destination_arn (empty forwards every event)"
+  type        = string
+  default     = ""
+}
+
+variable "log_subscription_role_arn" {
+  description = "IAM role CloudWatch Logs assumes to put records to a Kinesis or Firehose destination (not used for Lambda)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.log_subscription_role_arn == null || can(regex("^arn:aws[a-z-]*:iam::[0-9]{12}:role/.+$", var.log_subscription_role_arn))
+    error_message = "Log subscription role ARN must be a valid IAM role ARN."
+  }
+  validation {
+    condition     = var.log_subscription_destination_arn == null || can(regex(":lambda:", var.log_subscription_destination_arn)) || var.log_subscription_role_arn != null
+    error_message = "Kinesis and Firehose log subscription destinations require log_subscription_role_arn."
+  }
+}
+
+variable "alarm_sns_topic_arn" {
+  description = "Existing SNS topic notified by every alarm the module creates (alarm and OK actions)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.alarm_sns_topic_arn == null || can(regex("^arn:aws[a-z-]*:sns:[a-z0-9-]+:[0-9]{12}:.+$", var.alarm_sns_topic_arn))
+    error_message = "Alarm SNS topic ARN must be a valid SNS topic ARN."
+  }
+}
+
+variable "create_alarm_topic" {
+  description = "Create an SNS topic for alarm notifications and subscribe alarm_email_endpoints to it"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !(var.create_alarm_topic && var.alarm_sns_topic_arn != null)
+    error_message = "Only one of alarm_sns_topic_arn or create_alarm_topic can be set."
+  }
+}
+
+variable "alarm_email_endpoints" {
+  description = "Email addresses subscribed to the alarm topic created by create_alarm_topic"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = var.create_alarm_topic || length(var.alarm_email_endpoints) == 0
+    error_message = "Alarm email endpoints require create_alarm_topic to be true."
+  }
+  validation {
+    condition     = alltrue([for email in var.alarm_email_endpoints : can(regex("^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\\.[a-zA-Z]{2,}$", email))])
+    error_message = "Alarm email endpoints must be valid email addresses."
+  }
+}
+
+variable "alarm_treat_missing_data" {
+  description = "How the CPU, memory, and disk alarms treat missing data points (e.g., while instances are scaling)"
+  type        = string
+  default     = "notBreaching"
+  validation {
+    condition     = contains(["missing", "notBreaching", "breaching", "ignore"], var.alarm_treat_missing_data)
+    error_message = "Alarm treat missing data must be one of: missing, notBreaching, breaching, ignore."
+  }
+}
+
+variable "enable_warm_pool" {
+  description = "Keep pre-initialized instances in a warm pool to reduce scale-out latency"
+  type        = bool
+  default     = false
+}
+
+variable "warm_pool_state" {
+  description = "State of instances waiting in the warm pool (Stopped, Running, or Hibernated)"
+  type        = string
+  default     = "Stopped"
+  validation {
+    condition     = contains(["Stopped", "Running", "Hibernated"], var.warm_pool_state)
+    error_message = "Warm pool state must be one of: Stopped, Running, Hibernated."
+  }
+}
+
+variable "warm_pool_min_size" {
+  description = "Minimum number of instances kept in the warm pool"
+  type        = number
+  default     = 0
+  validation {
+    condition     = var.warm_pool_min_size >= 0
+    error_message = "Warm pool min size must be 0 or greater."
+  }
+}
+
+variable "warm_pool_max_prepared_capacity" {
+  description = "Maximum instances allowed in the warm pool plus the group (null defaults to max_size)"
+  type        = number
+  default     = null
+  validation {
+    condition     = var.warm_pool_max_prepared_capacity == null || try(var.warm_pool_max_prepared_capacity >= var.warm_pool_min_size, false)
+    error_message = "Warm pool max prepared capacity must be greater than or equal to warm_pool_min_size."
+  }
+}
+
+variable "warm_pool_reuse_on_scale_in" {
+  description = "Return instances to the warm pool on scale-in instead of terminating them"
+  type        = bool
+  default     = false
+}
+
+variable "lifecycle_hooks" {
+  description = "Lifecycle hooks attached to the Auto Scaling Group. notification_target_arn and role_arn must be set together"
+  type = list(object({
+    name                    = string
+    lifecycle_transition    = string
+    heartbeat_timeout       = optional(number, 300)
+    default_result          = optional(string, "CONTINUE")
+    notification_target_arn = optional(string)
+    role_arn                = optional(string)
+  }))
+  default = []
+  validation {
+    condition     = length(distinct([for hook in var.lifecycle_hooks : hook.name])) == length(var.lifecycle_hooks)
+    error_message = "Lifecycle hook names must be unique."
+  }
+  validation {
+    condition     = alltrue([for hook in var.lifecycle_hooks : contains(["autoscaling:EC2_INSTANCE_LAUNCHING", "autoscaling:EC2_INSTANCE_TERMINATING"], hook.lifecycle_transition)])
+    error_message = "Lifecycle transition must be one of: autoscaling:EC2_INSTANCE_LAUNCHING, autoscaling:EC2_INSTANCE_TERMINATING."
+  }
+  validation {
+    condition     = alltrue([for hook in var.lifecycle_hooks : hook.heartbeat_timeout >= 30 && hook.heartbeat_timeout <= 7200])
+    error_message = "Lifecycle hook heartbeat timeout must be between 30 and 7200 seconds."
+  }
+  validation {
+    condition     = alltrue([for hook in var.lifecycle_hooks : contains(["CONTINUE", "ABANDON"], hook.default_result)])
+    error_message = "Lifecycle hook default result must be either CONTINUE or ABANDON."
+  }
+  validation {
+    condition     = alltrue([for hook in var.lifecycle_hooks : (hook.notification_target_arn == null) == (hook.role_arn == null)])
+    error_message = "Lifecycle hook notification_target_arn and role_arn must be set together."
+  }
+}
+
+# Load Balancer Configuration
+variable "target_port" {
+  description = "Port for the target group"
+  type        = number
+  default     = 80
+  validation {
+    condition     = var.target_port >= 1 && var.target_port <= 65535
+    error_message = "Target port must be between 1 and 65535."
+  }
+}
+
+variable "target_group_protocol" {
+  description = "Target group protocol (HTTP, HTTPS, or GRPC for an HTTP target group using the GRPC protocol version)"
+  type        = string
+  default     = "HTTP"
+  validation {
+    condition     = contains(["HTTP", "HTTPS", "GRPC"], var.target_group_protocol)
+    error_message = "Target group protocol must be one of: HTTP, HTTPS, GRPC."
+  }
+}
+
+variable "target_group_protocol_version" {
+  description = "Protocol version sent to targets (HTTP1, HTTP2, GRPC); forced to GRPC when target_group_protocol is GRPC"
+  type        = string
+  default     = "HTTP1"
+  validation {
+    condition     = contains(["HTTP1", "HTTP2", "GRPC"], var.target_group_protocol_version)
+    error_message = "Target group protocol version must be one of: HTTP1, HTTP2, GRPC."
+  }
+  # gRPC is only served by HTTPS listeners, so the plain HTTP forward listener cannot be used
+  validation {
+    condition     = !((var.target_group_protocol_version == "GRPC" || var.target_group_protocol == "GRPC") && var.force_allow_http)
+    error_message = "GRPC protocol version requires an HTTPS listener and cannot be combined with force_allow_http."
+  }
+}
+
+variable "health_check_path" {
+  description = "Health check path"
+  type        = string
+  default     = "/health"
+}
+
+variable "health_check_matcher" {
+  description = "Health check success codes (null defaults to 200, or 0 for gRPC target groups)"
+  type        = string
+  default     = null
+}
+
+variable "health_check_interval" {
+  description = "Seconds between target group health checks"
+  type        = number
+  default     = 30
+  validation {
+    condition     = var.health_check_interval >= 5 && var.health_check_interval <= 300
+    error_message = "Health check interval must be between 5 and 300 seconds."
+  }
+}
+
+variable "health_check_timeout" {
+  description = "Seconds to wait for a health check response"
+  type        = number
+  default     = 5
+  validation {
+    condition     = var.health_check_timeout >= 2 && var.health_check_timeout <= 120
+    error_message = "Health check timeout must be between 2 and 120 seconds."
+  }
+  validation {
+    condition     = var.health_check_timeout < var.health_check_interval
+    error_message = "The health_check_timeout must be less than health_check_interval."
+  }
+}
+
+variable "healthy_threshold" {
+  description = "Consecutive successful health checks before a target is considered healthy"
+  type        = number
+  default     = 2
+  validation {
+    condition     = var.healthy_threshold >= 2 && var.healthy_threshold <= 10
+    error_message = "Healthy threshold must be between 2 and 10."
+  }
+}
+
+variable "unhealthy_threshold" {
+  description = "Consecutive failed health checks before a target is considered unhealthy"
+  type        = number
+  default     = 2
+  validation {
+    condition     = var.unhealthy_threshold >= 2 && var.unhealthy_threshold <= 10
+    error_message = "Unhealthy threshold must be between 2 and 10."
+  }
+}
+
+variable "enable_stickiness" {
+  description = "Enable session stickiness"
+  type        = bool
+  default     = false
+}
+
+variable "stickiness_duration" {
+  description = "Duration in seconds of the load balancer stickiness cookie (used when enable_stickiness is true)"
+  type        = number
+  default     = 86400
+  validation {
+    condition     = var.stickiness_duration >= 1 && var.stickiness_duration <= 604800
+    error_message = "The stickiness_duration must be between 1 and 604800 seconds."
+  }
+}
+
+variable "stickiness_type" {
+  description = "Stickiness cookie type: lb_cookie (generated by the load balancer) or app_cookie (set by the application)"
+  type        = string
+  default     = "lb_cookie"
+  validation {
+    condition     = contains(["lb_cookie", "app_cookie"], var.stickiness_type)
+    error_message = "Stickiness type must be either lb_cookie or app_cookie."
+  }
+}
+
+variable "stickiness_cookie_name" {
+  description = "Name of the application cookie used for stickiness (required when stickiness_type is app_cookie)"
+  type        = string
+  default     = null
+  validation {
+    condition     = !var.enable_stickiness || var.stickiness_type != "app_cookie" || try(length(var.stickiness_cookie_name) > 0, false)
+    error_message = "A stickiness cookie name is required when stickiness_type is app_cookie."
+  }
+}
+
+variable "enable_mirror_target_group" {
+  description = "Create a mirror target group that receives a weighted share of HTTPS traffic (shadow testing)"
+  type        = bool
+  default     = false
+}
+
+variable "mirror_target_group_name" {
+  description = "Name of the mirror target group (defaults to <project>-<environment>-mirror-tg)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.mirror_target_group_name == null || can(regex("^[a-zA-Z0-9][a-zA-Z0-9-]{0,31}$", var.mirror_target_group_name))
+    error_message = "Mirror target group name must be 1-32 characters and contain only letters, numbers, and hyphens."
+  }
+}
+
+variable "mirror_traffic_weight" {
+  description = "Percentage of HTTPS traffic forwarded to the mirror target group"
+  type        = number
+  default     = 5
+  validation {
+    condition     = var.mirror_traffic_weight >= 1 && var.mirror_traffic_weight <= 50
+    error_message = "Mirror traffic weight must be between 1 and 50 percent."
+  }
+}
+
+variable "enable_blue_green" {
+  description = "Create a green target group for blue/green deployments, weighted on the HTTPS listener or shifted by CodeDeploy"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !(var.enable_blue_green && var.enable_mirror_target_group)
+    error_message = "Blue/green deployments cannot be combined with the mirror target group."
+  }
+}
+
+variable "blue_green_traffic_control" {
+  description = "What shifts HTTPS traffic between blue and green: weights (blue_weight and green_weight, applied by Terraform) or codedeploy (CodeDeploy rewrites the listener and Terraform ignores its default action)"
+  type        = string
+  default     = "weights"
+  validation {
+    condition     = contains(["weights", "codedeploy"], var.blue_green_traffic_control)
+    error_message = "Blue/green traffic control must be one of: weights, codedeploy."
+  }
+  validation {
+    condition     = var.blue_green_traffic_control == "weights" || var.enable_blue_green
+    error_message = "CodeDeploy traffic control requires enable_blue_green to be true."
+  }
+  validation {
+    condition     = var.blue_green_traffic_control == "weights" || (var.blue_weight == 100 && var.green_weight == 0)
+    error_message = "Blue and green weights cannot be set when CodeDeploy controls traffic (blue_green_traffic_control = \"codedeploy\")."
+  }
+}
+
+variable "blue_weight" {
+  description = "Percentage of HTTPS traffic forwarded to the blue (module) target group when blue/green is enabled"
+  type        = number
+  default     = 100
+  validation {
+    condition     = var.blue_weight >= 0 && var.blue_weight <= 100
+    error_message = "Blue weight must be between 0 and 100."
+  }
+  validation {
+    condition     = var.blue_weight + var.green_weight == 100
+    error_message = "Blue and green weights must sum to 100."
+  }
+}
+
+variable "green_weight" {
+  description = "Percentage of HTTPS traffic forwarded to the green target group when blue/green is enabled"
+  type        = number
+  default     = 0
+  validation {
+    condition     = var.green_weight >= 0 && var.green_weight <= 100
+    error_message = "Green weight must be between 0 and 100."
+  }
+}
+
+variable "active_target_group" {
+  description = "Target group the Auto Scaling Group registers instances with (blue or green)"
+  type        = string
+  default     = "blue"
+  validation {
+    condition     = contains(["blue", "green"], var.active_target_group)
+    error_message = "Active target group must be one of: blue, green."
+  }
+  validation {
+    condition     = var.active_target_group == "blue" || var.enable_blue_green
+    error_message = "The green target group can only be active when enable_blue_green is true."
+  }
+}
+
+variable "green_target_group_name" {
+  description = "Name of the green target group (defaults to <project>-<environment>-green-tg)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.green_target_group_name == null || can(regex("^[a-zA-Z0-9][a-zA-Z0-9-]{0,31}$", var.green_target_group_name))
+    error_message = "Green target group name must be 1-32 characters and contain only letters, numbers, and hyphens."
+  }
+}
+
+variable "unhealthy_state_routing_min_healthy_count" {
+  description = "Minimum healthy targets before the target group fails DNS over and routes to all targets (applies to the blue and green target groups)"
+  type        = number
+  default     = 1
+  validation {
+    condition     = var.unhealthy_state_routing_min_healthy_count >= 1 && floor(var.unhealthy_state_routing_min_healthy_count) == var.unhealthy_state_routing_min_healthy_count
+    error_message = "Unhealthy state routing minimum healthy count must be a whole number of at least 1."
+  }
+}
+
+variable "unhealthy_state_routing_min_healthy_percentage" {
+  description = "Minimum percentage of healthy targets before the target group fails DNS over and routes to all targets (\"off\" or 1-100)"
+  type        = string
+  default     = "off"
+  validation {
+    condition     = var.unhealthy_state_routing_min_healthy_percentage == "off" || try(tonumber(var.unhealthy_state_routing_min_healthy_percentage) >= 1 && tonumber(var.unhealthy_state_routing_min_healthy_percentage) <= 100, false)
+    error_message = "Unhealthy state routing minimum healthy percentage must be \"off\" or a number between 1 and 100."
+  }
+}
+
+variable "deregistration_delay" {
+  description = "Seconds to wait for in-flight requests to drain before deregistering a target"
+  type        = number
+  default     = 300
+  validation {
+    condition     = var.deregistration_delay >= 0 && var.deregistration_delay <= 3600
+    error_message = "Deregistration delay must be between 0 and 3600 seconds."
+  }
+}
+
+variable "slow_start" {
+  description = "Seconds a newly registered target ramps up traffic before receiving its full share (0 disables)"
+  type        = number
+  default     = 0
+  validation {
+    condition     = var.slow_start == 0 || (var.slow_start >= 30 && var.slow_start <= 900)
+    error_message = "Slow start must be 0 or between 30 and 900 seconds."
+  }
+}
+
+variable "enable_cross_zone_load_balancing" {
+  description = "Enable cross-zone load balancing on the load balancer"
+  type        = bool
+  default     = true
+}
+
+variable "enable_deletion_protection" {
+  description = "Enable deletion protection for the load balancer (defaults to true in production and false elsewhere)"
+  type        = bool
+  default     = null
+}
+
+variable "drop_invalid_header_fields" {
+  description = "Drop HTTP headers with invalid field names instead of routing them to targets"
+  type        = bool
+  default     = false
+}
+
+variable "desync_mitigation_mode" {
+  description = "How the load balancer handles requests that pose an HTTP desync risk: monitor, defensive, or strictest"
+  type        = string
+  default     = "defensive"
+  validation {
+    condition     = contains(["monitor", "defensive", "strictest"], var.desync_mitigation_mode)
+    error_message = "Desync mitigation mode must be one of: monitor, defensive, strictest."
+  }
+}
+
 variable "enable_access_logs" {
   description = "Enable access logs for the load balancer"
   type        = bool
@@ -188,6 +1134,176 @@ variable "access_logs_bucket" {
   default     = null
 }
 
+variable "listener_rules" {
+  description = "Path- and host-based routing rules added to the HTTPS listener. Rules without a target_group_arn forward to the module's target group; rules without a priority get the next free one from listener_rule_priority_base"
+  type = list(object({
+    priority         = optional(number)
+    path_patterns    = optional(list(string), [])
+    host_headers     = optional(list(string), [])
+    target_group_arn = optional(string)
+  }))
+  default = []
+  validation {
+    condition = alltrue([
+      for rule in var.listener_rules : rule.priority == null || try(rule.priority >= 1 && rule.priority <= 50000, false)
+    ])
+    error_message = "Listener rule priorities must be between 1 and 50000."
+  }
+  validation {
+    condition = (
+      length(distinct([for rule in var.listener_rules : rule.priority if rule.priority != null])) ==
+      length([for rule in var.listener_rules : rule.priority if rule.priority != null])
+    )
+    error_message = "Listener rule priorities must be unique."
+  }
+  validation {
+    condition = alltrue([
+      for rule in var.listener_rules : length(rule.path_patterns) > 0 || length(rule.host_headers) > 0
+    ])
+    error_message = "Each listener rule must define at least one path pattern or host header."
+  }
+}
+
+variable "listener_rule_priority_base" {
+  description = "First priority auto-assigned to listener rules without one; explicit priorities are skipped"
+  type        = number
+  default     = 100
+  validation {
+    condition     = var.listener_rule_priority_base >= 1 && var.listener_rule_priority_base + length(var.listener_rules) - 1 <= 50000
+    error_message = "Listener rule priority base must be at least 1 and leave room for every listener rule below priority 50000."
+  }
+}
+
+variable "asg_target_group_arns" {
+  description = "Existing target groups (e.g. other listeners or load balancers) that the Auto Scaling Group also registers instances with, as a map of stable name to ARN. The name keys the attachment, so adding or removing an entry leaves the others attached"
+  type        = map(string)
+  default     = {}
+  validation {
+    condition     = alltrue([for name in keys(var.asg_target_group_arns) : can(regex("^[a-zA-Z0-9_-]+$", name))])
+    error_message = "ASG target group names must contain only alphanumeric characters, hyphens, and underscores."
+  }
+  validation {
+    condition     = alltrue([for arn in values(var.asg_target_group_arns) : can(regex("^arn:aws[a-z-]*:elasticloadbalancing:[a-z0-9-]+:[0-9]{12}:targetgroup/.+$", arn))])
+    error_message = "ASG target group ARNs must be Elastic Load Balancing target group ARNs."
+  }
+  validation {
+    condition     = length(distinct(values(var.asg_target_group_arns))) == length(var.asg_target_group_arns)
+    error_message = "ASG target group ARNs must not contain duplicates."
+  }
+}
+
+variable "additional_listeners" {
+  description = "Extra ALB listeners, each forwarding to its own target group on target_port. The health check matcher defaults to 0 for GRPC and 200 for HTTP1/HTTP2"
+  type = list(object({
+    port                 = number
+    target_port          = number
+    protocol             = optional(string, "HTTPS")
+    protocol_version     = optional(string, "HTTP1")
+    certificate_arn      = optional(string)
+    health_check_path    = optional(string)
+    health_check_matcher = optional(string)
+  }))
+  default = []
+  validation {
+    condition = alltrue([
+      for listener in var.additional_listeners : listener.port >= 1 && listener.port <= 65535 && listener.target_port >= 1 && listener.target_port <= 65535
+    ])
+    error_message = "Additional listener ports and target ports must be between 1 and 65535."
+  }
+  validation {
+    condition = alltrue([
+      for listener in var.additional_listeners : !contains([80, 443, 8080], listener.port)
+    ])
+    error_message = "Additional listener ports must not use 80, 443, or 8080, which are reserved for the module's own listeners."
+  }
+  validation {
+    condition     = length(distinct([for listener in var.additional_listeners : listener.port])) == length(var.additional_listeners)
+    error_message = "Additional listener ports must be unique."
+  }
+  validation {
+    condition = alltrue([
+      for listener in var.additional_listeners : contains(["HTTP", "HTTPS"], listener.protocol)
+    ])
+    error_message = "Additional listener protocol must be one of: HTTP, HTTPS."
+  }
+  validation {
+    condition = alltrue([
+      for listener in var.additional_listeners : contains(["HTTP1", "HTTP2", "GRPC"], listener.protocol_version)
+    ])
+    error_message = "Additional listener protocol version must be one of: HTTP1, HTTP2, GRPC."
+  }
+  # The ALB only negotiates HTTP/2 and gRPC over TLS
+  validation {
+    condition = alltrue([
+      for listener in var.additional_listeners : listener.protocol == "HTTPS" || listener.protocol_version == "HTTP1"
+    ])
+    error_message = "HTTP2 and GRPC protocol versions require an HTTPS additional listener."
+  }
+}
+
+# DNS Configuration
+variable "route53_zone_id" {
+  description = "Route53 hosted zone ID for ALB alias records (records are skipped when null)"
+  type        = string
+  default     = null
+}
+
+variable "dns_records" {
+  description = "Hostnames to alias to the ALB, either the zone apex (e.g., example.com) or a subdomain (e.g., app.example.com)"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = var.route53_zone_id == null || length(var.dns_records) > 0
+    error_message = "At least one DNS record must be provided when route53_zone_id is set."
+  }
+}
+
+variable "routing_policy" {
+  description = "Route53 routing policy for the ALB records (simple, weighted, latency, failover)"
+  type        = string
+  default     = "simple"
+  validation {
+    condition     = contains(["simple", "weighted", "latency", "failover"], var.routing_policy)
+    error_message = "Routing policy must be one of: simple, weighted, latency, failover."
+  }
+}
+
+variable "set_identifier" {
+  description = "Unique identifier distinguishing this record set from others with the same name (required unless routing_policy is simple)"
+  type        = string
+  default     = null
+  validation {
+    condition     = var.routing_policy == "simple" || try(length(var.set_identifier) > 0, false)
+    error_message = "A set identifier is required when routing_policy is weighted, latency, or failover."
+  }
+}
+
+variable "routing_weight" {
+  description = "Relative weight of this record set (weighted routing only)"
+  type        = number
+  default     = 100
+  validation {
+    condition     = var.routing_weight >= 0 && var.routing_weight <= 255
+    error_message = "Routing weight must be between 0 and 255."
+  }
+}
+
+variable "routing_region" {
+  description = "AWS region the record set is associated with for latency routing (null uses the current region)"
+  type        = string
+  default     = null
+}
+
+variable "failover_role" {
+  description = "Whether this record set is the PRIMARY or SECONDARY target (failover routing only)"
+  type        = string
+  default     = "PRIMARY"
+  validation {
+    condition     = contains(["PRIMARY", "SECONDARY"], var.failover_role)
+    error_message = "Failover role must be either PRIMARY or SECONDARY."
+  }
+}
+
 # SSL Configuration
 variable "ssl_certificate_arn" {
   description = "ARN of the SSL certificate for HTTPS listener"
@@ -195,12 +1311,36 @@ variable "ssl_certificate_arn" {
   default     = null
 }
 
+variable "additional_certificate_arns" {
+  description = "Additional ACM certificate ARNs attached to the HTTPS listener for SNI; ssl_certificate_arn stays the default certificate"
+  type        = list(string)
+  default     = []
+  validation {
+    condition     = alltrue([for arn in var.additional_certificate_arns : can(regex("^arn:aws[a-z-]*:acm:[a-z0-9-]+:[0-9]{12}:certificate/", arn))])
+    error_message = "Additional certificate ARNs must be ACM certificate ARNs."
+  }
+  validation {
+    condition     = length(distinct(var.additional_certificate_arns)) == length(var.additional_certificate_arns)
+    error_message = "Additional certificate ARNs must be unique."
+  }
+  validation {
+    condition     = length(var.additional_certificate_arns) == 0 || (var.ssl_certificate_arn != null && !contains(var.additional_certificate_arns, coalesce(var.ssl_certificate_arn, "none")))
+    error_message = "Additional certificates require ssl_certificate_arn to be set (the self-signed development certificate cannot serve as the SNI default) and must not repeat it."
+  }
+}
+
 variable "ssl_policy" {
   description = "SSL policy for HTTPS listener"
   type        = string
   default     = "ELBSecurityPolicy-TLS-1-2-2017-01"
 }
 
+variable "tags" {
+  description = "Tags (e.g., cost_center, owner) applied to every taggable resource; module tags such as Environment and Module take precedence"
+  type        = map(string)
+  default     = {}
+}
+
 variable "additional_tags" {
   description = "Additional tags to apply to resources"
   type        = map(string)
@@ -226,12 +1366,71 @@ variable "waf_rate_limit" {
   default     = 2000
 }
 
+variable "waf_rate_limit_priority" {
+  description = "Priority of the WAF rate limiting rule"
+  type        = number
+  default     = 3
+  validation {
+    condition     = var.waf_rate_limit_priority >= 0
+    error_message = "WAF rate limit priority must be zero or greater."
+  }
+}
+
+variable "enable_managed_rules" {
+  description = "Enable AWS managed rule groups in the WAF Web ACL"
+  type        = bool
+  default     = true
+}
+
+variable "managed_rule_groups" {
+  description = "AWS managed rule groups to add to the WAF Web ACL. override_action is 'none' (enforce) or 'count' (monitor only). metric_name overrides the CloudWatch metric name, which otherwise keeps the module's existing names for the default groups"
+  type = list(object({
+    name            = string
+    priority        = number
+    vendor_name     = optional(string, "AWS")
+    override_action = optional(string, "none")
+    metric_name     = optional(string)
+  }))
+  default = [
+    {
+      name     = "AWSManagedRulesCommonRuleSet"
+      priority = 1
+    },
+    {
+      name     = "AWSManagedRulesKnownBadInputsRuleSet"
+      priority = 2
+    }
+  ]
+  validation {
+    condition = alltrue([
+      for group in var.managed_rule_groups : contains(["none", "count"], group.override_action)
+    ])
+    error_message = "Managed rule group override_action must be one of: none, count."
+  }
+}
+
+variable "managed_rule_exclusions" {
+  description = "Map of managed rule group name to rule names within that group to switch to count mode"
+  type        = map(list(string))
+  default     = {}
+}
+
 variable "enable_geo_blocking" {
   description = "Enable geographic blocking in WAF"
   type        = bool
   default     = false
 }
 
+variable "waf_geo_blocking_priority" {
+  description = "Priority of the WAF geo blocking rule"
+  type        = number
+  default     = 4
+  validation {
+    condition     = var.waf_geo_blocking_priority >= 0
+    error_message = "WAF geo blocking priority must be zero or greater."
+  }
+}
+
 variable "blocked_countries" {
   description = "List of country codes to block (2-letter ISO codes)"
   type        = list(string)
@@ -242,4 +1441,190 @@ variable "blocked_countries" {
     ])
     error_message = "Country codes must be 2-letter ISO codes (e.g., 'CN', 'RU')."
   }
-}
\ No newline at end of file
+}
+
+variable "waf_ip_allowlist" {
+  description = "IPv4 CIDR blocks always allowed by the WAF, ahead of rate limiting and every other rule"
+  type        = list(string)
+  default     = []
+  validation {
+    condition = alltrue([
+      for cidr in var.waf_ip_allowlist : can(cidrnetmask(cidr))
+    ])
+    error_message = "WAF IP allowlist entries must be valid IPv4 CIDR blocks."
+  }
+}
+
+variable "waf_ip_allowlist_priority" {
+  description = "Priority of the WAF IP allowlist rule (must be lower than every other rule priority)"
+  type        = number
+  default     = 0
+  validation {
+    condition     = var.waf_ip_allowlist_priority >= 0
+    error_message = "WAF IP allowlist priority must be zero or greater."
+  }
+}
+
+variable "waf_ip_blocklist" {
+  description = "IPv4 CIDR blocks always blocked by the WAF"
+  type        = list(string)
+  default     = []
+  validation {
+    condition = alltrue([
+      for cidr in var.waf_ip_blocklist : can(cidrnetmask(cidr))
+    ])
+    error_message = "WAF IP blocklist entries must be valid IPv4 CIDR blocks."
+  }
+  # Two CIDRs overlap when their network addresses match at the shorter of the two prefixes
+  validation {
+    condition = alltrue(flatten([
+      for blocked in var.waf_ip_blocklist : [
+        for allowed in var.waf_ip_allowlist : try(
+          cidrhost("${split("/", blocked)[0]}/${min(tonumber(split("/", blocked)[1]), tonumber(split("/", allowed)[1]))}", 0) !=
+          cidrhost("${split("/", allowed)[0]}/${min(tonumber(split("/", blocked)[1]), tonumber(split("/", allowed)[1]))}", 0),
+          true
+        )
+      ]
+    ]))
+    error_message = "WAF IP blocklist entries must not overlap the WAF IP allowlist."
+  }
+}
+
+variable "waf_ip_blocklist_priority" {
+  description = "Priority of the WAF IP blocklist rule"
+  type        = number
+  default     = 5
+  validation {
+    condition     = var.waf_ip_blocklist_priority >= 0
+    error_message = "WAF IP blocklist priority must be zero or greater."
+  }
+}
+
+variable "require_secret_header" {
+  description = "Block requests whose secret_header_name header does not exactly match secret_header_value (for ALBs only reachable through a CDN that adds the header)"
+  type        = bool
+  default     = false
+  validation {
+    condition     = !var.require_secret_header || var.enable_waf
+    error_message = "Requiring a secret header needs enable_waf to be true."
+  }
+}
+
+variable "secret_header_name" {
+  description = "Name of the header the CDN adds to every origin request"
+  type        = string
+  default     = "X-Origin-Verify"
+  validation {
+    condition     = can(regex("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$", var.secret_header_name))
+    error_message = "Secret header name must be a valid HTTP header name."
+  }
+}
+
+variable "secret_header_value" {
+  description = "Shared secret the header must carry (required when require_secret_header is true)"
+  type        = string
+  default     = null
+  sensitive   = true
+  validation {
+    condition     = !var.require_secret_header || try(length(var.secret_header_value) >= 1 && length(var.secret_header_value) <= 200, false)
+    error_message = "A secret header value of 1-200 characters is required when require_secret_header is true."
+  }
+}
+
+variable "waf_secret_header_priority" {
+  description = "Priority of the WAF secret header rule"
+  type        = number
+  default     = 6
+  validation {
+    condition     = var.waf_secret_header_priority >= 0
+    error_message = "WAF secret header priority must be zero or greater."
+  }
+}
+
+variable "enable_waf_logging" {
+  description = "Send WAF request logs to a CloudWatch log group or Firehose (see waf_log_destination)"
+  type        = bool
+  default     = false
+}
+
+variable "waf_log_destination" {
+  description = "Where WAF logs are delivered: cloudwatch (log group) or firehose (Firehose delivery stream to an S3 bucket)"
+  type        = string
+  default     = "cloudwatch"
+  validation {
+    condition     = contains(["cloudwatch", "firehose"], var.waf_log_destination)
+    error_message = "WAF log destination must be one of: cloudwatch, firehose."
+  }
+}
+
+variable "waf_log_filter" {
+  description = "Which WAF requests are logged: all, blocked (BLOCK actions only), or counted (COUNT actions only)"
+  type        = string
+  default     = "all"
+  validation {
+    condition     = contains(["all", "blocked", "counted"], var.waf_log_filter)
+    error_message = "WAF log filter must be one of: all, blocked, counted."
+  }
+}
+
+variable "waf_log_retention_days" {
+  description = "Number of days to retain WAF logs (log group retention or S3 object expiration)"
+  type        = number
+  default     = 30
+  validation {
+    condition     = contains([1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1827, 3653], var.waf_log_retention_days)
+    error_message = "WAF log retention days must be a valid CloudWatch Logs retention period."
+  }
+}
+
+variable "waf_firehose_buffering_size" {
+  description = "Firehose buffer size in MB before WAF logs are written to S3"
+  type        = number
+  default     = 5
+  validation {
+    condition     = var.waf_firehose_buffering_size >= 1 && var.waf_firehose_buffering_size <= 128
+    error_message = "WAF Firehose buffering size must be between 1 and 128 MB."
+  }
+}
+
+variable "waf_firehose_buffering_interval" {
+  description = "Firehose buffer interval in seconds before WAF logs are written to S3"
+  type        = number
+  default     = 300
+  validation {
+    condition     = var.waf_firehose_buffering_interval >= 0 && var.waf_firehose_buffering_interval <= 900
+    error_message = "WAF Firehose buffering interval must be between 0 and 900 seconds."
+  }
+}
+
+variable "waf_firehose_compression_format" {
+  description = "Compression applied by Firehose to WAF log objects"
+  type        = string
+  default     = "GZIP"
+  validation {
+    condition     = contains(["UNCOMPRESSED", "GZIP", "ZIP", "Snappy", "HADOOP_SNAPPY"], var.waf_firehose_compression_format)
+    error_message = "WAF Firehose compression format must be one of: UNCOMPRESSED, GZIP, ZIP, Snappy, HADOOP_SNAPPY."
+  }
+}
+
+variable "waf_firehose_s3_prefix" {
+  description = "S3 key prefix for WAF log objects (failed deliveries go under <prefix>errors/)"
+  type        = string
+  default     = "waf-logs/"
+  validation {
+    condition     = var.waf_firehose_s3_prefix == "" || endswith(var.waf_firehose_s3_prefix, "/")
+    error_message = "WAF Firehose S3 prefix must be empty or end with a slash."
+  }
+}
+
+variable "waf_log_kms_key_arn" {
+  description = "KMS key ARN encrypting the WAF log bucket and Firehose stream (null uses AES256 and an AWS owned key)"
+  type        = string
+  default     = null
+}
+
+variable "waf_log_bucket_force_destroy" {
+  description = "Allow the WAF log bucket to be destroyed even when it contains logs"
+  type        = bool
+  default     = false
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCdnModule(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := strings.ToLower(random.UniqueId())
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../terraform/modules/cdn",
+
+		Vars: map[string]interface{}{
+			"project_name":           fmt.Sprintf("test-cdn-%s", uniqueID),
+			"environment":            "staging",
+			"price_class":            "PriceClass_100",
+			"force_destroy":          true,
+			"alb_origin_domain_name": "example.com",
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	distributionID := terraform.Output(t, terraformOptions, "distribution_id")
+	domainName := terraform.Output(t, terraformOptions, "cloudfront_domain_name")
+	assert.True(t, strings.HasSuffix(domainName, ".cloudfront.net"))
+
+	// Verify the distribution finished deploying and is serving traffic
+	distribution := getCloudFrontDistribution(t, distributionID)
+	assert.Equal(t, "Deployed", awssdk.StringValue(distribution.Status))
+	assert.True(t, awssdk.BoolValue(distribution.DistributionConfig.Enabled))
+	assert.Equal(t, "PriceClass_100", awssdk.StringValue(distribution.DistributionConfig.PriceClass))
+
+	// Verify the S3 bucket and ALB origins are both configured
+	require.NotNil(t, distribution.DistributionConfig.Origins)
+	assert.Equal(t, int64(2), awssdk.Int64Value(distribution.DistributionConfig.Origins.Quantity))
+}
+
+func TestCdnModuleValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		vars          map[string]interface{}
+		errorContains string
+	}{
+		{
+			name: "aliases_without_certificate",
+			vars: map[string]interface{}{
+				"project_name": "test-cdn",
+				"environment":  "staging",
+				"aliases":      []string{"static.example.com"},
+			},
+			errorContains: "An ACM certificate is required when aliases are set",
+		},
+		{
+			name: "certificate_outside_us_east_1",
+			vars: map[string]interface{}{
+				"project_name":        "test-cdn",
+				"environment":         "staging",
+				"aliases":             []string{"static.example.com"},
+				"acm_certificate_arn": "arn:aws:acm:eu-west-1:123456789012:certificate/abcd1234",
+			},
+			errorContains: "ACM certificate must be a valid certificate ARN in us-east-1",
+		},
+		{
+			name: "invalid_price_class",
+			vars: map[string]interface{}{
+				"project_name": "test-cdn",
+				"environment":  "staging",
+				"price_class":  "PriceClass_50",
+			},
+			errorContains: "Price class must be one of",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../terraform/modules/cdn",
+				Vars:         tc.vars,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientVpnModule(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+
+	// Plan only - an endpoint needs a real ACM server certificate to apply
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../terraform/modules/client-vpn",
+		PlanFilePath: filepath.Join(t.TempDir(), "plan.out"),
+
+		Vars: map[string]interface{}{
+			"project_name":           "test-cvpn",
+			"environment":            "staging",
+			"vpc_id":                 "vpc-123",
+			"subnet_ids":             []string{"subnet-123", "subnet-456"},
+			"client_cidr_block":      "172.16.0.0/22",
+			"server_certificate_arn": "arn:aws:acm:us-east-1:123456789012:certificate/server",
+			"authentication": map[string]interface{}{
+				"type":                       "certificate-authentication",
+				"root_certificate_chain_arn": "arn:aws:acm:us-east-1:123456789012:certificate/client-root",
+			},
+			"authorization_rules": []map[string]interface{}{
+				{"target_network_cidr": "10.0.0.0/16", "description": "VPC"},
+			},
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	}
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	endpoint, ok := plan.ResourcePlannedValuesMap["aws_ec2_client_vpn_endpoint.main"]
+	require.True(t, ok, "Client VPN endpoint should be planned")
+	assert.Equal(t, "172.16.0.0/22", endpoint.AttributeValues["client_cidr_block"])
+	assert.Equal(t, true, endpoint.AttributeValues["split_tunnel"])
+	assert.Equal(t, "vpc-123", endpoint.AttributeValues["vpc_id"])
+
+	authenticationOptions := endpoint.AttributeValues["authentication_options"].([]interface{})
+	require.Len(t, authenticationOptions, 1)
+	assert.Equal(t, "certificate-authentication", authenticationOptions[0].(map[string]interface{})["type"])
+
+	// One association per subnet
+	for i, subnetID := range []string{"subnet-123", "subnet-456"} {
+		association, ok := plan.ResourcePlannedValuesMap[fmt.Sprintf("aws_ec2_client_vpn_network_association.main[%d]", i)]
+		require.True(t, ok, "Network association for %s should be planned", subnetID)
+		assert.Equal(t, subnetID, association.AttributeValues["subnet_id"])
+	}
+
+	rule, ok := plan.ResourcePlannedValuesMap[`aws_ec2_client_vpn_authorization_rule.main["10.0.0.0/16-all"]`]
+	require.True(t, ok, "Authorization rule should be planned")
+	assert.Equal(t, true, rule.AttributeValues["authorize_all_groups"])
+
+	// Split tunnel leaves internet traffic on the client, so no default route is added
+	_, ok = plan.ResourcePlannedValuesMap["aws_ec2_client_vpn_route.internet[0]"]
+	assert.False(t, ok, "Internet route should not be planned with split tunnel")
+}
+
+func TestClientVpnModuleFullTunnel(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+
+	// Plan only - the routes and authorization rules are visible on the planned resources
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../terraform/modules/client-vpn",
+		PlanFilePath: filepath.Join(t.TempDir(), "plan.out"),
+
+		Vars: map[string]interface{}{
+			"project_name":           "test-cvpn-full",
+			"environment":            "staging",
+			"vpc_id":                 "vpc-123",
+			"subnet_ids":             []string{"subnet-123", "subnet-456"},
+			"client_cidr_block":      "172.16.0.0/22",
+			"server_certificate_arn": "arn:aws:acm:us-east-1:123456789012:certificate/server",
+			"split_tunnel":           false,
+			"authentication": map[string]interface{}{
+				"type":                       "certificate-authentication",
+				"root_certificate_chain_arn": "arn:aws:acm:us-east-1:123456789012:certificate/client-root",
+			},
+			"authorization_rules": []map[string]interface{}{
+				{"target_network_cidr": "10.0.0.0/16", "description": "VPC"},
+			},
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	}
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	// A default route through every associated subnet
+	for i, subnetID := range []string{"subnet-123", "subnet-456"} {
+		route, ok := plan.ResourcePlannedValuesMap[fmt.Sprintf("aws_ec2_client_vpn_route.internet[%d]", i)]
+		require.True(t, ok, "Internet route through %s should be planned", subnetID)
+		assert.Equal(t, "0.0.0.0/0", route.AttributeValues["destination_cidr_block"])
+		assert.Equal(t, subnetID, route.AttributeValues["target_vpc_subnet_id"])
+	}
+
+	// Internet access is authorized alongside the caller's rules
+	rule, ok := plan.ResourcePlannedValuesMap[`aws_ec2_client_vpn_authorization_rule.main["0.0.0.0/0-all"]`]
+	require.True(t, ok, "Internet authorization rule should be planned")
+	assert.Equal(t, true, rule.AttributeValues["authorize_all_groups"])
+
+	_, ok = plan.ResourcePlannedValuesMap[`aws_ec2_client_vpn_authorization_rule.main["10.0.0.0/16-all"]`]
+	assert.True(t, ok, "VPC authorization rule should be planned")
+}
+
+func TestClientVpnModuleValidation(t *testing.T) {
+	t.Parallel()
+
+	baseVars := func(overrides map[string]interface{}) map[string]interface{} {
+		vars := map[string]interface{}{
+			"project_name":           "test-cvpn",
+			"environment":            "staging",
+			"vpc_id":                 "vpc-123",
+			"subnet_ids":             []string{"subnet-123"},
+			"client_cidr_block":      "172.16.0.0/22",
+			"server_certificate_arn": "arn:aws:acm:us-east-1:123456789012:certificate/server",
+			"authentication": map[string]interface{}{
+				"type":                       "certificate-authentication",
+				"root_certificate_chain_arn": "arn:aws:acm:us-east-1:123456789012:certificate/client-root",
+			},
+			"authorization_rules": []map[string]interface{}{
+				{"target_network_cidr": "10.0.0.0/16"},
+			},
+		}
+		for key, value := range overrides {
+			vars[key] = value
+		}
+		return vars
+	}
+
+	testCases := []struct {
+		name          string
+		vars          map[string]interface{}
+		errorContains string
+	}{
+		{
+			name:          "client_cidr_block_too_small",
+			vars:          baseVars(map[string]interface{}{"client_cidr_block": "172.16.0.0/24"}),
+			errorContains: "Client CIDR block must be a valid IPv4 CIDR block between /12 and /22",
+		},
+		{
+			name: "federated_authentication_without_saml_provider",
+			vars: baseVars(map[string]interface{}{
+				"authentication": map[string]interface{}{"type": "federated-authentication"},
+			}),
+			errorContains: "federated authentication requires saml_provider_arn",
+		},
+		{
+			name: "access_group_with_certificate_authentication",
+			vars: baseVars(map[string]interface{}{
+				"authorization_rules": []map[string]interface{}{
+					{"target_network_cidr": "10.0.0.0/16", "access_group_id": "engineering"},
+				},
+			}),
+			errorContains: "Authorization rules can only be limited to an access group with federated or directory service authentication",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../terraform/modules/client-vpn",
+				Vars:         tc.vars,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
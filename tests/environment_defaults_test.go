@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/beyondepic/epic-infrastructure/tests/helpers"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEnvironmentDefaults plans the web-application module with each
+// environments/<environment>.tfvars file and checks the sizing it resolves to
+func TestEnvironmentDefaults(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		environment        string
+		instanceType       string
+		minSize            float64
+		maxSize            float64
+		desiredCapacity    float64
+		detailedMonitoring bool
+	}{
+		{
+			environment:        "staging",
+			instanceType:       "t3.small",
+			minSize:            1,
+			maxSize:            2,
+			desiredCapacity:    1,
+			detailedMonitoring: false,
+		},
+		{
+			environment:        "production",
+			instanceType:       "t3.medium",
+			minSize:            2,
+			maxSize:            6,
+			desiredCapacity:    2,
+			detailedMonitoring: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.environment, func(t *testing.T) {
+			t.Parallel()
+
+			// Plan only - the environment file supplies everything but identity and networking
+			webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+				"project_name": "test-envdefaults",
+				"environment":  nil,
+			})
+			webAppOptions.VarFiles = []string{fmt.Sprintf("environments/%s.tfvars", tc.environment)}
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+			assert.Equal(t, tc.environment, plan.RawPlan.Variables["environment"].Value)
+
+			launchTemplate, ok := plan.ResourcePlannedValuesMap["aws_launch_template.web"]
+			require.True(t, ok, "Launch template should be planned")
+			assert.Equal(t, tc.instanceType, launchTemplate.AttributeValues["instance_type"])
+
+			monitoring := launchTemplate.AttributeValues["monitoring"].([]interface{})
+			require.Len(t, monitoring, 1)
+			assert.Equal(t, tc.detailedMonitoring, monitoring[0].(map[string]interface{})["enabled"])
+
+			asg, ok := plan.ResourcePlannedValuesMap["aws_autoscaling_group.web"]
+			require.True(t, ok, "Auto Scaling Group should be planned")
+			assert.Equal(t, tc.minSize, asg.AttributeValues["min_size"])
+			assert.Equal(t, tc.maxSize, asg.AttributeValues["max_size"])
+			assert.Equal(t, tc.desiredCapacity, asg.AttributeValues["desired_capacity"])
+		})
+	}
+}
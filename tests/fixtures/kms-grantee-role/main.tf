@@ -0,0 +1,33 @@
+# Test fixture: IAM role used as the grantee principal in the kms module's grant test
+
+terraform {
+  required_version = ">= 1.13.3"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 6.14.0"
+    }
+  }
+}
+
+resource "aws_iam_role" "grantee" {
+  name_prefix = "test-kms-grantee-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "ec2.amazonaws.com"
+        }
+      }
+    ]
+  })
+}
+
+output "role_arn" {
+  value = aws_iam_role.grantee.arn
+}
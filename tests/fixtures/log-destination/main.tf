@@ -0,0 +1,67 @@
+# Test fixture: Kinesis stream and the role CloudWatch Logs assumes to write to it,
+# the destination of the web-application module's log subscription filter test
+
+terraform {
+  required_version = ">= 1.13.3"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 6.14.0"
+    }
+  }
+}
+
+variable "name_prefix" {
+  description = "Prefix of the stream and role names"
+  type        = string
+}
+
+resource "aws_kinesis_stream" "logs" {
+  name        = "${var.name_prefix}-logs"
+  shard_count = 1
+}
+
+resource "aws_iam_role" "logs" {
+  name_prefix = "${var.name_prefix}-logs-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "logs.amazonaws.com"
+        }
+      }
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "logs" {
+  name = "put-records"
+  role = aws_iam_role.logs.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action   = "kinesis:PutRecord"
+        Effect   = "Allow"
+        Resource = aws_kinesis_stream.logs.arn
+      }
+    ]
+  })
+}
+
+output "stream_arn" {
+  value = aws_kinesis_stream.logs.arn
+}
+
+output "role_arn" {
+  value = aws_iam_role.logs.arn
+
+  # The role is only usable once it can write to the stream
+  depends_on = [aws_iam_role_policy.logs]
+}
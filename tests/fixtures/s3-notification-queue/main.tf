@@ -0,0 +1,55 @@
+# Test fixture: SQS queue that accepts event notifications from a bucket
+# for the s3-bucket module's event notification test
+
+terraform {
+  required_version = ">= 1.13.3"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 6.14.0"
+    }
+  }
+}
+
+variable "queue_name" {
+  description = "Name of the notification queue"
+  type        = string
+}
+
+variable "source_bucket_name" {
+  description = "Name of the bucket sending event notifications"
+  type        = string
+}
+
+resource "aws_sqs_queue" "notifications" {
+  name = var.queue_name
+}
+
+# S3 validates this policy when the bucket notification is created
+resource "aws_sqs_queue_policy" "notifications" {
+  queue_url = aws_sqs_queue.notifications.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Principal = {
+          Service = "s3.amazonaws.com"
+        }
+        Action   = "sqs:SendMessage"
+        Resource = aws_sqs_queue.notifications.arn
+        Condition = {
+          ArnEquals = {
+            "aws:SourceArn" = "arn:aws:s3:::${var.source_bucket_name}"
+          }
+        }
+      }
+    ]
+  })
+}
+
+output "queue_arn" {
+  value = aws_sqs_queue.notifications.arn
+}
@@ -0,0 +1,97 @@
+# Test fixture: versioned destination bucket and replication role for the
+# s3-bucket module's cross-region replication test
+
+terraform {
+  required_version = ">= 1.13.3"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 6.14.0"
+    }
+  }
+}
+
+variable "bucket_name" {
+  description = "Name of the destination bucket"
+  type        = string
+}
+
+variable "source_bucket_name" {
+  description = "Name of the source bucket objects are replicated from"
+  type        = string
+}
+
+resource "aws_s3_bucket" "destination" {
+  bucket        = var.bucket_name
+  force_destroy = true
+}
+
+resource "aws_s3_bucket_versioning" "destination" {
+  bucket = aws_s3_bucket.destination.id
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+
+resource "aws_iam_role" "replication" {
+  name_prefix = "test-s3-replication-"
+
+  assume_role_policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Action = "sts:AssumeRole"
+        Effect = "Allow"
+        Principal = {
+          Service = "s3.amazonaws.com"
+        }
+      }
+    ]
+  })
+}
+
+resource "aws_iam_role_policy" "replication" {
+  name_prefix = "test-s3-replication-"
+  role        = aws_iam_role.replication.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "s3:GetReplicationConfiguration",
+          "s3:ListBucket"
+        ]
+        Resource = "arn:aws:s3:::${var.source_bucket_name}"
+      },
+      {
+        Effect = "Allow"
+        Action = [
+          "s3:GetObjectVersionForReplication",
+          "s3:GetObjectVersionAcl",
+          "s3:GetObjectVersionTagging"
+        ]
+        Resource = "arn:aws:s3:::${var.source_bucket_name}/*"
+      },
+      {
+        Effect = "Allow"
+        Action = [
+          "s3:ReplicateObject",
+          "s3:ReplicateDelete",
+          "s3:ReplicateTags"
+        ]
+        Resource = "${aws_s3_bucket.destination.arn}/*"
+      }
+    ]
+  })
+}
+
+output "bucket_arn" {
+  value = aws_s3_bucket.destination.arn
+}
+
+output "role_arn" {
+  value = aws_iam_role.replication.arn
+}
@@ -0,0 +1,83 @@
+# Test fixture: internal ALB with two target groups, one per listener path, for
+# the web-application module's shared Auto Scaling Group test
+
+terraform {
+  required_version = ">= 1.13.3"
+
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 6.14.0"
+    }
+  }
+}
+
+variable "name_prefix" {
+  description = "Prefix of the load balancer and target group names"
+  type        = string
+}
+
+variable "vpc_id" {
+  description = "ID of the VPC the target groups belong to"
+  type        = string
+}
+
+variable "subnet_ids" {
+  description = "Subnets in at least two Availability Zones for the load balancer"
+  type        = list(string)
+}
+
+variable "security_group_id" {
+  description = "Security group attached to the load balancer"
+  type        = string
+}
+
+resource "aws_lb" "fixture" {
+  name               = "${var.name_prefix}-lb"
+  internal           = true
+  load_balancer_type = "application"
+  security_groups    = [var.security_group_id]
+  subnets            = var.subnet_ids
+}
+
+# Target groups can only be attached to an Auto Scaling Group once a load balancer uses them
+resource "aws_lb_target_group" "fixture" {
+  for_each = toset(["api", "admin"])
+
+  name     = "${var.name_prefix}-${each.key}"
+  port     = 80
+  protocol = "HTTP"
+  vpc_id   = var.vpc_id
+}
+
+resource "aws_lb_listener" "fixture" {
+  load_balancer_arn = aws_lb.fixture.arn
+  port              = 80
+  protocol          = "HTTP"
+
+  default_action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.fixture["api"].arn
+  }
+}
+
+resource "aws_lb_listener_rule" "admin" {
+  listener_arn = aws_lb_listener.fixture.arn
+  priority     = 10
+
+  action {
+    type             = "forward"
+    target_group_arn = aws_lb_target_group.fixture["admin"].arn
+  }
+
+  condition {
+    path_pattern {
+      values = ["/admin/*"]
+    }
+  }
+}
+
+output "target_group_arns" {
+  description = "ARNs of the api and admin target groups, keyed by name"
+  value       = { for name, group in aws_lb_target_group.fixture : name => group.arn }
+}
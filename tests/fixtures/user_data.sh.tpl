@@ -0,0 +1,4 @@
+#!/bin/bash
+# Test user data template for the web-application module
+
+echo "Bootstrapping ${application_name} in ${environment}" > /var/log/bootstrap.log
@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestTerraformFormatting fails when any file under ../terraform would be rewritten by
+// `terraform fmt`, mirroring the format gate in CI. Run `terraform fmt -recursive` to fix.
+func TestTerraformFormatting(t *testing.T) {
+	t.Parallel()
+
+	terraformBin, err := exec.LookPath("terraform")
+	if err != nil {
+		t.Skip("Skipping format check: terraform is not installed")
+	}
+
+	cmd := exec.Command(terraformBin, "fmt", "-check", "-recursive", "-no-color")
+	cmd.Dir = "../terraform"
+
+	// fmt -check prints each unformatted file on its own line and exits non-zero
+	output, err := cmd.Output()
+	if err == nil {
+		return
+	}
+
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		t.Fatalf("terraform fmt failed to run: %v", err)
+	}
+
+	unformatted := strings.Fields(string(output))
+	if len(unformatted) == 0 {
+		t.Fatalf("terraform fmt failed: %v\n%s", err, exitErr.Stderr)
+	}
+
+	t.Errorf("%d Terraform file(s) are not formatted; run `terraform fmt -recursive` in terraform/:\n  %s",
+		len(unformatted), strings.Join(unformatted, "\n  "))
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beyondepic/epic-infrastructure/tests/helpers"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fullStackUserData serves a static page on port 80, including the module's default /health check path
+const fullStackUserData = `#!/bin/bash
+yum install -y httpd
+echo "epic-full-stack" > /var/www/html/index.html
+echo "ok" > /var/www/html/health
+systemctl enable --now httpd
+`
+
+// fullStackListenerPort is an extra HTTP listener the smoke test curls; port 80 only
+// redirects to HTTPS, which needs a validated certificate
+const fullStackListenerPort = 8000
+
+// teardownStage is one deployed layer of the stack and how to destroy it
+type teardownStage struct {
+	name    string
+	destroy func()
+}
+
+func TestFullStackLifecycle(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	prefix := fmt.Sprintf("test-stack-%s", strings.ToLower(random.UniqueId()))
+
+	// Stages are destroyed newest first from a single cleanup rather than independent defers,
+	// so a layer is only destroyed once everything deployed on top of it is gone. A failed
+	// destroy stops the teardown instead of pulling the networking out from under live resources.
+	var stages []teardownStage
+	var networking helpers.NetworkingOutputs
+	t.Cleanup(func() {
+		for i := len(stages) - 1; i >= 0; i-- {
+			t.Logf("Destroying %s", stages[i].name)
+			stages[i].destroy()
+		}
+
+		// The VPC can only be deleted once nothing from the upper layers is left inside it
+		if networking.VpcID != "" {
+			assert.False(t, vpcExists(t, awsRegion, networking.VpcID), "VPC should be deleted after teardown")
+		}
+	})
+
+	// Layer 1: networking, with a NAT Gateway so the instances can install a web server
+	networkingOptions := helpers.NetworkingOptions(t, awsRegion, prefix, map[string]interface{}{
+		"private_subnet_count": 2,
+		"enable_nat_gateway":   true,
+		"nat_gateway_count":    1,
+	})
+
+	// Each stage is registered before its apply so a partially applied layer is still destroyed
+	stages = append(stages, teardownStage{
+		name: "shared-networking",
+		destroy: func() {
+			helpers.DestroyWaitingForENIs(t, networkingOptions, awsRegion, networking.SecurityGroupIDs(), helpers.ENIWaitTimeout(t))
+		},
+	})
+	terraform.InitAndApply(t, networkingOptions)
+	networking = helpers.NewNetworkingOutputs(terraform.OutputAll(t, networkingOptions))
+	require.NotEmpty(t, networking.VpcID)
+
+	// Layer 2: the web application deployed into the networking outputs. The module creates
+	// its own ALB security group open to this runner on the smoke test listener, and the
+	// instances also join the networking web security group so the ALB can reach port 80.
+	webAppOptions := helpers.WebApplicationOptions(t, awsRegion, prefix, networking, map[string]interface{}{
+		"instance_type":                 "t3.micro",
+		"min_size":                      1,
+		"max_size":                      2,
+		"desired_capacity":              1,
+		"enable_waf":                    false,
+		"user_data":                     fullStackUserData,
+		"alb_security_group_id":         nil,
+		"alb_ingress_cidr_blocks":       []string{getRunnerPublicCIDR(t)},
+		"additional_security_group_ids": []string{networking.WebSecurityGroupID},
+		"additional_listeners": []map[string]interface{}{
+			{
+				"port":              fullStackListenerPort,
+				"target_port":       80,
+				"protocol":          "HTTP",
+				"health_check_path": "/",
+			},
+		},
+	})
+
+	var albSecurityGroupID string
+	stages = append(stages, teardownStage{
+		name: "web-application",
+		destroy: func() {
+			helpers.DestroyWaitingForENIs(t, webAppOptions, awsRegion, []string{albSecurityGroupID}, helpers.ENIWaitTimeout(t))
+
+			// The module-created ALB security group must be gone before the VPC is destroyed
+			if albSecurityGroupID != "" {
+				require.False(t, securityGroupExists(t, awsRegion, albSecurityGroupID), "ALB security group should be deleted before networking teardown")
+			}
+		},
+	})
+	terraform.InitAndApply(t, webAppOptions)
+	webApp := helpers.NewWebApplicationOutputs(webAppOptions, terraform.OutputAll(t, webAppOptions))
+	albSecurityGroupID = terraform.Output(t, webAppOptions, "alb_security_group_id")
+	require.NotEmpty(t, webApp.LoadBalancerDNSName)
+
+	// End-to-end: the ALB must serve the instance's page before anything is torn down.
+	// Instances need a few minutes to boot, install httpd, and pass health checks.
+	url := fmt.Sprintf("http://%s:%d/", webApp.LoadBalancerDNSName, fullStackListenerPort)
+	body := waitForHTTPStatus(t, url, 200, 15*time.Minute)
+	assert.Contains(t, body, "epic-full-stack")
+}
@@ -0,0 +1,95 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// AssertMonthlyCostBelow estimates the monthly cost of terraformDir with vars using
+// `infracost breakdown` and fails the test if it exceeds maxUSD.
+// The test is skipped when infracost is not installed.
+func AssertMonthlyCostBelow(t *testing.T, terraformDir string, vars map[string]interface{}, maxUSD float64) {
+	infracost, err := exec.LookPath("infracost")
+	if err != nil {
+		t.Skip("Skipping cost guardrail: infracost is not installed (see https://www.infracost.io/docs/)")
+	}
+
+	// infracost resolves variable files relative to --path
+	varFile := filepath.Join(t.TempDir(), "cost.tfvars.json")
+	varJSON, err := json.Marshal(vars)
+	if err != nil {
+		t.Fatalf("Failed to encode Terraform variables: %v", err)
+	}
+	if err := os.WriteFile(varFile, varJSON, 0o600); err != nil {
+		t.Fatalf("Failed to write Terraform variable file: %v", err)
+	}
+	relativeVarFile, err := relativeToDir(terraformDir, varFile)
+	if err != nil {
+		t.Fatalf("Failed to resolve variable file relative to %s: %v", terraformDir, err)
+	}
+
+	output, err := exec.Command(infracost, "breakdown",
+		"--path", terraformDir,
+		"--terraform-var-file", relativeVarFile,
+		"--format", "json",
+		"--no-color",
+	).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			t.Fatalf("infracost breakdown failed: %v\n%s", err, exitErr.Stderr)
+		}
+		t.Fatalf("infracost breakdown failed: %v", err)
+	}
+
+	monthlyCost, err := parseTotalMonthlyCost(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Logf("Estimated monthly cost of %s: $%.2f (ceiling $%.2f)", terraformDir, monthlyCost, maxUSD)
+	if monthlyCost > maxUSD {
+		t.Errorf("Estimated monthly cost $%.2f exceeds the $%.2f ceiling", monthlyCost, maxUSD)
+	}
+}
+
+// relativeToDir returns path relative to dir. Both are made absolute first, since
+// filepath.Rel cannot relate a relative directory to an absolute path such as t.TempDir().
+func relativeToDir(dir string, path string) (string, error) {
+	absoluteDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Rel(absoluteDir, absolutePath)
+}
+
+// parseTotalMonthlyCost reads totalMonthlyCost from infracost JSON output.
+// infracost reports costs as decimal strings. A missing or null total means nothing
+// was priced, which for a guardrail is an error rather than a zero-cost estimate.
+func parseTotalMonthlyCost(output []byte) (float64, error) {
+	var breakdown struct {
+		TotalMonthlyCost *string `json:"totalMonthlyCost"`
+	}
+	if err := json.Unmarshal(output, &breakdown); err != nil {
+		return 0, fmt.Errorf("failed to parse infracost output: %w", err)
+	}
+	if breakdown.TotalMonthlyCost == nil {
+		return 0, fmt.Errorf("infracost output has no totalMonthlyCost; no resources were priced")
+	}
+
+	monthlyCost, err := strconv.ParseFloat(*breakdown.TotalMonthlyCost, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid totalMonthlyCost %q: %w", *breakdown.TotalMonthlyCost, err)
+	}
+
+	return monthlyCost, nil
+}
@@ -0,0 +1,126 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// DefaultENIWaitTimeout bounds how long a destroy waits for lingering ENIs to be released.
+// ALB and Lambda ENIs can take well over ten minutes to disappear after their owner is deleted.
+const DefaultENIWaitTimeout = 20 * time.Minute
+
+// ENIWaitTimeoutEnvVar overrides DefaultENIWaitTimeout with a Go duration (e.g. "30m")
+const ENIWaitTimeoutEnvVar = "TEST_ENI_WAIT_TIMEOUT"
+
+// eniPollInterval is how often the ENIs referencing a security group are re-checked
+var eniPollInterval = 15 * time.Second
+
+// ENIWaitTimeout returns the maximum ENI wait from TEST_ENI_WAIT_TIMEOUT, falling back to DefaultENIWaitTimeout
+func ENIWaitTimeout(t *testing.T) time.Duration {
+	value := os.Getenv(ENIWaitTimeoutEnvVar)
+	if value == "" {
+		return DefaultENIWaitTimeout
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		t.Fatalf("%s must be a positive Go duration, got %q", ENIWaitTimeoutEnvVar, value)
+	}
+
+	return timeout
+}
+
+// DestroyWaitingForENIs runs terraform destroy and, when a security group deletion fails with
+// DependencyViolation, waits until no ENIs reference securityGroupIDs before retrying.
+// The test fails if the ENIs are still attached after maxWait or the destroy fails for another reason.
+func DestroyWaitingForENIs(t *testing.T, options *terraform.Options, region string, securityGroupIDs []string, maxWait time.Duration) {
+	err := destroyWithENIRetry(
+		func() error {
+			_, err := terraform.DestroyE(t, options)
+			return err
+		},
+		func(timeout time.Duration) error {
+			return waitForSecurityGroupENIs(t, region, securityGroupIDs, timeout)
+		},
+		maxWait,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// destroyWithENIRetry retries destroy for as long as it fails with DependencyViolation,
+// waiting for the dependent ENIs to be released between attempts
+func destroyWithENIRetry(destroy func() error, waitForENIs func(time.Duration) error, maxWait time.Duration) error {
+	deadline := time.Now().Add(maxWait)
+
+	for {
+		err := destroy()
+		if err == nil || !isDependencyViolation(err) {
+			return err
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("destroy still blocked by dependent ENIs after %s: %w", maxWait, err)
+		}
+
+		if waitErr := waitForENIs(remaining); waitErr != nil {
+			return fmt.Errorf("%v (destroy error: %w)", waitErr, err)
+		}
+	}
+}
+
+// isDependencyViolation reports whether a destroy failed because a resource is still in use
+func isDependencyViolation(err error) bool {
+	return strings.Contains(err.Error(), "DependencyViolation")
+}
+
+// waitForSecurityGroupENIs polls until no network interfaces reference the security groups
+func waitForSecurityGroupENIs(t *testing.T, region string, securityGroupIDs []string, timeout time.Duration) error {
+	if len(securityGroupIDs) == 0 {
+		time.Sleep(eniPollInterval)
+		return nil
+	}
+
+	client, err := aws.NewEc2ClientE(t, region)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		output, err := client.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+			Filters: []*ec2.Filter{
+				{Name: awssdk.String("group-id"), Values: awssdk.StringSlice(securityGroupIDs)},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(output.NetworkInterfaces) == 0 {
+			return nil
+		}
+
+		eniIDs := make([]string, 0, len(output.NetworkInterfaces))
+		for _, eni := range output.NetworkInterfaces {
+			eniIDs = append(eniIDs, fmt.Sprintf("%s (%s)", awssdk.StringValue(eni.NetworkInterfaceId), awssdk.StringValue(eni.Description)))
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("ENIs still reference security groups %v after %s: %s", securityGroupIDs, timeout, strings.Join(eniIDs, ", "))
+		}
+
+		t.Logf("Waiting for %d ENI(s) to release security groups %v: %s", len(eniIDs), securityGroupIDs, strings.Join(eniIDs, ", "))
+		time.Sleep(eniPollInterval)
+	}
+}
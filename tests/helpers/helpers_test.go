@@ -0,0 +1,264 @@
+package helpers
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkingOptionsOverrides(t *testing.T) {
+	t.Parallel()
+
+	options := NetworkingOptions(t, "us-east-1", "test-helpers", map[string]interface{}{
+		"private_subnet_count": 2,
+		"enable_vpc_endpoints": nil,
+	})
+
+	assert.Equal(t, "../terraform/modules/shared-networking", options.TerraformDir)
+	assert.Equal(t, "us-east-1", options.EnvVars["AWS_DEFAULT_REGION"])
+	assert.Equal(t, "test-helpers", options.Vars["project_name"])
+	assert.Equal(t, 2, options.Vars["private_subnet_count"])
+	assert.Equal(t, 2, options.Vars["public_subnet_count"])
+	assert.NotContains(t, options.Vars, "enable_vpc_endpoints")
+}
+
+func TestNewNetworkingOutputs(t *testing.T) {
+	t.Parallel()
+
+	// Shaped like terraform.OutputAll, which decodes lists as []interface{}
+	outputs := NewNetworkingOutputs(map[string]interface{}{
+		"vpc_id":                        "vpc-123",
+		"vpc_cidr_block":                "10.0.0.0/16",
+		"public_subnet_ids":             []interface{}{"subnet-pub1", "subnet-pub2"},
+		"private_subnet_ids":            []interface{}{"subnet-priv1"},
+		"private_route_table_ids":       []interface{}{"rtb-123"},
+		"web_security_group_id":         "sg-web",
+		"application_security_group_id": "sg-app",
+		"database_security_group_id":    "sg-db",
+		"db_subnet_group_name":          nil,
+	})
+
+	assert.Equal(t, NetworkingOutputs{
+		VpcID:                      "vpc-123",
+		VpcCidrBlock:               "10.0.0.0/16",
+		PublicSubnetIDs:            []string{"subnet-pub1", "subnet-pub2"},
+		PrivateSubnetIDs:           []string{"subnet-priv1"},
+		PrivateRouteTableIDs:       []string{"rtb-123"},
+		WebSecurityGroupID:         "sg-web",
+		ApplicationSecurityGroupID: "sg-app",
+		DatabaseSecurityGroupID:    "sg-db",
+	}, outputs)
+}
+
+func TestWebApplicationOptionsWiring(t *testing.T) {
+	t.Parallel()
+
+	networking := NetworkingOutputs{
+		VpcID:                      "vpc-123",
+		PublicSubnetIDs:            []string{"subnet-pub1"},
+		PrivateSubnetIDs:           []string{"subnet-priv1"},
+		WebSecurityGroupID:         "sg-web",
+		ApplicationSecurityGroupID: "sg-app",
+	}
+
+	// The module creates its own ALB security group when alb_security_group_id is removed
+	options := WebApplicationOptions(t, "us-east-1", "test-helpers", networking, map[string]interface{}{
+		"enable_waf":            false,
+		"alb_security_group_id": nil,
+	})
+
+	assert.Equal(t, "../terraform/modules/web-application", options.TerraformDir)
+	assert.Equal(t, "vpc-123", options.Vars["vpc_id"])
+	assert.Equal(t, []string{"subnet-priv1"}, options.Vars["subnet_ids"])
+	assert.Equal(t, []string{"subnet-pub1"}, options.Vars["public_subnet_ids"])
+	assert.Equal(t, "sg-app", options.Vars["security_group_id"])
+	assert.Equal(t, false, options.Vars["enable_waf"])
+	assert.NotContains(t, options.Vars, "alb_security_group_id")
+	assert.Contains(t, options.RetryableTerraformErrors, logSubscriptionRoleNotReadyError)
+}
+
+func TestWebApplicationPlanVars(t *testing.T) {
+	t.Parallel()
+
+	vars := WebApplicationPlanVars(map[string]interface{}{
+		"project_name":  "test-plan",
+		"instance_type": "t3.large",
+		"environment":   nil,
+	})
+
+	assert.Equal(t, "test-plan", vars["project_name"])
+	assert.Equal(t, "t3.large", vars["instance_type"])
+	assert.Equal(t, []string{"subnet-123"}, vars["subnet_ids"])
+	assert.NotContains(t, vars, "environment")
+
+	// Overrides do not leak into later calls
+	assert.Equal(t, "staging", WebApplicationPlanVars()["environment"])
+}
+
+func TestNewWebApplicationOutputs(t *testing.T) {
+	t.Parallel()
+
+	outputs := NewWebApplicationOutputs(nil, map[string]interface{}{
+		"autoscaling_group_name": "test-asg",
+		"load_balancer_arn":      "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/test/abc",
+		"load_balancer_dns_name": "test.us-east-1.elb.amazonaws.com",
+		"waf_web_acl_arn":        nil,
+	})
+
+	assert.Equal(t, "test-asg", outputs.AutoscalingGroupName)
+	assert.Equal(t, "test.us-east-1.elb.amazonaws.com", outputs.LoadBalancerDNSName)
+	assert.Empty(t, outputs.TargetGroupArn)
+}
+
+func TestNetworkingOutputsSecurityGroupIDs(t *testing.T) {
+	t.Parallel()
+
+	outputs := NetworkingOutputs{WebSecurityGroupID: "sg-web", DatabaseSecurityGroupID: "sg-db"}
+	assert.Equal(t, []string{"sg-web", "sg-db"}, outputs.SecurityGroupIDs())
+
+	// Apply failed before any outputs were read
+	assert.Empty(t, NetworkingOutputs{}.SecurityGroupIDs())
+}
+
+func TestDestroyWithENIRetry(t *testing.T) {
+	t.Parallel()
+
+	dependencyViolation := errors.New("deleting EC2 Security Group (sg-123): operation error EC2: DeleteSecurityGroup, api error DependencyViolation: resource sg-123 has a dependent object")
+
+	t.Run("retries_after_enis_are_released", func(t *testing.T) {
+		destroyCalls, waitCalls := 0, 0
+		err := destroyWithENIRetry(
+			func() error {
+				destroyCalls++
+				if destroyCalls == 1 {
+					return dependencyViolation
+				}
+				return nil
+			},
+			func(time.Duration) error {
+				waitCalls++
+				return nil
+			},
+			time.Minute,
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, destroyCalls)
+		assert.Equal(t, 1, waitCalls)
+	})
+
+	t.Run("does_not_retry_other_errors", func(t *testing.T) {
+		destroyCalls := 0
+		err := destroyWithENIRetry(
+			func() error {
+				destroyCalls++
+				return errors.New("AccessDenied")
+			},
+			func(time.Duration) error {
+				t.Fatal("should not wait for ENIs")
+				return nil
+			},
+			time.Minute,
+		)
+
+		assert.EqualError(t, err, "AccessDenied")
+		assert.Equal(t, 1, destroyCalls)
+	})
+
+	t.Run("fails_when_enis_outlive_max_wait", func(t *testing.T) {
+		err := destroyWithENIRetry(
+			func() error { return dependencyViolation },
+			func(time.Duration) error { return errors.New("ENIs still reference security groups") },
+			time.Minute,
+		)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, dependencyViolation)
+		assert.Contains(t, err.Error(), "ENIs still reference security groups")
+	})
+
+	t.Run("fails_once_max_wait_has_elapsed", func(t *testing.T) {
+		err := destroyWithENIRetry(
+			func() error { return dependencyViolation },
+			func(time.Duration) error {
+				t.Fatal("should not wait once the deadline has passed")
+				return nil
+			},
+			0,
+		)
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, dependencyViolation)
+	})
+}
+
+func TestENIWaitTimeout(t *testing.T) {
+	t.Setenv(ENIWaitTimeoutEnvVar, "")
+	assert.Equal(t, DefaultENIWaitTimeout, ENIWaitTimeout(t))
+
+	t.Setenv(ENIWaitTimeoutEnvVar, "45m")
+	assert.Equal(t, 45*time.Minute, ENIWaitTimeout(t))
+}
+
+func TestParseTotalMonthlyCost(t *testing.T) {
+	t.Parallel()
+
+	monthlyCost, err := parseTotalMonthlyCost([]byte(`{"version": "0.2", "totalMonthlyCost": "42.1875", "projects": []}`))
+	require.NoError(t, err)
+	assert.InDelta(t, 42.1875, monthlyCost, 0.0001)
+
+	// Nothing priced is not a zero-cost estimate
+	_, err = parseTotalMonthlyCost([]byte(`{"totalMonthlyCost": null}`))
+	assert.Error(t, err)
+
+	_, err = parseTotalMonthlyCost([]byte(`{"version": "0.2", "projects": []}`))
+	assert.Error(t, err)
+
+	_, err = parseTotalMonthlyCost([]byte(`{"totalMonthlyCost": "not-a-number"}`))
+	assert.Error(t, err)
+
+	_, err = parseTotalMonthlyCost([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestRelativeToDir(t *testing.T) {
+	t.Parallel()
+
+	// A relative module directory and an absolute temporary file, as in AssertMonthlyCostBelow
+	varFile := filepath.Join(t.TempDir(), "cost.tfvars.json")
+	relative, err := relativeToDir("../terraform/modules/web-application", varFile)
+	require.NoError(t, err)
+	assert.False(t, filepath.IsAbs(relative))
+
+	absoluteDir, err := filepath.Abs("../terraform/modules/web-application")
+	require.NoError(t, err)
+	assert.Equal(t, varFile, filepath.Join(absoluteDir, relative))
+}
+
+func TestParseWafRulesSummary(t *testing.T) {
+	t.Parallel()
+
+	rules, err := parseWafRulesSummary([]byte(`[
+		{"name": "IPAllowlistRule", "priority": 0, "action": "allow"},
+		{"name": "AWSManagedRulesCommonRuleSet", "priority": 1, "action": "none"},
+		{"name": "RateLimitRule", "priority": 3, "action": "block"}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	assert.Equal(t, WafRule{Name: "AWSManagedRulesCommonRuleSet", Priority: 1, Action: "none"}, rules[1])
+
+	// WAF disabled
+	rules, err = parseWafRulesSummary([]byte(`[]`))
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	_, err = parseWafRulesSummary([]byte(`[{"name": "RateLimitRule", "priority": 3}, {"name": "GeoBlockingRule", "priority": 3}]`))
+	assert.Error(t, err)
+
+	_, err = parseWafRulesSummary([]byte(`not json`))
+	assert.Error(t, err)
+}
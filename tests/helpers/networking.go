@@ -0,0 +1,135 @@
+// Package helpers provides shared setup for the EPiC infrastructure integration tests.
+package helpers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// ModulesDir is the Terraform modules directory relative to the tests package,
+// which is the working directory of the integration tests
+const ModulesDir = "../terraform/modules"
+
+// NetworkingOutputs holds the shared-networking outputs that dependent modules consume
+type NetworkingOutputs struct {
+	VpcID                      string
+	VpcCidrBlock               string
+	PublicSubnetIDs            []string
+	PrivateSubnetIDs           []string
+	PrivateRouteTableIDs       []string
+	WebSecurityGroupID         string
+	ApplicationSecurityGroupID string
+	DatabaseSecurityGroupID    string
+	DatabaseSubnetGroupName    string
+}
+
+// NetworkingOptions builds Terraform options for a minimal shared-networking deployment named prefix:
+// two public subnets, one private subnet, and no NAT Gateways, flow logs, or VPC endpoints.
+// Overrides replace individual variables; a nil override value removes the variable.
+func NetworkingOptions(t *testing.T, region string, prefix string, overrides ...map[string]interface{}) *terraform.Options {
+	vars := mergeVars(map[string]interface{}{
+		"project_name":          prefix,
+		"environment":           "staging",
+		"public_subnet_count":   2,
+		"private_subnet_count":  1,
+		"database_subnet_count": 0,
+		"enable_nat_gateway":    false,
+		"enable_flow_logs":      false,
+		"enable_vpc_endpoints":  false,
+	}, overrides...)
+
+	return terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: fmt.Sprintf("%s/shared-networking", ModulesDir),
+		Vars:         vars,
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+}
+
+// DeployNetworking applies the shared-networking module, registers its destruction
+// as a test cleanup, and returns the outputs dependent modules need
+func DeployNetworking(t *testing.T, region string, prefix string, overrides ...map[string]interface{}) NetworkingOutputs {
+	options := NetworkingOptions(t, region, prefix, overrides...)
+
+	// Registered before apply so a partially applied stack is still destroyed.
+	// ENIs left behind by ALBs and NAT instances can briefly block the security group deletions.
+	var outputs NetworkingOutputs
+	t.Cleanup(func() {
+		DestroyWaitingForENIs(t, options, region, outputs.SecurityGroupIDs(), ENIWaitTimeout(t))
+	})
+	terraform.InitAndApply(t, options)
+
+	outputs = NewNetworkingOutputs(terraform.OutputAll(t, options))
+	return outputs
+}
+
+// SecurityGroupIDs returns the non-empty web, application, and database security group IDs
+func (n NetworkingOutputs) SecurityGroupIDs() []string {
+	ids := []string{}
+	for _, id := range []string{n.WebSecurityGroupID, n.ApplicationSecurityGroupID, n.DatabaseSecurityGroupID} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// NewNetworkingOutputs maps decoded shared-networking outputs (as returned by terraform.OutputAll) onto NetworkingOutputs
+func NewNetworkingOutputs(outputs map[string]interface{}) NetworkingOutputs {
+	return NetworkingOutputs{
+		VpcID:                      stringOutput(outputs, "vpc_id"),
+		VpcCidrBlock:               stringOutput(outputs, "vpc_cidr_block"),
+		PublicSubnetIDs:            stringListOutput(outputs, "public_subnet_ids"),
+		PrivateSubnetIDs:           stringListOutput(outputs, "private_subnet_ids"),
+		PrivateRouteTableIDs:       stringListOutput(outputs, "private_route_table_ids"),
+		WebSecurityGroupID:         stringOutput(outputs, "web_security_group_id"),
+		ApplicationSecurityGroupID: stringOutput(outputs, "application_security_group_id"),
+		DatabaseSecurityGroupID:    stringOutput(outputs, "database_security_group_id"),
+		DatabaseSubnetGroupName:    stringOutput(outputs, "db_subnet_group_name"),
+	}
+}
+
+// mergeVars copies defaults and applies each override map in order
+func mergeVars(defaults map[string]interface{}, overrides ...map[string]interface{}) map[string]interface{} {
+	vars := make(map[string]interface{}, len(defaults))
+	for key, value := range defaults {
+		vars[key] = value
+	}
+
+	for _, override := range overrides {
+		for key, value := range override {
+			if value == nil {
+				delete(vars, key)
+				continue
+			}
+			vars[key] = value
+		}
+	}
+
+	return vars
+}
+
+// stringOutput returns a string output, or an empty string when it is missing or null
+func stringOutput(outputs map[string]interface{}, name string) string {
+	value, _ := outputs[name].(string)
+	return value
+}
+
+// stringListOutput returns a list of strings output, or an empty list when it is missing or null
+func stringListOutput(outputs map[string]interface{}, name string) []string {
+	values := []string{}
+
+	items, _ := outputs[name].([]interface{})
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// WafRule is one entry of the web-application module's waf_rules_summary output.
+type WafRule struct {
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Action   string `json:"action"`
+}
+
+// AssertWafRuleOrder decodes summaryJSON (`terraform output -json waf_rules_summary`)
+// and fails the test unless the rules evaluate in exactly the order of expectedNames.
+func AssertWafRuleOrder(t *testing.T, summaryJSON string, expectedNames []string) {
+	rules, err := parseWafRulesSummary([]byte(summaryJSON))
+	if err != nil {
+		t.Fatalf("Failed to decode WAF rules summary: %v", err)
+	}
+
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.Name)
+	}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Errorf("WAF rules evaluate in order %v, expected %v", names, expectedNames)
+	}
+}
+
+// parseWafRulesSummary decodes the summary and checks it is sorted by strictly increasing
+// priority, since WAF rejects duplicate priorities and evaluates the lowest first.
+func parseWafRulesSummary(output []byte) ([]WafRule, error) {
+	var rules []WafRule
+	if err := json.Unmarshal(output, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse waf_rules_summary: %w", err)
+	}
+
+	for i := 1; i < len(rules); i++ {
+		if rules[i].Priority <= rules[i-1].Priority {
+			return nil, fmt.Errorf("rule %s (priority %d) is listed after %s (priority %d)",
+				rules[i].Name, rules[i].Priority, rules[i-1].Name, rules[i-1].Priority)
+		}
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,126 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// logSubscriptionRoleNotReadyError matches the PutSubscriptionFilter error returned while IAM
+// propagates a new log subscription role
+const logSubscriptionRoleNotReadyError = ".*Could not deliver test message to specified destination.*"
+
+// WebApplicationOutputs holds the Terraform options of a web-application deployment
+// together with its most commonly asserted outputs
+type WebApplicationOutputs struct {
+	Options              *terraform.Options
+	AutoscalingGroupName string
+	LoadBalancerArn      string
+	LoadBalancerDNSName  string
+	TargetGroupArn       string
+}
+
+// WebApplicationOptions builds Terraform options for a web-application deployment named prefix
+// on top of networking: instances in the private subnets, the ALB in the public subnets with the
+// web security group, and the "test-instance-profile" instance profile.
+// Overrides replace individual variables; a nil override value removes the variable.
+func WebApplicationOptions(t *testing.T, region string, prefix string, networking NetworkingOutputs, overrides ...map[string]interface{}) *terraform.Options {
+	vars := mergeVars(map[string]interface{}{
+		"project_name":          prefix,
+		"environment":           "staging",
+		"application_name":      "test-app",
+		"vpc_id":                networking.VpcID,
+		"subnet_ids":            networking.PrivateSubnetIDs,
+		"public_subnet_ids":     networking.PublicSubnetIDs,
+		"security_group_id":     networking.ApplicationSecurityGroupID,
+		"alb_security_group_id": networking.WebSecurityGroupID,
+		"instance_profile_name": "test-instance-profile",
+	}, overrides...)
+
+	options := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: fmt.Sprintf("%s/web-application", ModulesDir),
+		Vars:         vars,
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": region,
+		},
+	})
+
+	// CloudWatch Logs sends a test message when a subscription filter is created, which
+	// fails until a newly created log_subscription_role_arn can be assumed
+	options.RetryableTerraformErrors[logSubscriptionRoleNotReadyError] = "Log subscription role is not assumable by CloudWatch Logs yet"
+
+	return options
+}
+
+// WebApplicationPlanVars returns the variables of a plan-only web-application test: placeholder
+// VPC, subnet, security group, and instance profile IDs that are never looked up at plan time.
+// Overrides replace individual variables; a nil override value removes the variable.
+func WebApplicationPlanVars(overrides ...map[string]interface{}) map[string]interface{} {
+	return mergeVars(map[string]interface{}{
+		"project_name":          "test",
+		"environment":           "staging",
+		"application_name":      "test-app",
+		"vpc_id":                "vpc-123",
+		"subnet_ids":            []string{"subnet-123"},
+		"public_subnet_ids":     []string{"subnet-456"},
+		"security_group_id":     "sg-123",
+		"alb_security_group_id": "sg-456",
+		"instance_profile_name": "test-profile",
+	}, overrides...)
+}
+
+// WebApplicationPlanOptions builds Terraform options for a plan-only web-application test with
+// WebApplicationPlanVars, a random stable region, and a plan file in the test's temporary directory.
+// The test is skipped when AWS credentials are not configured, since the provider still needs them to plan.
+func WebApplicationPlanOptions(t *testing.T, overrides ...map[string]interface{}) *terraform.Options {
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	return &terraform.Options{
+		TerraformDir: fmt.Sprintf("%s/web-application", ModulesDir),
+		PlanFilePath: filepath.Join(t.TempDir(), "plan.out"),
+		Vars:         WebApplicationPlanVars(overrides...),
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": aws.GetRandomStableRegion(t, nil, nil),
+		},
+	}
+}
+
+// DeployWebApplication applies the web-application module, registers its destruction
+// as a test cleanup, and returns its options and key outputs
+func DeployWebApplication(t *testing.T, region string, prefix string, networking NetworkingOutputs, overrides ...map[string]interface{}) WebApplicationOutputs {
+	options := WebApplicationOptions(t, region, prefix, networking, overrides...)
+
+	// Registered before apply so a partially applied stack is still destroyed.
+	// The ALB's ENIs outlive it for a while and can block deleting a module-created ALB security group.
+	var albSecurityGroupIDs []string
+	t.Cleanup(func() {
+		DestroyWaitingForENIs(t, options, region, albSecurityGroupIDs, ENIWaitTimeout(t))
+	})
+	terraform.InitAndApply(t, options)
+
+	outputs := terraform.OutputAll(t, options)
+	if id := stringOutput(outputs, "alb_security_group_id"); id != "" {
+		albSecurityGroupIDs = []string{id}
+	}
+
+	return NewWebApplicationOutputs(options, outputs)
+}
+
+// NewWebApplicationOutputs maps decoded web-application outputs (as returned by terraform.OutputAll) onto WebApplicationOutputs
+func NewWebApplicationOutputs(options *terraform.Options, outputs map[string]interface{}) WebApplicationOutputs {
+	return WebApplicationOutputs{
+		Options:              options,
+		AutoscalingGroupName: stringOutput(outputs, "autoscaling_group_name"),
+		LoadBalancerArn:      stringOutput(outputs, "load_balancer_arn"),
+		LoadBalancerDNSName:  stringOutput(outputs, "load_balancer_dns_name"),
+		TargetGroupArn:       stringOutput(outputs, "target_group_arn"),
+	}
+}
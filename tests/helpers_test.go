@@ -0,0 +1,1035 @@
+package tests
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/eventbridge"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/require"
+)
+
+// getRegionalWebACL fetches a REGIONAL scoped WAFv2 Web ACL by ID and name
+func getRegionalWebACL(t *testing.T, awsRegion string, webACLID string, webACLName string) *wafv2.WebACL {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := wafv2.New(sess).GetWebACL(&wafv2.GetWebACLInput{
+		Id:    awssdk.String(webACLID),
+		Name:  awssdk.String(webACLName),
+		Scope: awssdk.String(wafv2.ScopeRegional),
+	})
+	require.NoError(t, err)
+
+	return output.WebACL
+}
+
+// getWebACLRuleNames returns the names of all rules in a Web ACL
+func getWebACLRuleNames(webACL *wafv2.WebACL) []string {
+	ruleNames := make([]string, 0, len(webACL.Rules))
+	for _, rule := range webACL.Rules {
+		ruleNames = append(ruleNames, awssdk.StringValue(rule.Name))
+	}
+
+	return ruleNames
+}
+
+// getWebACLResourceArns returns the ARNs of the load balancers associated with a REGIONAL Web ACL
+func getWebACLResourceArns(t *testing.T, awsRegion string, webACLArn string) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := wafv2.New(sess).ListResourcesForWebACL(&wafv2.ListResourcesForWebACLInput{
+		WebACLArn:    awssdk.String(webACLArn),
+		ResourceType: awssdk.String(wafv2.ResourceTypeApplicationLoadBalancer),
+	})
+	require.NoError(t, err)
+
+	return awssdk.StringValueSlice(output.ResourceArns)
+}
+
+// getListenerForwardWeights returns the target group ARN to weight mapping of a listener's default forward action
+func getListenerForwardWeights(t *testing.T, awsRegion string, listenerArn string) map[string]int64 {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := elbv2.New(sess).DescribeListeners(&elbv2.DescribeListenersInput{
+		ListenerArns: []*string{awssdk.String(listenerArn)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.Listeners, 1)
+
+	weights := make(map[string]int64)
+	for _, action := range output.Listeners[0].DefaultActions {
+		if awssdk.StringValue(action.Type) != elbv2.ActionTypeEnumForward || action.ForwardConfig == nil {
+			continue
+		}
+		for _, targetGroup := range action.ForwardConfig.TargetGroups {
+			weights[awssdk.StringValue(targetGroup.TargetGroupArn)] = awssdk.Int64Value(targetGroup.Weight)
+		}
+	}
+
+	return weights
+}
+
+// getRequiredProviders parses every .tf file in a module directory and returns the
+// version constraint of each provider declared in a terraform.required_providers block
+func getRequiredProviders(t *testing.T, moduleDir string) map[string]string {
+	files, err := filepath.Glob(filepath.Join(moduleDir, "*.tf"))
+	require.NoError(t, err)
+
+	parser := hclparse.NewParser()
+	providers := make(map[string]string)
+
+	for _, file := range files {
+		hclFile, diags := parser.ParseHCLFile(file)
+		require.False(t, diags.HasErrors(), diags.Error())
+
+		content, _, diags := hclFile.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "terraform"}},
+		})
+		require.False(t, diags.HasErrors(), diags.Error())
+
+		for _, terraformBlock := range content.Blocks {
+			terraformContent, _, diags := terraformBlock.Body.PartialContent(&hcl.BodySchema{
+				Blocks: []hcl.BlockHeaderSchema{{Type: "required_providers"}},
+			})
+			require.False(t, diags.HasErrors(), diags.Error())
+
+			for _, requiredProviders := range terraformContent.Blocks {
+				attributes, diags := requiredProviders.Body.JustAttributes()
+				require.False(t, diags.HasErrors(), diags.Error())
+
+				for name, attribute := range attributes {
+					value, diags := attribute.Expr.Value(nil)
+					require.False(t, diags.HasErrors(), diags.Error())
+
+					version := ""
+					if value.Type().IsObjectType() && value.Type().HasAttribute("version") {
+						version = value.GetAttr("version").AsString()
+					}
+					providers[name] = version
+				}
+			}
+		}
+	}
+
+	return providers
+}
+
+// getRouteTableRoutes returns the routes of a route table
+func getRouteTableRoutes(t *testing.T, awsRegion string, routeTableID string) []*ec2.Route {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+		RouteTableIds: []*string{awssdk.String(routeTableID)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.RouteTables, 1)
+
+	return output.RouteTables[0].Routes
+}
+
+// findRoute returns the route to an IPv4 or IPv6 destination CIDR, or nil when there is none
+func findRoute(routes []*ec2.Route, destination string) *ec2.Route {
+	for _, route := range routes {
+		if awssdk.StringValue(route.DestinationCidrBlock) == destination || awssdk.StringValue(route.DestinationIpv6CidrBlock) == destination {
+			return route
+		}
+	}
+
+	return nil
+}
+
+// getSubnet describes a single subnet
+func getSubnet(t *testing.T, awsRegion string, subnetID string) *ec2.Subnet {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: []*string{awssdk.String(subnetID)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.Subnets, 1)
+
+	return output.Subnets[0]
+}
+
+// getSubnetIpv6CidrBlocks returns the associated IPv6 CIDR blocks of a subnet
+func getSubnetIpv6CidrBlocks(subnet *ec2.Subnet) []string {
+	blocks := []string{}
+	for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+		if awssdk.StringValue(association.Ipv6CidrBlockState.State) == ec2.SubnetCidrBlockStateCodeAssociated {
+			blocks = append(blocks, awssdk.StringValue(association.Ipv6CidrBlock))
+		}
+	}
+
+	return blocks
+}
+
+// getVpcIpv6CidrBlocks returns the associated IPv6 CIDR blocks of a VPC
+func getVpcIpv6CidrBlocks(t *testing.T, awsRegion string, vpcID string) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeVpcs(&ec2.DescribeVpcsInput{
+		VpcIds: []*string{awssdk.String(vpcID)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.Vpcs, 1)
+
+	blocks := []string{}
+	for _, association := range output.Vpcs[0].Ipv6CidrBlockAssociationSet {
+		if awssdk.StringValue(association.Ipv6CidrBlockState.State) == ec2.VpcCidrBlockStateCodeAssociated {
+			blocks = append(blocks, awssdk.StringValue(association.Ipv6CidrBlock))
+		}
+	}
+
+	return blocks
+}
+
+// getDefaultRouteNatGatewayID returns the NAT Gateway targeted by a route table's
+// 0.0.0.0/0 route, or an empty string when there is no NAT default route
+func getDefaultRouteNatGatewayID(t *testing.T, awsRegion string, routeTableID string) string {
+	for _, route := range getRouteTableRoutes(t, awsRegion, routeTableID) {
+		if awssdk.StringValue(route.DestinationCidrBlock) == "0.0.0.0/0" {
+			return awssdk.StringValue(route.NatGatewayId)
+		}
+	}
+
+	return ""
+}
+
+// getDefaultRouteNetworkInterfaceID returns the network interface targeted by a route
+// table's 0.0.0.0/0 route, or an empty string when it does not target one
+func getDefaultRouteNetworkInterfaceID(t *testing.T, awsRegion string, routeTableID string) string {
+	for _, route := range getRouteTableRoutes(t, awsRegion, routeTableID) {
+		if awssdk.StringValue(route.DestinationCidrBlock) == "0.0.0.0/0" {
+			return awssdk.StringValue(route.NetworkInterfaceId)
+		}
+	}
+
+	return ""
+}
+
+// getManagedPrefixListID looks up a managed prefix list ID by name
+func getManagedPrefixListID(t *testing.T, awsRegion string, prefixListName string) string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeManagedPrefixLists(&ec2.DescribeManagedPrefixListsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   awssdk.String("prefix-list-name"),
+				Values: []*string{awssdk.String(prefixListName)},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.PrefixLists, 1)
+
+	return awssdk.StringValue(output.PrefixLists[0].PrefixListId)
+}
+
+// getSecurityGroupIngressPrefixListIDs returns the prefix list IDs referenced by a security group's ingress rules
+func getSecurityGroupIngressPrefixListIDs(t *testing.T, awsRegion string, securityGroupID string) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		GroupIds: []*string{awssdk.String(securityGroupID)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.SecurityGroups, 1)
+
+	prefixListIDs := []string{}
+	for _, permission := range output.SecurityGroups[0].IpPermissions {
+		for _, prefixList := range permission.PrefixListIds {
+			prefixListIDs = append(prefixListIDs, awssdk.StringValue(prefixList.PrefixListId))
+		}
+	}
+
+	return prefixListIDs
+}
+
+// getNetworkACLSubnetIDs returns the IDs of the subnets associated with a Network ACL
+func getNetworkACLSubnetIDs(t *testing.T, awsRegion string, networkACLID string) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeNetworkAcls(&ec2.DescribeNetworkAclsInput{
+		NetworkAclIds: []*string{awssdk.String(networkACLID)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.NetworkAcls, 1)
+
+	subnetIDs := []string{}
+	for _, association := range output.NetworkAcls[0].Associations {
+		subnetIDs = append(subnetIDs, awssdk.StringValue(association.SubnetId))
+	}
+
+	return subnetIDs
+}
+
+// getNetworkACLIngressAllowedCIDRs returns the IPv4 CIDR blocks a Network ACL allows in on a TCP port
+func getNetworkACLIngressAllowedCIDRs(t *testing.T, awsRegion string, networkACLID string, port int64) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeNetworkAcls(&ec2.DescribeNetworkAclsInput{
+		NetworkAclIds: []*string{awssdk.String(networkACLID)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.NetworkAcls, 1)
+
+	cidrs := []string{}
+	for _, entry := range output.NetworkAcls[0].Entries {
+		if awssdk.BoolValue(entry.Egress) || awssdk.StringValue(entry.RuleAction) != ec2.RuleActionAllow || entry.CidrBlock == nil {
+			continue
+		}
+		// Protocol 6 is TCP and -1 is all traffic
+		protocol := awssdk.StringValue(entry.Protocol)
+		if protocol != "6" && protocol != "-1" {
+			continue
+		}
+		if protocol == "6" && (awssdk.Int64Value(entry.PortRange.From) > port || awssdk.Int64Value(entry.PortRange.To) < port) {
+			continue
+		}
+		cidrs = append(cidrs, awssdk.StringValue(entry.CidrBlock))
+	}
+
+	return cidrs
+}
+
+// createPrivateHostedZone creates a Route53 private hosted zone associated with a VPC and returns its ID
+func createPrivateHostedZone(t *testing.T, awsRegion string, zoneName string, vpcID string) string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := route53.New(sess).CreateHostedZone(&route53.CreateHostedZoneInput{
+		Name:            awssdk.String(zoneName),
+		CallerReference: awssdk.String(random.UniqueId()),
+		HostedZoneConfig: &route53.HostedZoneConfig{
+			PrivateZone: awssdk.Bool(true),
+		},
+		VPC: &route53.VPC{
+			VPCId:     awssdk.String(vpcID),
+			VPCRegion: awssdk.String(awsRegion),
+		},
+	})
+	require.NoError(t, err)
+
+	return strings.TrimPrefix(awssdk.StringValue(output.HostedZone.Id), "/hostedzone/")
+}
+
+// deleteHostedZone deletes a Route53 hosted zone that only holds its default NS and SOA records
+func deleteHostedZone(t *testing.T, awsRegion string, zoneID string) {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	_, err = route53.New(sess).DeleteHostedZone(&route53.DeleteHostedZoneInput{
+		Id: awssdk.String(zoneID),
+	})
+	require.NoError(t, err)
+}
+
+// getRoute53AliasTargetDNSName returns the alias target DNS name of a record, without the trailing dot
+func getRoute53AliasTargetDNSName(t *testing.T, awsRegion string, zoneID string, recordName string, recordType string) string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := route53.New(sess).ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+		HostedZoneId:    awssdk.String(zoneID),
+		StartRecordName: awssdk.String(recordName),
+		StartRecordType: awssdk.String(recordType),
+		MaxItems:        awssdk.String("1"),
+	})
+	require.NoError(t, err)
+	require.Len(t, output.ResourceRecordSets, 1)
+
+	recordSet := output.ResourceRecordSets[0]
+	require.Equal(t, strings.TrimSuffix(recordName, ".")+".", awssdk.StringValue(recordSet.Name))
+	require.Equal(t, recordType, awssdk.StringValue(recordSet.Type))
+	require.NotNil(t, recordSet.AliasTarget, "record %s should be an alias record", recordName)
+
+	return strings.TrimSuffix(awssdk.StringValue(recordSet.AliasTarget.DNSName), ".")
+}
+
+// getAsgSubnetIDs returns the subnet IDs from an Auto Scaling Group's VPCZoneIdentifier
+func getAsgSubnetIDs(t *testing.T, awsRegion string, asgName string) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := autoscaling.New(sess).DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{awssdk.String(asgName)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.AutoScalingGroups, 1)
+
+	return strings.Split(awssdk.StringValue(output.AutoScalingGroups[0].VPCZoneIdentifier), ",")
+}
+
+// getAsgHealthCheckType returns the health check type (EC2 or ELB) of an Auto Scaling Group
+func getAsgHealthCheckType(t *testing.T, awsRegion string, asgName string) string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := autoscaling.New(sess).DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{awssdk.String(asgName)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.AutoScalingGroups, 1)
+
+	return awssdk.StringValue(output.AutoScalingGroups[0].HealthCheckType)
+}
+
+// getAsgTargetGroupArns returns the target group ARNs an Auto Scaling Group registers its instances with
+func getAsgTargetGroupArns(t *testing.T, awsRegion string, asgName string) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := autoscaling.New(sess).DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{awssdk.String(asgName)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.AutoScalingGroups, 1)
+
+	return awssdk.StringValueSlice(output.AutoScalingGroups[0].TargetGroupARNs)
+}
+
+// getTargetTrackingResourceLabel returns the predefined metric resource label of a target tracking scaling policy
+func getTargetTrackingResourceLabel(t *testing.T, awsRegion string, asgName string, policyArn string) string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := autoscaling.New(sess).DescribePolicies(&autoscaling.DescribePoliciesInput{
+		AutoScalingGroupName: awssdk.String(asgName),
+	})
+	require.NoError(t, err)
+
+	for _, policy := range output.ScalingPolicies {
+		if awssdk.StringValue(policy.PolicyARN) != policyArn {
+			continue
+		}
+		require.NotNil(t, policy.TargetTrackingConfiguration)
+		require.NotNil(t, policy.TargetTrackingConfiguration.PredefinedMetricSpecification)
+
+		return awssdk.StringValue(policy.TargetTrackingConfiguration.PredefinedMetricSpecification.ResourceLabel)
+	}
+
+	require.Failf(t, "scaling policy not found", "policy %s not found on ASG %s", policyArn, asgName)
+	return ""
+}
+
+// getCloudWatchAlarmTags returns the tags of a CloudWatch alarm as a map
+func getCloudWatchAlarmTags(t *testing.T, awsRegion string, alarmArn string) map[string]string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := cloudwatch.New(sess).ListTagsForResource(&cloudwatch.ListTagsForResourceInput{
+		ResourceARN: awssdk.String(alarmArn),
+	})
+	require.NoError(t, err)
+
+	tags := make(map[string]string)
+	for _, tag := range output.Tags {
+		tags[awssdk.StringValue(tag.Key)] = awssdk.StringValue(tag.Value)
+	}
+
+	return tags
+}
+
+// getBucketReplicationDestinations returns the destination bucket ARNs of a bucket's replication rules
+func getBucketReplicationDestinations(t *testing.T, awsRegion string, bucketName string) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := s3.New(sess).GetBucketReplication(&s3.GetBucketReplicationInput{
+		Bucket: awssdk.String(bucketName),
+	})
+	require.NoError(t, err)
+
+	destinations := []string{}
+	for _, rule := range output.ReplicationConfiguration.Rules {
+		destinations = append(destinations, awssdk.StringValue(rule.Destination.Bucket))
+	}
+
+	return destinations
+}
+
+// getBucketPublicAccessBlock returns a bucket's public access block configuration
+func getBucketPublicAccessBlock(t *testing.T, awsRegion string, bucketName string) *s3.PublicAccessBlockConfiguration {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := s3.New(sess).GetPublicAccessBlock(&s3.GetPublicAccessBlockInput{
+		Bucket: awssdk.String(bucketName),
+	})
+	require.NoError(t, err)
+
+	return output.PublicAccessBlockConfiguration
+}
+
+// getBucketLifecycleExpirationDays returns the current-version expiration age in days of each enabled lifecycle rule, keyed by rule ID
+func getBucketLifecycleExpirationDays(t *testing.T, awsRegion string, bucketName string) map[string]int64 {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := s3.New(sess).GetBucketLifecycleConfiguration(&s3.GetBucketLifecycleConfigurationInput{
+		Bucket: awssdk.String(bucketName),
+	})
+	require.NoError(t, err)
+
+	expirationDays := map[string]int64{}
+	for _, rule := range output.Rules {
+		if awssdk.StringValue(rule.Status) == s3.ExpirationStatusEnabled && rule.Expiration != nil {
+			expirationDays[awssdk.StringValue(rule.ID)] = awssdk.Int64Value(rule.Expiration.Days)
+		}
+	}
+
+	return expirationDays
+}
+
+// getBucketNotificationQueueArns returns the queue ARNs and events of a bucket's SQS event notifications
+func getBucketNotificationQueueArns(t *testing.T, awsRegion string, bucketName string) map[string][]string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := s3.New(sess).GetBucketNotificationConfiguration(&s3.GetBucketNotificationConfigurationRequest{
+		Bucket: awssdk.String(bucketName),
+	})
+	require.NoError(t, err)
+
+	queues := map[string][]string{}
+	for _, configuration := range output.QueueConfigurations {
+		queueArn := awssdk.StringValue(configuration.QueueArn)
+		queues[queueArn] = append(queues[queueArn], awssdk.StringValueSlice(configuration.Events)...)
+	}
+
+	return queues
+}
+
+// describeFlowLogs returns the flow logs attached to a VPC or subnet
+func describeFlowLogs(t *testing.T, awsRegion string, resourceID string) []*ec2.FlowLog {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeFlowLogs(&ec2.DescribeFlowLogsInput{
+		Filter: []*ec2.Filter{
+			{
+				Name:   awssdk.String("resource-id"),
+				Values: []*string{awssdk.String(resourceID)},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	return output.FlowLogs
+}
+
+// getVpcEndpoint fetches a VPC endpoint by ID
+func getVpcEndpoint(t *testing.T, awsRegion string, endpointID string) *ec2.VpcEndpoint {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeVpcEndpoints(&ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: []*string{awssdk.String(endpointID)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.VpcEndpoints, 1)
+
+	return output.VpcEndpoints[0]
+}
+
+// getVpcFlowLogDestinationTypes returns the log destination type of each flow log attached to a VPC
+func getVpcFlowLogDestinationTypes(t *testing.T, awsRegion string, vpcID string) []string {
+	destinationTypes := []string{}
+	for _, flowLog := range describeFlowLogs(t, awsRegion, vpcID) {
+		destinationTypes = append(destinationTypes, awssdk.StringValue(flowLog.LogDestinationType))
+	}
+
+	return destinationTypes
+}
+
+// getVpcFlowLogFormats returns the log format of each flow log attached to a VPC
+func getVpcFlowLogFormats(t *testing.T, awsRegion string, vpcID string) []string {
+	logFormats := []string{}
+	for _, flowLog := range describeFlowLogs(t, awsRegion, vpcID) {
+		logFormats = append(logFormats, awssdk.StringValue(flowLog.LogFormat))
+	}
+
+	return logFormats
+}
+
+// getSubnetFlowLogIDs returns the IDs of the flow logs attached directly to a subnet
+func getSubnetFlowLogIDs(t *testing.T, awsRegion string, subnetID string) []string {
+	flowLogIDs := []string{}
+	for _, flowLog := range describeFlowLogs(t, awsRegion, subnetID) {
+		flowLogIDs = append(flowLogIDs, awssdk.StringValue(flowLog.FlowLogId))
+	}
+
+	return flowLogIDs
+}
+
+// getLogGroupKmsKeyID returns the KMS key ARN a CloudWatch Log Group is encrypted with (empty when unencrypted)
+func getLogGroupKmsKeyID(t *testing.T, awsRegion string, logGroupName string) string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := cloudwatchlogs.New(sess).DescribeLogGroups(&cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: awssdk.String(logGroupName),
+	})
+	require.NoError(t, err)
+
+	for _, logGroup := range output.LogGroups {
+		if awssdk.StringValue(logGroup.LogGroupName) == logGroupName {
+			return awssdk.StringValue(logGroup.KmsKeyId)
+		}
+	}
+
+	require.FailNow(t, "log group not found", logGroupName)
+	return ""
+}
+
+// getLogSubscriptionFilter returns a named subscription filter on a CloudWatch Log Group
+func getLogSubscriptionFilter(t *testing.T, awsRegion string, logGroupName string, filterName string) *cloudwatchlogs.SubscriptionFilter {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := cloudwatchlogs.New(sess).DescribeSubscriptionFilters(&cloudwatchlogs.DescribeSubscriptionFiltersInput{
+		LogGroupName:     awssdk.String(logGroupName),
+		FilterNamePrefix: awssdk.String(filterName),
+	})
+	require.NoError(t, err)
+
+	for _, filter := range output.SubscriptionFilters {
+		if awssdk.StringValue(filter.FilterName) == filterName {
+			return filter
+		}
+	}
+
+	require.FailNow(t, "subscription filter not found", filterName)
+	return nil
+}
+
+// getCloudFrontDistribution fetches a CloudFront distribution by ID (CloudFront is a global service)
+func getCloudFrontDistribution(t *testing.T, distributionID string) *cloudfront.Distribution {
+	sess, err := aws.NewAuthenticatedSession("us-east-1")
+	require.NoError(t, err)
+
+	output, err := cloudfront.New(sess).GetDistribution(&cloudfront.GetDistributionInput{
+		Id: awssdk.String(distributionID),
+	})
+	require.NoError(t, err)
+
+	return output.Distribution
+}
+
+// createTemporaryKeyPair imports a freshly generated EC2 key pair and deletes it when the test finishes.
+// It returns the key pair name and the PEM encoded private key.
+func createTemporaryKeyPair(t *testing.T, awsRegion string) (string, string) {
+	keyPairName := fmt.Sprintf("terratest-%s", random.UniqueId())
+	keyPair := aws.CreateAndImportEC2KeyPair(t, awsRegion, keyPairName)
+
+	t.Cleanup(func() {
+		aws.DeleteEC2KeyPair(t, keyPair)
+	})
+
+	return keyPair.Name, keyPair.KeyPair.PrivateKey
+}
+
+// getVpcDhcpOptionsID returns the ID of the DHCP options set associated with a VPC
+func getVpcDhcpOptionsID(t *testing.T, awsRegion string, vpcID string) string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeVpcs(&ec2.DescribeVpcsInput{
+		VpcIds: []*string{awssdk.String(vpcID)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.Vpcs, 1)
+
+	return awssdk.StringValue(output.Vpcs[0].DhcpOptionsId)
+}
+
+// getDhcpOptionsConfiguration returns the values of a DHCP options set keyed by option name (e.g., domain-name)
+func getDhcpOptionsConfiguration(t *testing.T, awsRegion string, dhcpOptionsID string) map[string][]string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeDhcpOptions(&ec2.DescribeDhcpOptionsInput{
+		DhcpOptionsIds: []*string{awssdk.String(dhcpOptionsID)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.DhcpOptions, 1)
+
+	configuration := map[string][]string{}
+	for _, option := range output.DhcpOptions[0].DhcpConfigurations {
+		for _, value := range option.Values {
+			key := awssdk.StringValue(option.Key)
+			configuration[key] = append(configuration[key], awssdk.StringValue(value.Value))
+		}
+	}
+
+	return configuration
+}
+
+// getLaunchTemplateInstanceProfileName returns the IAM instance profile name of a launch template's latest version
+func getLaunchTemplateInstanceProfileName(t *testing.T, awsRegion string, launchTemplateID string) string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeLaunchTemplateVersions(&ec2.DescribeLaunchTemplateVersionsInput{
+		LaunchTemplateId: awssdk.String(launchTemplateID),
+		Versions:         []*string{awssdk.String("$Latest")},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.LaunchTemplateVersions, 1)
+
+	profile := output.LaunchTemplateVersions[0].LaunchTemplateData.IamInstanceProfile
+	require.NotNil(t, profile, "launch template should have an IAM instance profile")
+
+	return awssdk.StringValue(profile.Name)
+}
+
+// getSecurityGroupIngressPermissions returns the ingress rules of a security group
+func getSecurityGroupIngressPermissions(t *testing.T, awsRegion string, securityGroupID string) []*ec2.IpPermission {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		GroupIds: []*string{awssdk.String(securityGroupID)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.SecurityGroups, 1)
+
+	return output.SecurityGroups[0].IpPermissions
+}
+
+// getRunnerPublicCIDR returns the public IP address of the machine running the tests as a /32 CIDR block
+func getRunnerPublicCIDR(t *testing.T) string {
+	resp, err := http.Get("https://checkip.amazonaws.com")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return fmt.Sprintf("%s/32", strings.TrimSpace(string(body)))
+}
+
+// waitForHTTPStatus polls url until it returns expectedStatus and returns the response body.
+// The delay between attempts starts at 5 seconds and doubles up to a minute; the test fails once timeout has elapsed.
+func waitForHTTPStatus(t *testing.T, url string, expectedStatus int, timeout time.Duration) string {
+	client := &http.Client{Timeout: 10 * time.Second}
+	deadline := time.Now().Add(timeout)
+	delay := 5 * time.Second
+
+	for {
+		resp, err := client.Get(url)
+		if err == nil {
+			body, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if readErr == nil && resp.StatusCode == expectedStatus {
+				return string(body)
+			}
+			err = fmt.Errorf("got HTTP %d", resp.StatusCode)
+		}
+
+		if time.Now().Add(delay).After(deadline) {
+			t.Fatalf("%s did not return HTTP %d within %s: %v", url, expectedStatus, timeout, err)
+		}
+
+		t.Logf("Retrying %s in %s: %v", url, delay, err)
+		time.Sleep(delay)
+		delay = min(delay*2, time.Minute)
+	}
+}
+
+// getMaintenanceWindow fetches an SSM maintenance window by ID
+func getMaintenanceWindow(t *testing.T, awsRegion string, windowID string) *ssm.GetMaintenanceWindowOutput {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ssm.New(sess).GetMaintenanceWindow(&ssm.GetMaintenanceWindowInput{
+		WindowId: awssdk.String(windowID),
+	})
+	require.NoError(t, err)
+
+	return output
+}
+
+// getPatchBaseline fetches an SSM patch baseline by ID
+func getPatchBaseline(t *testing.T, awsRegion string, baselineID string) *ssm.GetPatchBaselineOutput {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ssm.New(sess).GetPatchBaseline(&ssm.GetPatchBaselineInput{
+		BaselineId: awssdk.String(baselineID),
+	})
+	require.NoError(t, err)
+
+	return output
+}
+
+// getLoadBalancerScheme returns the scheme (internal or internet-facing) of a load balancer
+func getLoadBalancerScheme(t *testing.T, awsRegion string, loadBalancerArn string) string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := elbv2.New(sess).DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		LoadBalancerArns: []*string{awssdk.String(loadBalancerArn)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.LoadBalancers, 1)
+
+	return awssdk.StringValue(output.LoadBalancers[0].Scheme)
+}
+
+// getLoadBalancerIPAddressType returns the IP address type (ipv4 or dualstack) of a load balancer
+func getLoadBalancerIPAddressType(t *testing.T, awsRegion string, loadBalancerArn string) string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := elbv2.New(sess).DescribeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		LoadBalancerArns: []*string{awssdk.String(loadBalancerArn)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.LoadBalancers, 1)
+
+	return awssdk.StringValue(output.LoadBalancers[0].IpAddressType)
+}
+
+// getLoadBalancerAttributes returns the attributes of a load balancer keyed by attribute name
+func getLoadBalancerAttributes(t *testing.T, awsRegion string, loadBalancerArn string) map[string]string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := elbv2.New(sess).DescribeLoadBalancerAttributes(&elbv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: awssdk.String(loadBalancerArn),
+	})
+	require.NoError(t, err)
+
+	attributes := map[string]string{}
+	for _, attribute := range output.Attributes {
+		attributes[awssdk.StringValue(attribute.Key)] = awssdk.StringValue(attribute.Value)
+	}
+
+	return attributes
+}
+
+// describeCloudWatchAlarm fetches a CloudWatch metric alarm by ARN
+func describeCloudWatchAlarm(t *testing.T, awsRegion string, alarmArn string) *cloudwatch.MetricAlarm {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	// Alarm ARNs end with ":alarm:<name>"
+	alarmName := alarmArn[strings.LastIndex(alarmArn, ":alarm:")+len(":alarm:"):]
+
+	output, err := cloudwatch.New(sess).DescribeAlarms(&cloudwatch.DescribeAlarmsInput{
+		AlarmNames: []*string{awssdk.String(alarmName)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.MetricAlarms, 1)
+
+	return output.MetricAlarms[0]
+}
+
+// getCloudWatchAlarmActions returns the alarm actions of a CloudWatch metric alarm
+func getCloudWatchAlarmActions(t *testing.T, awsRegion string, alarmArn string) []string {
+	return awssdk.StringValueSlice(describeCloudWatchAlarm(t, awsRegion, alarmArn).AlarmActions)
+}
+
+// getCloudWatchAlarmDimensions returns the dimensions of a CloudWatch metric alarm keyed by name
+func getCloudWatchAlarmDimensions(t *testing.T, awsRegion string, alarmArn string) map[string]string {
+	dimensions := map[string]string{}
+	for _, dimension := range describeCloudWatchAlarm(t, awsRegion, alarmArn).Dimensions {
+		dimensions[awssdk.StringValue(dimension.Name)] = awssdk.StringValue(dimension.Value)
+	}
+
+	return dimensions
+}
+
+// getSecurityGroupNetworkInterfaceIDs returns the IDs of the network interfaces referencing a security group
+func getSecurityGroupNetworkInterfaceIDs(t *testing.T, awsRegion string, securityGroupID string) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := ec2.New(sess).DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			{Name: awssdk.String("group-id"), Values: []*string{awssdk.String(securityGroupID)}},
+		},
+	})
+	require.NoError(t, err)
+
+	ids := []string{}
+	for _, eni := range output.NetworkInterfaces {
+		ids = append(ids, awssdk.StringValue(eni.NetworkInterfaceId))
+	}
+
+	return ids
+}
+
+// securityGroupExists reports whether a security group is still present
+func securityGroupExists(t *testing.T, awsRegion string, securityGroupID string) bool {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	_, err = ec2.New(sess).DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		GroupIds: []*string{awssdk.String(securityGroupID)},
+	})
+	if err != nil && strings.Contains(err.Error(), "InvalidGroup.NotFound") {
+		return false
+	}
+	require.NoError(t, err)
+
+	return true
+}
+
+// vpcExists reports whether a VPC is still present, treating a not-found error as deleted
+func vpcExists(t *testing.T, awsRegion string, vpcID string) bool {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	_, err = ec2.New(sess).DescribeVpcs(&ec2.DescribeVpcsInput{
+		VpcIds: []*string{awssdk.String(vpcID)},
+	})
+	if err != nil && strings.Contains(err.Error(), "InvalidVpcID.NotFound") {
+		return false
+	}
+	require.NoError(t, err)
+
+	return true
+}
+
+// getAsgSuspendedProcessNames returns the names of the processes suspended on an Auto Scaling Group
+func getAsgSuspendedProcessNames(t *testing.T, awsRegion string, asgName string) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := autoscaling.New(sess).DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{awssdk.String(asgName)},
+	})
+	require.NoError(t, err)
+	require.Len(t, output.AutoScalingGroups, 1)
+
+	names := []string{}
+	for _, process := range output.AutoScalingGroups[0].SuspendedProcesses {
+		names = append(names, awssdk.StringValue(process.ProcessName))
+	}
+
+	return names
+}
+
+// getTestRegions returns the regions listed in TEST_REGIONS (comma-separated), or defaultRegions when it is unset
+func getTestRegions(defaultRegions []string) []string {
+	regions := []string{}
+	for _, region := range strings.Split(os.Getenv("TEST_REGIONS"), ",") {
+		if region = strings.TrimSpace(region); region != "" {
+			regions = append(regions, region)
+		}
+	}
+
+	if len(regions) == 0 {
+		return defaultRegions
+	}
+
+	return regions
+}
+
+// getKmsGrant fetches a grant on a KMS key by grant ID
+func getKmsGrant(t *testing.T, awsRegion string, keyID string, grantID string) *kms.GrantListEntry {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := kms.New(sess).ListGrants(&kms.ListGrantsInput{
+		KeyId:   awssdk.String(keyID),
+		GrantId: awssdk.String(grantID),
+	})
+	require.NoError(t, err)
+	require.Len(t, output.Grants, 1)
+
+	return output.Grants[0]
+}
+
+// getDBInstance describes a single RDS instance
+func getDBInstance(t *testing.T, awsRegion string, identifier string) *rds.DBInstance {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := rds.New(sess).DescribeDBInstances(&rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: awssdk.String(identifier),
+	})
+	require.NoError(t, err)
+	require.Len(t, output.DBInstances, 1)
+
+	return output.DBInstances[0]
+}
+
+// getLambdaFunctionConfiguration describes the configuration of a Lambda function
+func getLambdaFunctionConfiguration(t *testing.T, awsRegion string, functionName string) *lambda.FunctionConfiguration {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := lambda.New(sess).GetFunctionConfiguration(&lambda.GetFunctionConfigurationInput{
+		FunctionName: awssdk.String(functionName),
+	})
+	require.NoError(t, err)
+
+	return output
+}
+
+// getEventBridgeRule describes an EventBridge rule on the default event bus
+func getEventBridgeRule(t *testing.T, awsRegion string, ruleName string) *eventbridge.DescribeRuleOutput {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := eventbridge.New(sess).DescribeRule(&eventbridge.DescribeRuleInput{
+		Name: awssdk.String(ruleName),
+	})
+	require.NoError(t, err)
+
+	return output
+}
+
+// getEventBridgeRuleTargetArns returns the target ARNs of an EventBridge rule on the default event bus
+func getEventBridgeRuleTargetArns(t *testing.T, awsRegion string, ruleName string) []string {
+	sess, err := aws.NewAuthenticatedSession(awsRegion)
+	require.NoError(t, err)
+
+	output, err := eventbridge.New(sess).ListTargetsByRule(&eventbridge.ListTargetsByRuleInput{
+		Rule: awssdk.String(ruleName),
+	})
+	require.NoError(t, err)
+
+	arns := []string{}
+	for _, target := range output.Targets {
+		arns = append(arns, awssdk.StringValue(target.Arn))
+	}
+
+	return arns
+}
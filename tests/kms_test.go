@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKmsModule(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Create the role the grant is issued to
+	roleOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "fixtures/kms-grantee-role",
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, roleOptions)
+	terraform.InitAndApply(t, roleOptions)
+
+	roleArn := terraform.Output(t, roleOptions, "role_arn")
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../terraform/modules/kms",
+
+		Vars: map[string]interface{}{
+			"project_name":            fmt.Sprintf("test-kms-%s", uniqueID),
+			"environment":             "staging",
+			"deletion_window_in_days": 7,
+			"grants": []map[string]interface{}{
+				{
+					"name":              "test-encrypt-decrypt",
+					"grantee_principal": roleArn,
+					"operations":        []string{"Encrypt", "Decrypt"},
+				},
+			},
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	keyID := terraform.Output(t, terraformOptions, "key_id")
+	assert.Equal(t, fmt.Sprintf("alias/test-kms-%s-staging", uniqueID), terraform.Output(t, terraformOptions, "alias_name"))
+
+	grantIDs := terraform.OutputMap(t, terraformOptions, "grant_ids")
+	grantID := grantIDs["test-encrypt-decrypt"]
+	require.NotEmpty(t, grantID)
+
+	// Verify the grant gives the role exactly the requested operations
+	grant := getKmsGrant(t, awsRegion, keyID, grantID)
+	assert.Equal(t, roleArn, awssdk.StringValue(grant.GranteePrincipal))
+	assert.ElementsMatch(t, []string{"Encrypt", "Decrypt"}, awssdk.StringValueSlice(grant.Operations))
+}
+
+func TestKmsModuleValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		vars          map[string]interface{}
+		errorContains string
+	}{
+		{
+			name: "invalid_grant_operation",
+			vars: map[string]interface{}{
+				"project_name": "test-kms",
+				"environment":  "staging",
+				"grants": []map[string]interface{}{
+					{
+						"name":              "test",
+						"grantee_principal": "arn:aws:iam::123456789012:role/test",
+						"operations":        []string{"Encrypt", "DeleteKey"},
+					},
+				},
+			},
+			errorContains: "Each grant must list at least one valid KMS grant operation",
+		},
+		{
+			name: "grantee_not_an_iam_principal",
+			vars: map[string]interface{}{
+				"project_name": "test-kms",
+				"environment":  "staging",
+				"grants": []map[string]interface{}{
+					{
+						"name":              "test",
+						"grantee_principal": "lambda.amazonaws.com",
+						"operations":        []string{"Decrypt"},
+					},
+				},
+			},
+			errorContains: "Grant grantee principals must be IAM principal ARNs",
+		},
+		{
+			name: "deletion_window_too_short",
+			vars: map[string]interface{}{
+				"project_name":            "test-kms",
+				"environment":             "staging",
+				"deletion_window_in_days": 3,
+			},
+			errorContains: "Deletion window must be between 7 and 30 days",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../terraform/modules/kms",
+				Vars:         tc.vars,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
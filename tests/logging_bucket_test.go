@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggingBucketModule(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	projectName := fmt.Sprintf("test-logs-%s", strings.ToLower(random.UniqueId()))
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../terraform/modules/logging-bucket",
+
+		Vars: map[string]interface{}{
+			"project_name":          projectName,
+			"environment":           "staging",
+			"force_destroy":         true,
+			"retention_days":        45,
+			"allow_cloudtrail_logs": true,
+			"allow_flow_logs":       true,
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	bucketName := terraform.Output(t, terraformOptions, "bucket_name")
+	assert.Equal(t, fmt.Sprintf("%s-staging-logs", projectName), bucketName)
+	assert.Equal(t, fmt.Sprintf("arn:aws:s3:::%s", bucketName), terraform.Output(t, terraformOptions, "bucket_arn"))
+
+	// Every public access setting is blocked
+	publicAccessBlock := getBucketPublicAccessBlock(t, awsRegion, bucketName)
+	require.NotNil(t, publicAccessBlock)
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.BlockPublicAcls))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.BlockPublicPolicy))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.IgnorePublicAcls))
+	assert.True(t, awssdk.BoolValue(publicAccessBlock.RestrictPublicBuckets))
+
+	// Logs expire at the configured age
+	assert.Equal(t, map[string]int64{"expire-logs": 45}, getBucketLifecycleExpirationDays(t, awsRegion, bucketName))
+
+	aws.AssertS3BucketVersioningExists(t, awsRegion, bucketName)
+	aws.AssertS3BucketPolicyExists(t, awsRegion, bucketName)
+}
+
+func TestLoggingBucketModuleValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		vars          map[string]interface{}
+		errorContains string
+	}{
+		{
+			name: "bucket_name_too_long",
+			vars: map[string]interface{}{
+				"project_name": "test-logs-with-a-long-project",
+				"environment":  "production",
+				"bucket_name":  "access-logs-with-a-long-suffix",
+			},
+			errorContains: "The full bucket name (<project_name>-<environment>-<bucket_name>) must be at most 63 characters",
+		},
+		{
+			name: "kms_key_with_elb_logs",
+			vars: map[string]interface{}{
+				"project_name":   "test-logs",
+				"environment":    "staging",
+				"kms_key_arn":    "arn:aws:kms:us-east-1:123456789012:key/12345678-1234-1234-1234-123456789012",
+				"allow_elb_logs": true,
+			},
+			errorContains: "ELB access logs require S3-managed encryption",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../terraform/modules/logging-bucket",
+				Vars:         tc.vars,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pinnedVersionConstraint matches a pessimistic constraint that fixes at least the
+// major version (e.g. "~> 5.0", "~> 6.14.0") or an exact version (e.g. "= 6.14.0")
+var pinnedVersionConstraint = regexp.MustCompile(`^(~>\s*\d+\.\d+(\.\d+)?|=?\s*\d+\.\d+\.\d+)$`)
+
+func TestModuleProviderVersionPinning(t *testing.T) {
+	t.Parallel()
+
+	modulesDir := "../terraform/modules"
+
+	entries, err := os.ReadDir(modulesDir)
+	require.NoError(t, err)
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		moduleName := entry.Name()
+		t.Run(moduleName, func(t *testing.T) {
+			t.Parallel()
+
+			providers := getRequiredProviders(t, filepath.Join(modulesDir, moduleName))
+
+			require.Contains(t, providers, "aws", "module %s must declare the aws provider in required_providers", moduleName)
+
+			for name, version := range providers {
+				assert.Regexp(t, pinnedVersionConstraint, version, "provider %s in module %s must be pinned to a major version", name, moduleName)
+			}
+		})
+	}
+}
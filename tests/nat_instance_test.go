@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/beyondepic/epic-infrastructure/tests/helpers"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNatInstanceModule(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Create networking without NAT Gateways so the NAT instance owns egress
+	prefix := fmt.Sprintf("test-nat-%s", uniqueID)
+	networkingOverrides := map[string]interface{}{
+		"public_subnet_count": 1,
+	}
+	networking := helpers.DeployNetworking(t, awsRegion, prefix, networkingOverrides)
+
+	natOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../terraform/modules/nat-instance",
+
+		Vars: map[string]interface{}{
+			"project_name":          prefix,
+			"environment":           "staging",
+			"vpc_id":                networking.VpcID,
+			"vpc_cidr":              networking.VpcCidrBlock,
+			"public_subnet_id":      networking.PublicSubnetIDs[0],
+			"enable_recovery_alarm": true,
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, natOptions)
+	terraform.InitAndApply(t, natOptions)
+
+	// Verify the NAT instance runs in a single-instance Auto Scaling Group
+	asgName := terraform.Output(t, natOptions, "autoscaling_group_name")
+	assert.NotEmpty(t, asgName)
+
+	capacity := aws.GetCapacityInfoForAsg(t, asgName, awsRegion)
+	assert.Equal(t, int64(1), capacity.MinCapacity)
+	assert.Equal(t, int64(1), capacity.MaxCapacity)
+	assert.Equal(t, int64(1), capacity.DesiredCapacity)
+
+	// The group's EC2 health check replaces an instance failing its system status check,
+	// in place of the EC2 recover action, so the recovery alarm watches the group and only notifies
+	assert.Equal(t, "EC2", getAsgHealthCheckType(t, awsRegion, asgName))
+
+	recoveryAlarmArn := terraform.Output(t, natOptions, "recovery_alarm_arn")
+	require.NotEmpty(t, recoveryAlarmArn)
+	assert.Equal(t, map[string]string{"AutoScalingGroupName": asgName}, getCloudWatchAlarmDimensions(t, awsRegion, recoveryAlarmArn))
+	assert.Empty(t, getCloudWatchAlarmActions(t, awsRegion, recoveryAlarmArn))
+
+	// Route the private subnets through the NAT interface. shared-networking owns the
+	// route, so applying it again leaves the route in place.
+	networkInterfaceID := terraform.Output(t, natOptions, "network_interface_id")
+	require.NotEmpty(t, networkInterfaceID)
+
+	networkingOverrides["nat_instance_network_interface_id"] = networkInterfaceID
+	routedOptions := helpers.NetworkingOptions(t, awsRegion, prefix, networkingOverrides)
+	terraform.Apply(t, routedOptions)
+	terraform.Apply(t, routedOptions)
+
+	for _, routeTableID := range networking.PrivateRouteTableIDs {
+		assert.Equal(t, networkInterfaceID, getDefaultRouteNetworkInterfaceID(t, awsRegion, routeTableID))
+	}
+}
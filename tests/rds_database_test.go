@@ -0,0 +1,235 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/beyondepic/epic-infrastructure/tests/helpers"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRdsDatabaseModuleReadReplica(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// The DB subnet group needs database subnets in two availability zones
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-rds-%s", uniqueID), map[string]interface{}{
+		"database_subnet_count": 2,
+	})
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../terraform/modules/rds-database",
+
+		Vars: map[string]interface{}{
+			"project_name":            fmt.Sprintf("test-rds-%s", uniqueID),
+			"environment":             "staging",
+			"db_subnet_group_name":    networking.DatabaseSubnetGroupName,
+			"vpc_security_group_ids":  []string{networking.DatabaseSecurityGroupID},
+			"backup_retention_period": 1,
+			"deletion_protection":     false,
+			"skip_final_snapshot":     true,
+			"create_read_replica":     true,
+			"replica_count":           1,
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	primaryIdentifier := terraform.Output(t, terraformOptions, "db_instance_identifier")
+
+	// Verify the replica endpoint is returned
+	replicaEndpoints := terraform.OutputList(t, terraformOptions, "read_replica_endpoints")
+	require.Len(t, replicaEndpoints, 1)
+	assert.NotEmpty(t, replicaEndpoints[0])
+
+	// Verify the replica replicates from the primary
+	replicaIdentifiers := terraform.OutputList(t, terraformOptions, "read_replica_identifiers")
+	require.Len(t, replicaIdentifiers, 1)
+
+	replica := getDBInstance(t, awsRegion, replicaIdentifiers[0])
+	assert.Equal(t, primaryIdentifier, awssdk.StringValue(replica.ReadReplicaSourceDBInstanceIdentifier))
+	assert.Equal(t, replicaEndpoints[0], fmt.Sprintf("%s:%d", awssdk.StringValue(replica.Endpoint.Address), awssdk.Int64Value(replica.Endpoint.Port)))
+
+	primary := getDBInstance(t, awsRegion, primaryIdentifier)
+	assert.Equal(t, []string{replicaIdentifiers[0]}, awssdk.StringValueSlice(primary.ReadReplicaDBInstanceIdentifiers))
+}
+
+func TestRdsDatabaseModuleCanary(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-rdscanary-%s", uniqueID), map[string]interface{}{
+		"database_subnet_count": 2,
+	})
+
+	// The database security group allows the application security group, so the canary uses it
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../terraform/modules/rds-database",
+
+		Vars: map[string]interface{}{
+			"project_name":              fmt.Sprintf("test-rdscanary-%s", uniqueID),
+			"environment":               "staging",
+			"db_subnet_group_name":      networking.DatabaseSubnetGroupName,
+			"vpc_security_group_ids":    []string{networking.DatabaseSecurityGroupID},
+			"deletion_protection":       false,
+			"skip_final_snapshot":       true,
+			"enable_canary":             true,
+			"canary_interval_minutes":   1,
+			"canary_security_group_ids": []string{networking.ApplicationSecurityGroupID},
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+	terraform.InitAndApply(t, terraformOptions)
+
+	primaryIdentifier := terraform.Output(t, terraformOptions, "db_instance_identifier")
+
+	// Verify the canary Lambda runs in the VPC with the canary security group
+	canaryArn := terraform.Output(t, terraformOptions, "canary_function_arn")
+	require.NotEmpty(t, canaryArn)
+
+	canary := getLambdaFunctionConfiguration(t, awsRegion, canaryArn)
+	require.NotNil(t, canary.VpcConfig)
+	assert.Equal(t, networking.VpcID, awssdk.StringValue(canary.VpcConfig.VpcId))
+	assert.Equal(t, []string{networking.ApplicationSecurityGroupID}, awssdk.StringValueSlice(canary.VpcConfig.SecurityGroupIds))
+
+	// Verify the schedule triggers the canary
+	ruleName := terraform.Output(t, terraformOptions, "canary_schedule_rule_name")
+	rule := getEventBridgeRule(t, awsRegion, ruleName)
+	assert.Equal(t, "rate(1 minute)", awssdk.StringValue(rule.ScheduleExpression))
+	assert.Equal(t, "ENABLED", awssdk.StringValue(rule.State))
+	assert.Equal(t, []string{canaryArn}, getEventBridgeRuleTargetArns(t, awsRegion, ruleName))
+
+	// Verify the reachability alarm watches the primary and treats missing data as unreachable
+	alarm := describeCloudWatchAlarm(t, awsRegion, terraform.Output(t, terraformOptions, "reachability_alarm_arn"))
+	assert.Equal(t, "DatabaseReachable", awssdk.StringValue(alarm.MetricName))
+	assert.Equal(t, "breaching", awssdk.StringValue(alarm.TreatMissingData))
+	assert.Equal(t, int64(60), awssdk.Int64Value(alarm.Period))
+	assert.Equal(t, map[string]string{"DBInstanceIdentifier": primaryIdentifier}, getCloudWatchAlarmDimensions(t, awsRegion, awssdk.StringValue(alarm.AlarmArn)))
+}
+
+func TestRdsDatabaseModuleValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		vars          map[string]interface{}
+		errorContains string
+	}{
+		{
+			name: "read_replica_without_backups",
+			vars: map[string]interface{}{
+				"project_name":            "test-rds",
+				"environment":             "staging",
+				"db_subnet_group_name":    "test-db-subnet-group",
+				"vpc_security_group_ids":  []string{"sg-123"},
+				"backup_retention_period": 0,
+				"create_read_replica":     true,
+			},
+			errorContains: "Read replicas require automated backups on the primary",
+		},
+		{
+			name: "replica_count_too_high",
+			vars: map[string]interface{}{
+				"project_name":           "test-rds",
+				"environment":            "staging",
+				"db_subnet_group_name":   "test-db-subnet-group",
+				"vpc_security_group_ids": []string{"sg-123"},
+				"create_read_replica":    true,
+				"replica_count":          16,
+			},
+			errorContains: "Replica count must be a whole number between 1 and 15",
+		},
+		{
+			name: "invalid_replica_instance_class",
+			vars: map[string]interface{}{
+				"project_name":           "test-rds",
+				"environment":            "staging",
+				"db_subnet_group_name":   "test-db-subnet-group",
+				"vpc_security_group_ids": []string{"sg-123"},
+				"create_read_replica":    true,
+				"replica_instance_class": "t3.micro",
+			},
+			errorContains: "Replica instance class must be an RDS instance class",
+		},
+		{
+			name: "canary_without_security_groups",
+			vars: map[string]interface{}{
+				"project_name":           "test-rds",
+				"environment":            "staging",
+				"db_subnet_group_name":   "test-db-subnet-group",
+				"vpc_security_group_ids": []string{"sg-123"},
+				"enable_canary":          true,
+			},
+			errorContains: "At least one canary security group ID must be provided",
+		},
+		{
+			name: "engine_version_mismatch",
+			vars: map[string]interface{}{
+				"project_name":           "test-rds",
+				"environment":            "staging",
+				"db_subnet_group_name":   "test-db-subnet-group",
+				"vpc_security_group_ids": []string{"sg-123"},
+				"engine":                 "mysql",
+				"engine_version":         "16",
+			},
+			errorContains: "Engine version must match the engine",
+		},
+		{
+			name: "postgres_with_mysql_engine_version",
+			vars: map[string]interface{}{
+				"project_name":           "test-rds",
+				"environment":            "staging",
+				"db_subnet_group_name":   "test-db-subnet-group",
+				"vpc_security_group_ids": []string{"sg-123"},
+				"engine_version":         "8.0",
+			},
+			errorContains: "Engine version must match the engine",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../terraform/modules/rds-database",
+				Vars:         tc.vars,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
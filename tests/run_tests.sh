@@ -103,7 +103,7 @@ echo -e "${BLUE}Starting integration tests...${NC}"
 echo ""
 
 # Test 1: Shared Networking Module
-if ! run_tests "TestSharedNetworkingModule" "Shared Networking Module Tests"; then
+if ! run_tests "TestSharedNetworking(Module|AcrossRegions)" "Shared Networking Module Tests"; then
     FAILED_TESTS+=("Shared Networking Module")
 fi
 
@@ -116,14 +116,84 @@ fi
 
 echo ""
 
-# Test 3: Validation Tests
+# Test 3: NAT Instance Module
+if ! run_tests "TestNatInstanceModule" "NAT Instance Module Tests"; then
+    FAILED_TESTS+=("NAT Instance Module")
+fi
+
+echo ""
+
+# Test 4: S3 Bucket Module
+if ! run_tests "TestS3BucketModule" "S3 Bucket Module Tests"; then
+    FAILED_TESTS+=("S3 Bucket Module")
+fi
+
+echo ""
+
+# Test 5: CDN Module
+if ! run_tests "TestCdnModule" "CDN Module Tests"; then
+    FAILED_TESTS+=("CDN Module")
+fi
+
+echo ""
+
+# Test 6: SSM Patching Module
+if ! run_tests "TestSsmPatchingModule" "SSM Patching Module Tests"; then
+    FAILED_TESTS+=("SSM Patching Module")
+fi
+
+echo ""
+
+# Test 7: KMS Module
+if ! run_tests "TestKmsModule" "KMS Module Tests"; then
+    FAILED_TESTS+=("KMS Module")
+fi
+
+echo ""
+
+# Test 8: Client VPN Module
+if ! run_tests "TestClientVpnModule" "Client VPN Module Tests"; then
+    FAILED_TESTS+=("Client VPN Module")
+fi
+
+echo ""
+
+# Test 9: Transit Gateway Module
+if ! run_tests "TestTransitGatewayModule" "Transit Gateway Module Tests"; then
+    FAILED_TESTS+=("Transit Gateway Module")
+fi
+
+echo ""
+
+# Test 10: RDS Database Module
+if ! run_tests "TestRdsDatabaseModule" "RDS Database Module Tests"; then
+    FAILED_TESTS+=("RDS Database Module")
+fi
+
+echo ""
+
+# Test 11: Full Stack Lifecycle
+if ! run_tests "TestFullStackLifecycle" "Full Stack Lifecycle Tests"; then
+    FAILED_TESTS+=("Full Stack Lifecycle")
+fi
+
+echo ""
+
+# Test 12: Cost Guardrail (skipped when infracost is not installed)
+if ! run_tests "CostGuardrail" "Cost Guardrail Tests"; then
+    FAILED_TESTS+=("Cost Guardrail")
+fi
+
+echo ""
+
+# Test 13: Validation Tests
 if ! run_tests ".*Validation.*" "Input Validation Tests"; then
     FAILED_TESTS+=("Input Validation")
 fi
 
 echo ""
 
-# Test 4: Security Tests
+# Test 14: Security Tests
 if ! run_tests ".*Security.*" "Security Feature Tests"; then
     FAILED_TESTS+=("Security Features")
 fi
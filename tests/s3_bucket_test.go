@@ -0,0 +1,225 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestS3BucketModuleWithReplication(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	replicaRegion := aws.GetRandomStableRegion(t, nil, []string{awsRegion})
+	projectName := fmt.Sprintf("test-s3-%s", strings.ToLower(random.UniqueId()))
+	sourceBucketName := fmt.Sprintf("%s-staging-source", projectName)
+
+	// Create the versioned destination bucket and replication role in another region
+	destinationOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "fixtures/s3-replication-destination",
+
+		Vars: map[string]interface{}{
+			"bucket_name":        fmt.Sprintf("%s-replica", projectName),
+			"source_bucket_name": sourceBucketName,
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": replicaRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, destinationOptions)
+	terraform.InitAndApply(t, destinationOptions)
+
+	destinationBucketArn := terraform.Output(t, destinationOptions, "bucket_arn")
+	replicationRoleArn := terraform.Output(t, destinationOptions, "role_arn")
+
+	bucketOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../terraform/modules/s3-bucket",
+
+		Vars: map[string]interface{}{
+			"project_name":                       projectName,
+			"environment":                        "staging",
+			"bucket_name":                        "source",
+			"force_destroy":                      true,
+			"enable_replication":                 true,
+			"replication_destination_bucket_arn": destinationBucketArn,
+			"replication_role_arn":               replicationRoleArn,
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, bucketOptions)
+	terraform.InitAndApply(t, bucketOptions)
+
+	bucketID := terraform.Output(t, bucketOptions, "bucket_id")
+	assert.Equal(t, sourceBucketName, bucketID)
+	assert.Equal(t, "true", terraform.Output(t, bucketOptions, "replication_enabled"))
+
+	// Verify the replication configuration targets the destination bucket
+	destinations := getBucketReplicationDestinations(t, awsRegion, bucketID)
+	assert.Equal(t, []string{destinationBucketArn}, destinations)
+}
+
+func TestS3BucketModuleEventNotifications(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	projectName := fmt.Sprintf("test-s3-%s", strings.ToLower(random.UniqueId()))
+	bucketName := fmt.Sprintf("%s-staging-uploads", projectName)
+
+	// Create the queue first so S3 can validate its policy when the notification is added
+	queueOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "fixtures/s3-notification-queue",
+
+		Vars: map[string]interface{}{
+			"queue_name":         fmt.Sprintf("%s-uploads", projectName),
+			"source_bucket_name": bucketName,
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, queueOptions)
+	terraform.InitAndApply(t, queueOptions)
+
+	queueArn := terraform.Output(t, queueOptions, "queue_arn")
+
+	bucketOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../terraform/modules/s3-bucket",
+
+		Vars: map[string]interface{}{
+			"project_name":  projectName,
+			"environment":   "staging",
+			"bucket_name":   "uploads",
+			"force_destroy": true,
+			"event_notifications": []map[string]interface{}{
+				{
+					"events":           []string{"s3:ObjectCreated:*"},
+					"destination_type": "sqs",
+					"destination_arn":  queueArn,
+					"filter_prefix":    "incoming/",
+				},
+			},
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, bucketOptions)
+	terraform.InitAndApply(t, bucketOptions)
+
+	bucketID := terraform.Output(t, bucketOptions, "bucket_id")
+	assert.Equal(t, bucketName, bucketID)
+	assert.Equal(t, []string{queueArn}, terraform.OutputList(t, bucketOptions, "event_notification_destination_arns"))
+
+	// Verify the notification configuration targets the queue for object creation
+	queues := getBucketNotificationQueueArns(t, awsRegion, bucketID)
+	assert.Equal(t, map[string][]string{queueArn: {"s3:ObjectCreated:*"}}, queues)
+}
+
+func TestS3BucketModuleValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		vars          map[string]interface{}
+		errorContains string
+	}{
+		{
+			name: "replication_without_versioning",
+			vars: map[string]interface{}{
+				"project_name":                       "test-s3",
+				"environment":                        "staging",
+				"bucket_name":                        "source",
+				"enable_versioning":                  false,
+				"enable_replication":                 true,
+				"replication_destination_bucket_arn": "arn:aws:s3:::test-s3-replica",
+				"replication_role_arn":               "arn:aws:iam::123456789012:role/replication",
+			},
+			errorContains: "Versioning must be enabled when replication is enabled",
+		},
+		{
+			name: "replication_without_destination",
+			vars: map[string]interface{}{
+				"project_name":         "test-s3",
+				"environment":          "staging",
+				"bucket_name":          "source",
+				"enable_replication":   true,
+				"replication_role_arn": "arn:aws:iam::123456789012:role/replication",
+			},
+			errorContains: "A valid destination bucket ARN",
+		},
+		{
+			name: "event_notification_arn_mismatch",
+			vars: map[string]interface{}{
+				"project_name": "test-s3",
+				"environment":  "staging",
+				"bucket_name":  "uploads",
+				"event_notifications": []map[string]interface{}{
+					{
+						"events":           []string{"s3:ObjectCreated:*"},
+						"destination_type": "sqs",
+						"destination_arn":  "arn:aws:sns:us-east-1:123456789012:uploads",
+					},
+				},
+			},
+			errorContains: "Event notification destination ARNs must match their destination type",
+		},
+		{
+			name: "event_notification_invalid_event",
+			vars: map[string]interface{}{
+				"project_name": "test-s3",
+				"environment":  "staging",
+				"bucket_name":  "uploads",
+				"event_notifications": []map[string]interface{}{
+					{
+						"events":           []string{"s3:ObjectCreated:Put:Extra"},
+						"destination_type": "sqs",
+						"destination_arn":  "arn:aws:sqs:us-east-1:123456789012:uploads",
+					},
+				},
+			},
+			errorContains: "Each event notification must list at least one S3 event",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../terraform/modules/s3-bucket",
+				Vars:         tc.vars,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScpModulePlan(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	// Plan only - applying SCPs requires Organizations permissions
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../terraform/modules/scp",
+		PlanFilePath: filepath.Join(t.TempDir(), "plan.out"),
+
+		Vars: map[string]interface{}{
+			"project_name": "test-scp",
+			"policies": []map[string]interface{}{
+				{
+					"name":    "deny-leave-organization",
+					"content": `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":"organizations:LeaveOrganization","Resource":"*"}]}`,
+				},
+				{
+					"name":    "deny-root-user",
+					"content": `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Action":"*","Resource":"*","Condition":{"StringLike":{"aws:PrincipalArn":"arn:aws:iam::*:root"}}}]}`,
+				},
+			},
+			"target_ou_ids": []string{"ou-ab12-cdef3456", "ou-ab12-ghij7890"},
+		},
+	}
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	policies := 0
+	attachments := 0
+	for address := range plan.ResourcePlannedValuesMap {
+		switch {
+		case strings.HasPrefix(address, "aws_organizations_policy.scp["):
+			policies++
+		case strings.HasPrefix(address, "aws_organizations_policy_attachment.scp["):
+			attachments++
+		}
+	}
+
+	// Every policy is attached to every OU
+	assert.Equal(t, 2, policies)
+	assert.Equal(t, 4, attachments)
+}
+
+func TestScpModuleValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		vars          map[string]interface{}
+		errorContains string
+	}{
+		{
+			name: "invalid_policy_json",
+			vars: map[string]interface{}{
+				"project_name": "test-scp",
+				"policies": []map[string]interface{}{
+					{"name": "broken", "content": "not-json"},
+				},
+			},
+			errorContains: "Policy content must be a valid JSON document",
+		},
+		{
+			name: "invalid_target_ou_id",
+			vars: map[string]interface{}{
+				"project_name": "test-scp",
+				"policies": []map[string]interface{}{
+					{"name": "deny-all", "content": `{"Version":"2012-10-17","Statement":[]}`},
+				},
+				"target_ou_ids": []string{"123456789012"},
+			},
+			errorContains: "Target IDs must be organizational unit IDs",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../terraform/modules/scp",
+				Vars:         tc.vars,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
@@ -1,14 +1,23 @@
 package tests
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/beyondepic/epic-infrastructure/tests/helpers"
 	"github.com/gruntwork-io/terratest/modules/aws"
 	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/retry"
+	"github.com/gruntwork-io/terratest/modules/ssh"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSharedNetworkingModule(t *testing.T) {
@@ -109,6 +118,75 @@ func TestSharedNetworkingModule(t *testing.T) {
 	// Verify Flow Logs are enabled
 	flowLogsEnabled := terraform.Output(t, terraformOptions, "vpc_flow_logs_enabled")
 	assert.Equal(t, "true", flowLogsEnabled)
+
+	// Verify database subnets are isolated by default
+	databaseRouteTableID := terraform.Output(t, terraformOptions, "database_route_table_id")
+	assert.Empty(t, getDefaultRouteNatGatewayID(t, awsRegion, databaseRouteTableID))
+
+	// Verify the routing summary reports each tier's egress posture
+	var routingSummary map[string]struct {
+		Egress              string   `json:"egress"`
+		IPv6Egress          string   `json:"ipv6_egress"`
+		TransitGatewayCIDRs []string `json:"transit_gateway_cidrs"`
+		RouteTableIDs       []string `json:"route_table_ids"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(terraform.OutputJson(t, terraformOptions, "routing_summary")), &routingSummary))
+	assert.Equal(t, "internet", routingSummary["public"].Egress)
+	assert.Equal(t, "nat_egress", routingSummary["private"].Egress)
+	assert.Equal(t, "isolated", routingSummary["database"].Egress)
+	assert.Equal(t, []string{databaseRouteTableID}, routingSummary["database"].RouteTableIDs)
+	for tier, summary := range routingSummary {
+		assert.Equal(t, "none", summary.IPv6Egress, tier)
+		assert.Empty(t, summary.TransitGatewayCIDRs, tier)
+	}
+
+	// Verify the deployment summary decodes and its nested fields are populated
+	var summary struct {
+		Networking struct {
+			VpcID            string   `json:"vpc_id"`
+			PublicSubnetIDs  []string `json:"public_subnet_ids"`
+			PrivateSubnetIDs []string `json:"private_subnet_ids"`
+		} `json:"networking"`
+		Security struct {
+			WebSecurityGroupID string `json:"web_security_group_id"`
+		} `json:"security"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(terraform.OutputJson(t, terraformOptions, "deployment_summary")), &summary))
+	assert.Equal(t, vpcID, summary.Networking.VpcID)
+	assert.ElementsMatch(t, publicSubnetIDs, summary.Networking.PublicSubnetIDs)
+	assert.ElementsMatch(t, privateSubnetIDs, summary.Networking.PrivateSubnetIDs)
+	assert.Equal(t, webSGID, summary.Security.WebSecurityGroupID)
+}
+
+func TestSharedNetworkingModuleDatabaseEgress(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-dbegress-%s", uniqueID), map[string]interface{}{
+		"public_subnet_count":             1,
+		"database_subnet_count":           2,
+		"enable_nat_gateway":              true,
+		"nat_gateway_count":               1,
+		"database_subnet_internet_egress": true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Verify the database route table sends egress through the NAT Gateway
+	natGatewayIDs := terraform.OutputList(t, terraformOptions, "nat_gateway_ids")
+	databaseRouteTableID := terraform.Output(t, terraformOptions, "database_route_table_id")
+
+	assert.Len(t, natGatewayIDs, 1)
+	assert.Equal(t, natGatewayIDs[0], getDefaultRouteNatGatewayID(t, awsRegion, databaseRouteTableID))
 }
 
 func TestSharedNetworkingModuleMinimal(t *testing.T) {
@@ -162,6 +240,837 @@ func TestSharedNetworkingModuleMinimal(t *testing.T) {
 	assert.Empty(t, dbSubnetGroupName)
 }
 
+func TestSharedNetworkingModuleNetworkACLs(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-nacl-%s", uniqueID), map[string]interface{}{
+		"public_subnet_count":   1,
+		"database_subnet_count": 2,
+		"enable_network_acls":   true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Verify a NACL is created per tier
+	networkACLIDs := terraform.OutputMap(t, terraformOptions, "network_acl_ids")
+	assert.NotEmpty(t, networkACLIDs["public"])
+	assert.NotEmpty(t, networkACLIDs["private"])
+	assert.NotEmpty(t, networkACLIDs["database"])
+
+	// Verify the database NACL is associated with all database subnets
+	databaseSubnetIDs := terraform.OutputList(t, terraformOptions, "database_subnet_ids")
+	assert.ElementsMatch(t, databaseSubnetIDs, getNetworkACLSubnetIDs(t, awsRegion, networkACLIDs["database"]))
+}
+
+func TestSharedNetworkingModuleFlowLogDestinations(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	testCases := []struct {
+		name            string
+		destinationType string
+		arnPrefix       string
+	}{
+		{"CloudWatchLogs", "cloud-watch-logs", "arn:aws:logs:"},
+		{"S3", "s3", "arn:aws:s3:::"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+			uniqueID := random.UniqueId()
+
+			terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-flow-%s", strings.ToLower(uniqueID)), map[string]interface{}{
+				"public_subnet_count":            1,
+				"enable_flow_logs":               true,
+				"flow_logs_destination_type":     tc.destinationType,
+				"flow_logs_traffic_type":         "REJECT",
+				"flow_logs_bucket_force_destroy": true,
+			})
+
+			defer terraform.Destroy(t, terraformOptions)
+
+			terraform.InitAndApply(t, terraformOptions)
+
+			destinationArn := terraform.Output(t, terraformOptions, "vpc_flow_log_destination_arn")
+			assert.True(t, strings.HasPrefix(destinationArn, tc.arnPrefix), "unexpected destination ARN %s", destinationArn)
+
+			// Verify the flow log delivers to the requested destination type
+			vpcID := terraform.Output(t, terraformOptions, "vpc_id")
+			assert.Equal(t, []string{tc.destinationType}, getVpcFlowLogDestinationTypes(t, awsRegion, vpcID))
+
+			// The default log format extends the AWS default fields with TCP flags and packet addresses
+			logFormats := getVpcFlowLogFormats(t, awsRegion, vpcID)
+			require.Len(t, logFormats, 1)
+			for _, field := range []string{"${srcaddr}", "${tcp-flags}", "${pkt-srcaddr}", "${pkt-dstaddr}"} {
+				assert.Contains(t, logFormats[0], field)
+			}
+		})
+	}
+}
+
+func TestSharedNetworkingModuleDhcpOptions(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	testCases := []struct {
+		name        string
+		dhcpOptions map[string]interface{}
+	}{
+		{
+			name: "Custom",
+			dhcpOptions: map[string]interface{}{
+				"domain_name":         "corp.example.internal",
+				"domain_name_servers": []string{"10.0.0.2", "AmazonProvidedDNS"},
+			},
+		},
+		{
+			name:        "Default",
+			dhcpOptions: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+			uniqueID := random.UniqueId()
+
+			overrides := map[string]interface{}{
+				"public_subnet_count": 1,
+			}
+			if tc.dhcpOptions != nil {
+				overrides["dhcp_options"] = tc.dhcpOptions
+			}
+
+			terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-dhcp-%s", uniqueID), overrides)
+
+			defer terraform.Destroy(t, terraformOptions)
+
+			terraform.InitAndApply(t, terraformOptions)
+
+			// dhcp_options_id is null, and so left out of the outputs, without a custom set
+			outputs := terraform.OutputAll(t, terraformOptions)
+			associatedID := getVpcDhcpOptionsID(t, awsRegion, outputs["vpc_id"].(string))
+			require.NotEqual(t, "default", associatedID, "the VPC should have a DHCP options set")
+			configuration := getDhcpOptionsConfiguration(t, awsRegion, associatedID)
+
+			// The module only associates its own DHCP options set when one is supplied;
+			// otherwise the VPC keeps the region's default set with Amazon-provided DNS
+			if tc.dhcpOptions == nil {
+				assert.NotContains(t, outputs, "dhcp_options_id")
+
+				defaultDomainName := fmt.Sprintf("%s.compute.internal", awsRegion)
+				if awsRegion == "us-east-1" {
+					defaultDomainName = "ec2.internal"
+				}
+				assert.Equal(t, []string{defaultDomainName}, configuration["domain-name"])
+				assert.Equal(t, []string{"AmazonProvidedDNS"}, configuration["domain-name-servers"])
+				return
+			}
+
+			dhcpOptionsID, _ := outputs["dhcp_options_id"].(string)
+			assert.NotEmpty(t, dhcpOptionsID)
+			assert.Equal(t, dhcpOptionsID, associatedID)
+			assert.Equal(t, []string{"corp.example.internal"}, configuration["domain-name"])
+		})
+	}
+}
+
+func TestSharedNetworkingModuleSubnetIPAlarm(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-ipalarm-%s", uniqueID), map[string]interface{}{
+		"public_subnet_count":       1,
+		"private_subnet_count":      2,
+		"database_subnet_count":     1,
+		"enable_subnet_ip_alarm":    true,
+		"subnet_ip_alarm_threshold": 10,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	// Verify the metric publishing Lambda is created
+	lambdaArn := terraform.Output(t, terraformOptions, "subnet_ip_monitor_lambda_arn")
+	assert.NotEmpty(t, lambdaArn)
+
+	// Verify an alarm exists for every subnet
+	alarmArns := terraform.OutputMap(t, terraformOptions, "subnet_ip_alarm_arns")
+	assert.Len(t, alarmArns, 4)
+	for _, key := range []string{"public-1", "private-1", "private-2", "database-1"} {
+		assert.NotEmpty(t, alarmArns[key], "alarm for subnet %s should exist", key)
+	}
+}
+
+func TestSharedNetworkingModuleBastion(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	// The runner connects from outside the VPC, so SSH has to pass the public
+	// subnet's NACL as well as the bastion security group
+	testCases := []struct {
+		name              string
+		enableNetworkACLs bool
+	}{
+		{name: "main_network_acl", enableNetworkACLs: false},
+		{name: "tier_network_acls", enableNetworkACLs: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+			uniqueID := random.UniqueId()
+			keyPairName, privateKey := createTemporaryKeyPair(t, awsRegion)
+			allowedCIDR := getRunnerPublicCIDR(t)
+
+			terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-bastion-%s", uniqueID), map[string]interface{}{
+				"public_subnet_count":   1,
+				"enable_network_acls":   tc.enableNetworkACLs,
+				"enable_bastion":        true,
+				"bastion_allowed_cidrs": []string{allowedCIDR},
+				"bastion_key_pair_name": keyPairName,
+			})
+
+			defer terraform.Destroy(t, terraformOptions)
+
+			terraform.InitAndApply(t, terraformOptions)
+
+			instanceID := terraform.Output(t, terraformOptions, "bastion_instance_id")
+			publicIP := terraform.Output(t, terraformOptions, "bastion_public_ip")
+			securityGroupID := terraform.Output(t, terraformOptions, "bastion_security_group_id")
+			assert.NotEmpty(t, instanceID)
+			assert.NotEmpty(t, publicIP)
+
+			// The only ingress rule is SSH from the allowed range
+			permissions := getSecurityGroupIngressPermissions(t, awsRegion, securityGroupID)
+			require.Len(t, permissions, 1)
+			assert.Equal(t, "tcp", awssdk.StringValue(permissions[0].IpProtocol))
+			assert.Equal(t, int64(22), awssdk.Int64Value(permissions[0].FromPort))
+			assert.Equal(t, int64(22), awssdk.Int64Value(permissions[0].ToPort))
+			require.Len(t, permissions[0].IpRanges, 1)
+			assert.Equal(t, allowedCIDR, awssdk.StringValue(permissions[0].IpRanges[0].CidrIp))
+
+			// The public subnet's NACL allows SSH from the allowed range
+			networkACLID := terraform.Output(t, terraformOptions, "network_acl_id")
+			if tc.enableNetworkACLs {
+				networkACLID = terraform.OutputMap(t, terraformOptions, "network_acl_ids")["public"]
+			}
+			assert.Contains(t, getNetworkACLIngressAllowedCIDRs(t, awsRegion, networkACLID, 22), allowedCIDR)
+
+			// Auto-recovery is on by default and recovers this instance in place
+			recoveryAlarmArn := terraform.Output(t, terraformOptions, "bastion_recovery_alarm_arn")
+			require.NotEmpty(t, recoveryAlarmArn)
+			assert.Equal(t, []string{fmt.Sprintf("arn:aws:automate:%s:ec2:recover", awsRegion)}, getCloudWatchAlarmActions(t, awsRegion, recoveryAlarmArn))
+			assert.Equal(t, map[string]string{"InstanceId": instanceID}, getCloudWatchAlarmDimensions(t, awsRegion, recoveryAlarmArn))
+
+			// SSH is reachable from the allowed range (the test runner)
+			host := ssh.Host{
+				Hostname:    publicIP,
+				SshUserName: "ec2-user",
+				SshKeyPair:  &ssh.KeyPair{PrivateKey: privateKey},
+			}
+			retry.DoWithRetry(t, "SSH to bastion", 30, 10*time.Second, func() (string, error) {
+				return "", ssh.CheckSshConnectionE(t, host)
+			})
+		})
+	}
+}
+
+func TestSharedNetworkingModuleTransitGateway(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	transitGatewayID := "tgw-0123456789abcdef0"
+
+	// Plan only - the transit gateway does not need to exist to verify the attachment and routes.
+	// Six private subnets is more than most regions have AZs, so some AZs hold two of them.
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, "test-tgw", map[string]interface{}{
+		"private_subnet_count":   6,
+		"enable_nat_gateway":     true,
+		"nat_gateway_count":      2,
+		"transit_gateway_id":     transitGatewayID,
+		"transit_gateway_routes": []string{"172.16.0.0/12", "192.168.0.0/16"},
+		"appliance_mode_support": true,
+	})
+	terraformOptions.PlanFilePath = filepath.Join(t.TempDir(), "plan.out")
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	attachment, ok := plan.ResourcePlannedValuesMap["aws_ec2_transit_gateway_vpc_attachment.main[0]"]
+	require.True(t, ok, "transit gateway attachment should be planned")
+	assert.Equal(t, transitGatewayID, attachment.AttributeValues["transit_gateway_id"])
+	assert.Equal(t, "enable", attachment.AttributeValues["appliance_mode_support"])
+
+	// The subnet IDs are unknown until apply, but the attachment takes exactly one per AZ
+	expectedSubnets := len(aws.GetAvailabilityZones(t, awsRegion))
+	if expectedSubnets > 6 {
+		expectedSubnets = 6
+	}
+	attachmentChange, ok := plan.ResourceChangesMap["aws_ec2_transit_gateway_vpc_attachment.main[0]"]
+	require.True(t, ok, "transit gateway attachment change should be planned")
+	subnetIDs := attachmentChange.Change.AfterUnknown.(map[string]interface{})["subnet_ids"].([]interface{})
+	assert.Len(t, subnetIDs, expectedSubnets)
+
+	// Every private route table, one per NAT Gateway, sends the listed CIDRs to the transit gateway
+	for i := 0; i < 2; i++ {
+		address := fmt.Sprintf("aws_route_table.private[%d]", i)
+		routeTable, ok := plan.ResourcePlannedValuesMap[address]
+		require.True(t, ok, "%s should be planned", address)
+
+		tgwRoutes := map[string]string{}
+		for _, route := range routeTable.AttributeValues["route"].([]interface{}) {
+			route := route.(map[string]interface{})
+			if tgwID, _ := route["transit_gateway_id"].(string); tgwID != "" {
+				tgwRoutes[route["cidr_block"].(string)] = tgwID
+			}
+		}
+		assert.Equal(t, map[string]string{
+			"172.16.0.0/12":  transitGatewayID,
+			"192.168.0.0/16": transitGatewayID,
+		}, tgwRoutes, address)
+	}
+}
+
+func TestSharedNetworkingModuleApplicationEgressRules(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+
+	// Plan only - the egress rules are visible on the planned security group
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, "test-egress", map[string]interface{}{
+		"public_subnet_count": 1,
+		"application_egress_rules": []map[string]interface{}{
+			{"description": "HTTPS", "from_port": 443, "to_port": 443, "cidr_blocks": []string{"0.0.0.0/0"}},
+		},
+	})
+	terraformOptions.PlanFilePath = filepath.Join(t.TempDir(), "plan.out")
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	securityGroup, ok := plan.ResourcePlannedValuesMap["aws_security_group.application"]
+	require.True(t, ok, "application security group should be planned")
+
+	// Only the 443 rule remains; the allow-all rule is replaced
+	egressRules := securityGroup.AttributeValues["egress"].([]interface{})
+	require.Len(t, egressRules, 1)
+
+	egress := egressRules[0].(map[string]interface{})
+	assert.Equal(t, "tcp", egress["protocol"])
+	assert.Equal(t, float64(443), egress["from_port"])
+	assert.Equal(t, float64(443), egress["to_port"])
+	assert.Equal(t, []interface{}{"0.0.0.0/0"}, egress["cidr_blocks"])
+}
+
+func TestSharedNetworkingAcrossRegions(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	// Defaults are enabled in every account, and us-west-1 offers only two AZs to most of them.
+	// Opt-in regions such as ap-southeast-4 can be tested with TEST_REGIONS=region1,region2.
+	regions := getTestRegions([]string{"us-east-1", "us-west-1", "ap-southeast-2"})
+	requestedSubnets := 3
+
+	for _, awsRegion := range regions {
+		awsRegion := awsRegion
+		t.Run(awsRegion, func(t *testing.T) {
+			t.Parallel()
+
+			availableZones := len(aws.GetAvailabilityZones(t, awsRegion))
+			expectedZones := requestedSubnets
+			if availableZones < expectedZones {
+				t.Logf("%s has %d AZs; expecting %d subnets to share them", awsRegion, availableZones, requestedSubnets)
+				expectedZones = availableZones
+			}
+
+			networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-region-%s", strings.ToLower(random.UniqueId())), map[string]interface{}{
+				"public_subnet_count":  requestedSubnets,
+				"private_subnet_count": requestedSubnets,
+			})
+
+			require.Len(t, networking.PublicSubnetIDs, requestedSubnets)
+			require.Len(t, networking.PrivateSubnetIDs, requestedSubnets)
+
+			// Each tier spans as many distinct AZs as the region allows
+			subnetZones := map[string]string{}
+			for _, subnet := range aws.GetSubnetsForVpc(t, networking.VpcID, awsRegion) {
+				subnetZones[subnet.Id] = subnet.AvailabilityZone
+			}
+
+			for tier, subnetIDs := range map[string][]string{
+				"public":  networking.PublicSubnetIDs,
+				"private": networking.PrivateSubnetIDs,
+			} {
+				zones := map[string]bool{}
+				for _, subnetID := range subnetIDs {
+					zones[subnetZones[subnetID]] = true
+				}
+				assert.Len(t, zones, expectedZones, "%s subnets in %s should span %d AZs", tier, awsRegion, expectedZones)
+			}
+		})
+	}
+}
+
+func TestSharedNetworkingModuleSubnetSizing(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Public and private ranges are explicit; the database tier is auto-calculated as /26
+	// subnets after the four /24s the other tiers hold in the automatic layout
+	expectedCidrs := map[string][]string{
+		"public":   {"10.0.200.0/24", "10.0.201.0/24"},
+		"private":  {"10.0.64.0/19", "10.0.96.0/19"},
+		"database": {"10.0.4.0/26", "10.0.4.64/26"},
+	}
+
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-cidrs-%s", strings.ToLower(uniqueID)), map[string]interface{}{
+		"vpc_cidr":                "10.0.0.0/16",
+		"public_subnet_count":     2,
+		"private_subnet_count":    2,
+		"database_subnet_count":   2,
+		"public_subnet_cidrs":     expectedCidrs["public"],
+		"private_subnet_cidrs":    expectedCidrs["private"],
+		"database_subnet_newbits": 10,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	var subnetCidrs map[string][]string
+	require.NoError(t, json.Unmarshal([]byte(terraform.OutputJson(t, terraformOptions, "subnet_cidr_blocks")), &subnetCidrs))
+	assert.Equal(t, expectedCidrs, subnetCidrs)
+
+	// Each subnet gets exactly the range for its position in the tier
+	for tier, cidrs := range expectedCidrs {
+		subnetIDs := terraform.OutputList(t, terraformOptions, fmt.Sprintf("%s_subnet_ids", tier))
+		require.Len(t, subnetIDs, len(cidrs))
+
+		for i, subnetID := range subnetIDs {
+			subnet := getSubnet(t, awsRegion, subnetID)
+			assert.Equal(t, cidrs[i], awssdk.StringValue(subnet.CidrBlock), "%s subnet %d should use its configured range", tier, i+1)
+		}
+	}
+}
+
+func TestSharedNetworkingModuleNatGatewayEipAllocations(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	allocationIDs := []string{"eipalloc-0123456789abcdef0", "eipalloc-0fedcba9876543210"}
+
+	// Plan only - the NAT Gateways reference the supplied allocations instead of new Elastic IPs
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, "test-eip", map[string]interface{}{
+		"private_subnet_count":           2,
+		"enable_nat_gateway":             true,
+		"nat_gateway_count":              2,
+		"nat_gateway_eip_allocation_ids": allocationIDs,
+	})
+	terraformOptions.PlanFilePath = filepath.Join(t.TempDir(), "plan.out")
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	for address := range plan.ResourcePlannedValuesMap {
+		assert.False(t, strings.HasPrefix(address, "aws_eip."), "No Elastic IP should be created, found %s", address)
+	}
+
+	for i, allocationID := range allocationIDs {
+		natGateway, ok := plan.ResourcePlannedValuesMap[fmt.Sprintf("aws_nat_gateway.main[%d]", i)]
+		require.True(t, ok, "NAT Gateway %d should be planned", i)
+		assert.Equal(t, allocationID, natGateway.AttributeValues["allocation_id"])
+	}
+}
+
+func TestSharedNetworkingModuleSecretsAccessEndpoints(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Only the Secrets Manager and KMS endpoints, without the general endpoint set
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-secrets-%s", strings.ToLower(uniqueID)), map[string]interface{}{
+		"private_subnet_count":            2,
+		"enable_secrets_access_endpoints": true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	vpcID := terraform.Output(t, terraformOptions, "vpc_id")
+	securityGroupID := terraform.Output(t, terraformOptions, "vpc_endpoints_security_group_id")
+	assert.NotEmpty(t, securityGroupID)
+
+	for service, output := range map[string]string{
+		"secretsmanager": "secretsmanager_vpc_endpoint_id",
+		"kms":            "kms_vpc_endpoint_id",
+	} {
+		endpointID := terraform.Output(t, terraformOptions, output)
+		require.NotEmpty(t, endpointID, "%s endpoint should be created", service)
+
+		endpoint := getVpcEndpoint(t, awsRegion, endpointID)
+		assert.Equal(t, "available", awssdk.StringValue(endpoint.State))
+		assert.Equal(t, vpcID, awssdk.StringValue(endpoint.VpcId))
+		assert.True(t, strings.HasSuffix(awssdk.StringValue(endpoint.ServiceName), "."+service))
+		assert.True(t, awssdk.BoolValue(endpoint.PrivateDnsEnabled), "%s endpoint should use private DNS", service)
+		assert.Len(t, endpoint.SubnetIds, 2)
+	}
+}
+
+func TestSharedNetworkingModuleIPAddressTypes(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	// The NAT Gateway is only needed to exercise the NAT64 route of IPv6-only private subnets
+	testCases := []struct {
+		ipAddressType    string
+		enableNatGateway bool
+	}{
+		{ipAddressType: "ipv4", enableNatGateway: false},
+		{ipAddressType: "dualstack", enableNatGateway: false},
+		{ipAddressType: "ipv6", enableNatGateway: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.ipAddressType, func(t *testing.T) {
+			t.Parallel()
+
+			awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+			uniqueID := strings.ToLower(random.UniqueId())
+
+			terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-%s-%s", tc.ipAddressType, uniqueID), map[string]interface{}{
+				"ip_address_type":    tc.ipAddressType,
+				"enable_nat_gateway": tc.enableNatGateway,
+				"nat_gateway_count":  1,
+			})
+
+			defer terraform.Destroy(t, terraformOptions)
+
+			terraform.InitAndApply(t, terraformOptions)
+
+			outputs := terraform.OutputAll(t, terraformOptions)
+			vpcID := outputs["vpc_id"].(string)
+			internetGatewayID := outputs["internet_gateway_id"].(string)
+			vpcIpv6CidrBlock, _ := outputs["vpc_ipv6_cidr_block"].(string)
+			egressOnlyGatewayID, _ := outputs["egress_only_internet_gateway_id"].(string)
+
+			ipv6Enabled := tc.ipAddressType != "ipv4"
+			ipv6OnlyPrivate := tc.ipAddressType == "ipv6"
+
+			// CIDRs and gateways
+			if ipv6Enabled {
+				assert.True(t, strings.HasSuffix(vpcIpv6CidrBlock, "/56"), "VPC should have an Amazon-provided /56")
+				assert.Equal(t, []string{vpcIpv6CidrBlock}, getVpcIpv6CidrBlocks(t, awsRegion, vpcID))
+				assert.True(t, strings.HasPrefix(egressOnlyGatewayID, "eigw-"), "egress-only Internet Gateway should be created")
+			} else {
+				assert.Empty(t, vpcIpv6CidrBlock)
+				assert.Empty(t, getVpcIpv6CidrBlocks(t, awsRegion, vpcID))
+				assert.Empty(t, egressOnlyGatewayID)
+			}
+
+			// Public subnets are always dualstack or IPv4; private subnets lose IPv4 only in ipv6 mode
+			for _, subnetID := range terraform.OutputList(t, terraformOptions, "public_subnet_ids") {
+				subnet := getSubnet(t, awsRegion, subnetID)
+				assert.NotEmpty(t, awssdk.StringValue(subnet.CidrBlock))
+				if ipv6Enabled {
+					assert.Len(t, getSubnetIpv6CidrBlocks(subnet), 1)
+					assert.True(t, awssdk.BoolValue(subnet.AssignIpv6AddressOnCreation))
+				} else {
+					assert.Empty(t, getSubnetIpv6CidrBlocks(subnet))
+				}
+			}
+
+			for _, subnetID := range terraform.OutputList(t, terraformOptions, "private_subnet_ids") {
+				subnet := getSubnet(t, awsRegion, subnetID)
+				assert.Equal(t, ipv6OnlyPrivate, awssdk.BoolValue(subnet.Ipv6Native))
+				assert.Equal(t, ipv6OnlyPrivate, awssdk.BoolValue(subnet.EnableDns64))
+				if ipv6OnlyPrivate {
+					assert.Empty(t, awssdk.StringValue(subnet.CidrBlock), "IPv6-only subnets should have no IPv4 CIDR")
+				} else {
+					assert.NotEmpty(t, awssdk.StringValue(subnet.CidrBlock))
+				}
+				if ipv6Enabled {
+					assert.Len(t, getSubnetIpv6CidrBlocks(subnet), 1)
+				} else {
+					assert.Empty(t, getSubnetIpv6CidrBlocks(subnet))
+				}
+			}
+
+			// Routes: IPv4 and IPv6 default routes via the Internet Gateway for the public tier,
+			// IPv6 through the egress-only gateway and NAT64 through the NAT Gateway for the private tier
+			publicRoutes := getRouteTableRoutes(t, awsRegion, outputs["public_route_table_id"].(string))
+			require.NotNil(t, findRoute(publicRoutes, "0.0.0.0/0"))
+			assert.Equal(t, internetGatewayID, awssdk.StringValue(findRoute(publicRoutes, "0.0.0.0/0").GatewayId))
+
+			publicIpv6Route := findRoute(publicRoutes, "::/0")
+			if ipv6Enabled {
+				require.NotNil(t, publicIpv6Route, "public route table should have an IPv6 default route")
+				assert.Equal(t, internetGatewayID, awssdk.StringValue(publicIpv6Route.GatewayId))
+			} else {
+				assert.Nil(t, publicIpv6Route)
+			}
+
+			for _, routeTableID := range terraform.OutputList(t, terraformOptions, "private_route_table_ids") {
+				privateRoutes := getRouteTableRoutes(t, awsRegion, routeTableID)
+
+				privateIpv6Route := findRoute(privateRoutes, "::/0")
+				if ipv6Enabled {
+					require.NotNil(t, privateIpv6Route, "private route table should have an IPv6 default route")
+					assert.Equal(t, egressOnlyGatewayID, awssdk.StringValue(privateIpv6Route.EgressOnlyInternetGatewayId))
+				} else {
+					assert.Nil(t, privateIpv6Route)
+				}
+
+				nat64Route := findRoute(privateRoutes, "64:ff9b::/96")
+				if ipv6OnlyPrivate {
+					require.NotNil(t, nat64Route, "IPv6-only private subnets should route NAT64 through the NAT Gateway")
+					assert.True(t, strings.HasPrefix(awssdk.StringValue(nat64Route.NatGatewayId), "nat-"))
+				} else {
+					assert.Nil(t, nat64Route)
+				}
+			}
+
+			// The routing summary reports the same IPv6 egress
+			expectedIpv6Egress := map[string]string{"public": "none", "private": "none"}
+			if ipv6Enabled {
+				expectedIpv6Egress = map[string]string{"public": "internet", "private": "egress_only"}
+			}
+			routingSummary := outputs["routing_summary"].(map[string]interface{})
+			for tier, expected := range expectedIpv6Egress {
+				assert.Equal(t, expected, routingSummary[tier].(map[string]interface{})["ipv6_egress"], tier)
+			}
+		})
+	}
+}
+
+func TestSharedNetworkingModulePinnedAvailabilityZones(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Pin the last two zones so the result differs from the default spread over the first zones
+	zones := aws.GetAvailabilityZones(t, awsRegion)
+	if len(zones) < 3 {
+		t.Skipf("Skipping test: %s has fewer than three availability zones", awsRegion)
+	}
+	pinnedZones := zones[len(zones)-2:]
+
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-azs-%s", strings.ToLower(uniqueID)), map[string]interface{}{
+		"availability_zones":   pinnedZones,
+		"public_subnet_count":  2,
+		"private_subnet_count": 2,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	assert.Equal(t, pinnedZones, terraform.OutputList(t, terraformOptions, "availability_zones"))
+
+	// Subnets are placed in the pinned zones, in order
+	for _, tier := range []string{"public", "private"} {
+		subnetIDs := terraform.OutputList(t, terraformOptions, fmt.Sprintf("%s_subnet_ids", tier))
+		require.Len(t, subnetIDs, 2)
+
+		for i, subnetID := range subnetIDs {
+			subnet := getSubnet(t, awsRegion, subnetID)
+			assert.Equal(t, pinnedZones[i], awssdk.StringValue(subnet.AvailabilityZone), "%s subnet %d should be in the pinned zone", tier, i+1)
+		}
+	}
+
+	var subnetZones map[string][]string
+	require.NoError(t, json.Unmarshal([]byte(terraform.OutputJson(t, terraformOptions, "subnet_availability_zones")), &subnetZones))
+	assert.Equal(t, pinnedZones, subnetZones["public"])
+	assert.Equal(t, pinnedZones, subnetZones["private"])
+	assert.Empty(t, subnetZones["database"])
+}
+
+func TestSharedNetworkingModuleEncryptedFlowLogs(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-flowkms-%s", strings.ToLower(uniqueID)), map[string]interface{}{
+		"public_subnet_count": 1,
+		"enable_flow_logs":    true,
+		"flow_logs_encrypt":   true,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	kmsKeyArn := terraform.Output(t, terraformOptions, "vpc_flow_logs_kms_key_arn")
+	require.NotEmpty(t, kmsKeyArn)
+	assert.NotEmpty(t, terraform.Output(t, terraformOptions, "vpc_flow_log_group_arn"))
+
+	// The log group itself reports the module-created key
+	logGroupName := terraform.Output(t, terraformOptions, "vpc_flow_log_group_name")
+	logGroupKmsKeyID := getLogGroupKmsKeyID(t, awsRegion, logGroupName)
+	assert.NotEmpty(t, logGroupKmsKeyID)
+	assert.Equal(t, kmsKeyArn, logGroupKmsKeyID)
+}
+
+func TestSharedNetworkingModuleSubnetFlowLogs(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-subnetfl-%s", strings.ToLower(uniqueID)), map[string]interface{}{
+		"enable_flow_logs": true,
+		"subnet_flow_logs": []string{"public"},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	subnetFlowLogIDs := terraform.OutputMap(t, terraformOptions, "subnet_flow_log_ids")
+
+	// Every public subnet has its own flow log alongside the VPC-level one
+	publicSubnetIDs := terraform.OutputList(t, terraformOptions, "public_subnet_ids")
+	require.Len(t, publicSubnetIDs, 2)
+	for _, subnetID := range publicSubnetIDs {
+		flowLogIDs := getSubnetFlowLogIDs(t, awsRegion, subnetID)
+		require.Len(t, flowLogIDs, 1, "public subnet %s should have a flow log", subnetID)
+		assert.Equal(t, subnetFlowLogIDs[subnetID], flowLogIDs[0])
+	}
+
+	// Untargeted tiers only have the VPC flow log
+	for _, subnetID := range terraform.OutputList(t, terraformOptions, "private_subnet_ids") {
+		assert.Empty(t, getSubnetFlowLogIDs(t, awsRegion, subnetID))
+	}
+	assert.Len(t, getVpcFlowLogDestinationTypes(t, awsRegion, terraform.Output(t, terraformOptions, "vpc_id")), 1)
+}
+
+func TestSharedNetworkingModuleSSMOutputExport(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := strings.ToLower(random.UniqueId())
+	ssmPrefix := fmt.Sprintf("/test-ssm-%s/networking", uniqueID)
+
+	terraformOptions := helpers.NetworkingOptions(t, awsRegion, fmt.Sprintf("test-ssm-%s", uniqueID), map[string]interface{}{
+		"public_subnet_count":   1,
+		"private_subnet_count":  2,
+		"export_outputs_to_ssm": true,
+		"ssm_parameter_prefix":  ssmPrefix,
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	vpcID := terraform.Output(t, terraformOptions, "vpc_id")
+	assert.Equal(t, vpcID, aws.GetParameter(t, awsRegion, ssmPrefix+"/vpc_id"))
+
+	// Subnet lists are comma-separated; the empty database tier is not exported
+	privateSubnetIDs := terraform.OutputList(t, terraformOptions, "private_subnet_ids")
+	assert.Equal(t, strings.Join(privateSubnetIDs, ","), aws.GetParameter(t, awsRegion, ssmPrefix+"/private_subnet_ids"))
+
+	parameterNames := terraform.OutputMap(t, terraformOptions, "ssm_parameter_names")
+	assert.Equal(t, ssmPrefix+"/vpc_id", parameterNames["vpc_id"])
+	assert.NotContains(t, parameterNames, "database_subnet_ids")
+}
+
 func TestSharedNetworkingModuleValidation(t *testing.T) {
 	t.Parallel()
 
@@ -200,6 +1109,196 @@ func TestSharedNetworkingModuleValidation(t *testing.T) {
 			expectError:   true,
 			errorContains: "Public subnet count must be between 1 and 6",
 		},
+		{
+			name: "database_egress_without_nat_gateway",
+			vars: map[string]interface{}{
+				"project_name":                    "test-epic",
+				"environment":                     "staging",
+				"enable_nat_gateway":              false,
+				"database_subnet_internet_egress": true,
+			},
+			expectError:   true,
+			errorContains: "Database subnet internet egress requires enable_nat_gateway to be true",
+		},
+		{
+			name: "duplicate_network_acl_rule_number",
+			vars: map[string]interface{}{
+				"project_name":        "test-epic",
+				"environment":         "staging",
+				"enable_network_acls": true,
+				"network_acl_rules": map[string]interface{}{
+					"database": map[string]interface{}{
+						"ingress": []map[string]interface{}{
+							{"rule_number": 100, "cidr_block": "10.0.0.0/16"},
+							{"rule_number": 100, "action": "deny", "cidr_block": "0.0.0.0/0"},
+						},
+						"egress": []map[string]interface{}{
+							{"rule_number": 100, "cidr_block": "10.0.0.0/16"},
+						},
+					},
+				},
+			},
+			expectError:   true,
+			errorContains: "Network ACL rule numbers must be unique within each tier and direction",
+		},
+		{
+			name: "invalid_flow_logs_retention_days",
+			vars: map[string]interface{}{
+				"project_name":             "test-epic",
+				"environment":              "staging",
+				"flow_logs_retention_days": 10,
+			},
+			expectError:   true,
+			errorContains: "Flow logs retention days must be a valid CloudWatch Logs retention period",
+		},
+		{
+			name: "invalid_flow_logs_destination_type",
+			vars: map[string]interface{}{
+				"project_name":               "test-epic",
+				"environment":                "staging",
+				"flow_logs_destination_type": "kinesis",
+			},
+			expectError:   true,
+			errorContains: "Flow logs destination type must be one of: cloud-watch-logs, s3",
+		},
+		{
+			name: "invalid_flow_logs_traffic_type",
+			vars: map[string]interface{}{
+				"project_name":           "test-epic",
+				"environment":            "staging",
+				"flow_logs_traffic_type": "DROPPED",
+			},
+			expectError:   true,
+			errorContains: "Flow logs traffic type must be one of: ACCEPT, REJECT, ALL",
+		},
+		{
+			name: "bastion_without_allowed_cidrs",
+			vars: map[string]interface{}{
+				"project_name":   "test-epic",
+				"environment":    "staging",
+				"enable_bastion": true,
+			},
+			expectError:   true,
+			errorContains: "At least one bastion allowed CIDR must be provided when the bastion is enabled",
+		},
+		{
+			name: "transit_gateway_route_overlaps_vpc_cidr",
+			vars: map[string]interface{}{
+				"project_name":           "test-epic",
+				"environment":            "staging",
+				"vpc_cidr":               "10.0.0.0/16",
+				"transit_gateway_id":     "tgw-0123456789abcdef0",
+				"transit_gateway_routes": []string{"10.0.0.0/8"},
+			},
+			expectError:   true,
+			errorContains: "Transit gateway routes must not overlap the VPC CIDR",
+		},
+		{
+			name: "application_egress_rule_without_destination",
+			vars: map[string]interface{}{
+				"project_name": "test-epic",
+				"environment":  "staging",
+				"application_egress_rules": []map[string]interface{}{
+					{"from_port": 443, "to_port": 443},
+				},
+			},
+			expectError:   true,
+			errorContains: "Each application egress rule must define at least one CIDR block or security group ID",
+		},
+		{
+			name: "subnet_newbits_below_slash_28",
+			vars: map[string]interface{}{
+				"project_name":          "test-epic",
+				"environment":           "staging",
+				"public_subnet_newbits": 13,
+			},
+			expectError:   true,
+			errorContains: "Public subnet newbits must be at least 1 and keep the subnet prefix length at /28 or shorter",
+		},
+		{
+			name: "private_subnet_cidrs_count_mismatch",
+			vars: map[string]interface{}{
+				"project_name":         "test-epic",
+				"environment":          "staging",
+				"private_subnet_cidrs": []string{"10.0.64.0/19"},
+			},
+			expectError:   true,
+			errorContains: "Private subnet CIDRs must list exactly private_subnet_count blocks",
+		},
+		{
+			name: "public_subnet_cidr_outside_vpc",
+			vars: map[string]interface{}{
+				"project_name":        "test-epic",
+				"environment":         "staging",
+				"public_subnet_cidrs": []string{"10.0.200.0/24", "10.0.201.0/24", "10.1.0.0/24"},
+			},
+			expectError:   true,
+			errorContains: "Public subnet CIDRs must be network addresses of /28 or larger blocks within the VPC CIDR",
+		},
+		{
+			name: "invalid_flow_logs_log_format",
+			vars: map[string]interface{}{
+				"project_name":         "test-epic",
+				"environment":          "staging",
+				"flow_logs_log_format": "srcaddr dstaddr",
+			},
+			expectError:   true,
+			errorContains: "Flow logs log format must be a space-separated list of ${field} names",
+		},
+		{
+			name: "nat_gateway_eip_allocation_ids_count_mismatch",
+			vars: map[string]interface{}{
+				"project_name":                   "test-epic",
+				"environment":                    "staging",
+				"nat_gateway_count":              2,
+				"nat_gateway_eip_allocation_ids": []string{"eipalloc-0123456789abcdef0"},
+			},
+			expectError:   true,
+			errorContains: "The number of NAT Gateway EIP allocation IDs must equal nat_gateway_count",
+		},
+		{
+			name: "ipv6_only_private_subnets_with_vpc_endpoints",
+			vars: map[string]interface{}{
+				"project_name":    "test-epic",
+				"environment":     "staging",
+				"ip_address_type": "ipv6",
+			},
+			expectError:   true,
+			errorContains: "IPv6-only private subnets cannot host VPC interface endpoints or transit gateway attachments",
+		},
+		{
+			name: "more_subnets_than_pinned_availability_zones",
+			vars: map[string]interface{}{
+				"project_name":        "test-epic",
+				"environment":         "staging",
+				"availability_zones":  []string{"us-east-1a", "us-east-1b"},
+				"public_subnet_count": 3,
+			},
+			expectError:   true,
+			errorContains: "Each subnet tier must request no more subnets than there are pinned availability zones",
+		},
+		{
+			name: "flow_logs_kms_key_with_s3_destination",
+			vars: map[string]interface{}{
+				"project_name":               "test-epic",
+				"environment":                "staging",
+				"flow_logs_destination_type": "s3",
+				"flow_logs_kms_key_id":       "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			},
+			expectError:   true,
+			errorContains: "Flow logs KMS key ID requires the cloud-watch-logs flow logs destination type",
+		},
+		{
+			name: "flow_logs_encrypt_with_s3_destination",
+			vars: map[string]interface{}{
+				"project_name":               "test-epic",
+				"environment":                "staging",
+				"flow_logs_destination_type": "s3",
+				"flow_logs_encrypt":          true,
+			},
+			expectError:   true,
+			errorContains: "Flow logs encryption requires the cloud-watch-logs flow logs destination type",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSsmPatchingModule(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+	schedule := "cron(30 4 ? * SAT *)"
+
+	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "../terraform/modules/ssm-patching",
+
+		Vars: map[string]interface{}{
+			"project_name":                fmt.Sprintf("test-patch-%s", uniqueID),
+			"environment":                 "staging",
+			"maintenance_window_schedule": schedule,
+			"maintenance_window_duration": 4,
+			"maintenance_window_cutoff":   1,
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+
+	defer terraform.Destroy(t, terraformOptions)
+
+	terraform.InitAndApply(t, terraformOptions)
+
+	baselineID := terraform.Output(t, terraformOptions, "patch_baseline_id")
+	windowID := terraform.Output(t, terraformOptions, "maintenance_window_id")
+	patchGroup := terraform.Output(t, terraformOptions, "patch_group")
+	assert.Equal(t, fmt.Sprintf("test-patch-%s-staging", uniqueID), patchGroup)
+
+	// Verify the baseline is registered for the patch group
+	baseline := getPatchBaseline(t, awsRegion, baselineID)
+	assert.Equal(t, "AMAZON_LINUX_2", awssdk.StringValue(baseline.OperatingSystem))
+	assert.Equal(t, []string{patchGroup}, awssdk.StringValueSlice(baseline.PatchGroups))
+
+	// Verify the maintenance window uses the configured schedule
+	window := getMaintenanceWindow(t, awsRegion, windowID)
+	assert.Equal(t, schedule, awssdk.StringValue(window.Schedule))
+	assert.Equal(t, int64(4), awssdk.Int64Value(window.Duration))
+	assert.Equal(t, int64(1), awssdk.Int64Value(window.Cutoff))
+	assert.True(t, awssdk.BoolValue(window.Enabled))
+}
+
+func TestSsmPatchingModuleWindowsSeverity(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+
+	// Plan only - the patch filters are visible on the planned baseline
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../terraform/modules/ssm-patching",
+		PlanFilePath: filepath.Join(t.TempDir(), "plan.out"),
+
+		Vars: map[string]interface{}{
+			"project_name":     "test-patch-win",
+			"environment":      "staging",
+			"operating_system": "WINDOWS",
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	}
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	baseline, ok := plan.ResourcePlannedValuesMap["aws_ssm_patch_baseline.main"]
+	require.True(t, ok, "patch baseline should be planned")
+	approvalRules := baseline.AttributeValues["approval_rule"].([]interface{})
+	require.Len(t, approvalRules, 1)
+
+	filterValues := map[string][]interface{}{}
+	for _, filter := range approvalRules[0].(map[string]interface{})["patch_filter"].([]interface{}) {
+		filter := filter.(map[string]interface{})
+		filterValues[filter["key"].(string)] = filter["values"].([]interface{})
+	}
+	assert.Len(t, filterValues, 2)
+	assert.Contains(t, filterValues, "MSRC_SEVERITY")
+
+	// Windows uses its own update classifications by default
+	assert.ElementsMatch(t, []interface{}{"SecurityUpdates", "CriticalUpdates"}, filterValues["CLASSIFICATION"])
+}
+
+func TestSsmPatchingModuleValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		vars          map[string]interface{}
+		errorContains string
+	}{
+		{
+			name: "invalid_schedule",
+			vars: map[string]interface{}{
+				"project_name":                "test-patch",
+				"environment":                 "staging",
+				"maintenance_window_schedule": "every sunday",
+			},
+			errorContains: "Maintenance window schedule must be a cron() or rate() expression",
+		},
+		{
+			name: "cutoff_not_less_than_duration",
+			vars: map[string]interface{}{
+				"project_name":                "test-patch",
+				"environment":                 "staging",
+				"maintenance_window_duration": 2,
+				"maintenance_window_cutoff":   2,
+			},
+			errorContains: "Maintenance window cutoff must be at least 0 and less than the window duration",
+		},
+		{
+			name: "classification_invalid_for_os",
+			vars: map[string]interface{}{
+				"project_name":          "test-patch",
+				"environment":           "staging",
+				"operating_system":      "WINDOWS",
+				"patch_classifications": []string{"Security", "Bugfix"},
+			},
+			errorContains: "Patch classifications must be valid for the operating system",
+		},
+		{
+			name: "unsupported_operating_system",
+			vars: map[string]interface{}{
+				"project_name":     "test-patch",
+				"environment":      "staging",
+				"operating_system": "UBUNTU",
+			},
+			errorContains: "Operating system must be one of: AMAZON_LINUX_2, AMAZON_LINUX_2023, WINDOWS",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../terraform/modules/ssm-patching",
+				Vars:         tc.vars,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
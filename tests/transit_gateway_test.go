@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/aws"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitGatewayModule(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+
+	// Plan only - transit gateways take several minutes to create and delete
+	terraformOptions := &terraform.Options{
+		TerraformDir: "../terraform/modules/transit-gateway",
+		PlanFilePath: filepath.Join(t.TempDir(), "plan.out"),
+
+		Vars: map[string]interface{}{
+			"project_name":                    "test-tgw",
+			"environment":                     "shared",
+			"amazon_side_asn":                 64600,
+			"default_route_table_association": false,
+			"default_route_table_propagation": false,
+			"route_tables":                    []string{"shared", "workloads"},
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	}
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, terraformOptions)
+
+	transitGateway, ok := plan.ResourcePlannedValuesMap["aws_ec2_transit_gateway.main"]
+	require.True(t, ok, "Transit gateway should be planned")
+	assert.EqualValues(t, 64600, transitGateway.AttributeValues["amazon_side_asn"])
+	assert.Equal(t, "disable", transitGateway.AttributeValues["default_route_table_association"])
+	assert.Equal(t, "disable", transitGateway.AttributeValues["default_route_table_propagation"])
+
+	// One route table per name, tagged with its name
+	for _, name := range []string{"shared", "workloads"} {
+		routeTable, ok := plan.ResourcePlannedValuesMap[fmt.Sprintf(`aws_ec2_transit_gateway_route_table.main["%s"]`, name)]
+		require.True(t, ok, "Route table %s should be planned", name)
+		tags := routeTable.AttributeValues["tags"].(map[string]interface{})
+		assert.Equal(t, fmt.Sprintf("test-tgw-shared-tgw-%s-rt", name), tags["Name"])
+	}
+}
+
+func TestTransitGatewayModuleValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		vars          map[string]interface{}
+		errorContains string
+	}{
+		{
+			name: "public_amazon_side_asn",
+			vars: map[string]interface{}{
+				"project_name":    "test-tgw",
+				"environment":     "shared",
+				"amazon_side_asn": 16509,
+			},
+			errorContains: "Amazon side ASN must be a private ASN",
+		},
+		{
+			name: "duplicate_route_table_names",
+			vars: map[string]interface{}{
+				"project_name": "test-tgw",
+				"environment":  "shared",
+				"route_tables": []string{"shared", "shared"},
+			},
+			errorContains: "Route table names must be unique",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: "../terraform/modules/transit-gateway",
+				Vars:         tc.vars,
+			}
+
+			_, err := terraform.InitAndPlanE(t, terraformOptions)
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorContains)
+		})
+	}
+}
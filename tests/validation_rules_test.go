@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// validationRulesTimeout bounds how long `terraform test` may take for one module's
+// validation rules. The runs plan against a mocked AWS provider in parallel, so anything
+// slower means a run is reaching real infrastructure.
+const validationRulesTimeout = 10 * time.Second
+
+// TestValidationRules runs each module's tests/validation.tftest.hcl, which covers every
+// custom variable validation against a mocked AWS provider. It needs no AWS credentials;
+// set TF_PLUGIN_CACHE_DIR to keep provider downloads out of repeated runs.
+func TestValidationRules(t *testing.T) {
+	t.Parallel()
+
+	for _, module := range []string{"shared-networking", "web-application"} {
+		module := module
+		t.Run(module, func(t *testing.T) {
+			t.Parallel()
+
+			terraformOptions := &terraform.Options{
+				TerraformDir: filepath.Join("../terraform/modules", module),
+				NoColor:      true,
+			}
+
+			terraform.Init(t, terraformOptions)
+			terraform.Validate(t, terraformOptions)
+
+			start := time.Now()
+			terraform.RunTerraformCommand(t, terraformOptions, "test", "-filter=tests/validation.tftest.hcl")
+			elapsed := time.Since(start)
+
+			t.Logf("Validation rules for %s ran in %s", module, elapsed)
+			assert.Less(t, elapsed, validationRulesTimeout, "validation rules for %s should run in under %s", module, validationRulesTimeout)
+		})
+	}
+}
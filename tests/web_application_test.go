@@ -1,14 +1,22 @@
 package tests
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/beyondepic/epic-infrastructure/tests/helpers"
 	"github.com/gruntwork-io/terratest/modules/aws"
 	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWebApplicationModule(t *testing.T) {
@@ -26,84 +34,55 @@ func TestWebApplicationModule(t *testing.T) {
 	uniqueID := random.UniqueId()
 
 	// First, we need to create the networking infrastructure
-	networkingOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: "../terraform/modules/shared-networking",
-
-		Vars: map[string]interface{}{
-			"project_name":            fmt.Sprintf("test-web-%s", uniqueID),
-			"environment":            "test",
-			"vpc_cidr":               "10.0.0.0/16",
-			"public_subnet_count":    2,
-			"private_subnet_count":   2,
-			"database_subnet_count":  0,
-			"enable_nat_gateway":     true,
-			"nat_gateway_count":      1,
-			"enable_flow_logs":       false,
-			"enable_vpc_endpoints":   false,
-		},
-
-		EnvVars: map[string]string{
-			"AWS_DEFAULT_REGION": awsRegion,
-		},
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-web-%s", uniqueID), map[string]interface{}{
+		"vpc_cidr":             "10.0.0.0/16",
+		"private_subnet_count": 2,
+		"enable_nat_gateway":   true,
+		"nat_gateway_count":    1,
 	})
 
-	// Deploy networking first
-	defer terraform.Destroy(t, networkingOptions)
-	terraform.InitAndApply(t, networkingOptions)
-
-	// Get outputs from networking module
-	vpcID := terraform.Output(t, networkingOptions, "vpc_id")
-	publicSubnetIDs := terraform.OutputList(t, networkingOptions, "public_subnet_ids")
-	privateSubnetIDs := terraform.OutputList(t, networkingOptions, "private_subnet_ids")
-	webSGID := terraform.Output(t, networkingOptions, "web_security_group_id")
-	appSGID := terraform.Output(t, networkingOptions, "application_security_group_id")
-
 	// Now test the web application module
-	webAppOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: "../terraform/modules/web-application",
-
-		Vars: map[string]interface{}{
-			"project_name":            fmt.Sprintf("test-web-%s", uniqueID),
-			"environment":            "test",
-			"application_name":       "test-app",
-			"vpc_id":                 vpcID,
-			"subnet_ids":             privateSubnetIDs,
-			"public_subnet_ids":      publicSubnetIDs,
-			"security_group_id":      appSGID,
-			"alb_security_group_id":  webSGID,
-			"instance_profile_name":  "test-instance-profile",
-			"instance_type":          "t3.micro",
-			"min_size":              1,
-			"max_size":              3,
-			"desired_capacity":      2,
-			"enable_waf":            true,
-			"waf_rate_limit":        1000,
-			"enable_geo_blocking":   false,
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-web-%s", uniqueID), networking, map[string]interface{}{
+		"instance_type":       "t3.micro",
+		"min_size":            1,
+		"max_size":            3,
+		"desired_capacity":    2,
+		"enable_waf":          true,
+		"waf_rate_limit":      1000,
+		"enable_geo_blocking": false,
+		"managed_rule_groups": []map[string]interface{}{
+			{"name": "AWSManagedRulesCommonRuleSet", "priority": 1},
+			{"name": "AWSManagedRulesKnownBadInputsRuleSet", "priority": 2},
+			{"name": "AWSManagedRulesSQLiRuleSet", "priority": 5},
 		},
-
-		EnvVars: map[string]string{
-			"AWS_DEFAULT_REGION": awsRegion,
+		"data_volumes": []map[string]interface{}{
+			{"device_name": "/dev/xvdb", "size": 10},
 		},
+		"create_alarm_topic": true,
 	})
 
-	defer terraform.Destroy(t, webAppOptions)
-	terraform.InitAndApply(t, webAppOptions)
-
 	// Test Auto Scaling Group
-	asgName := terraform.Output(t, webAppOptions, "autoscaling_group_name")
-	asgID := terraform.Output(t, webAppOptions, "autoscaling_group_id")
+	asgName := terraform.Output(t, webApp.Options, "autoscaling_group_name")
+	asgID := terraform.Output(t, webApp.Options, "autoscaling_group_id")
 
 	assert.NotEmpty(t, asgName)
 	assert.NotEmpty(t, asgID)
 
-	// Verify ASG exists
-	// Note: GetAsgByName is deprecated in newer versions of Terratest
-	// Just verify the ASG name is returned
-	assert.NotEmpty(t, asgName)
+	// Verify the ASG spans every private subnet for high availability
+	asgSubnetIDs := getAsgSubnetIDs(t, awsRegion, asgName)
+	for _, subnetID := range networking.PrivateSubnetIDs {
+		assert.Contains(t, asgSubnetIDs, subnetID)
+	}
+
+	// The instance outputs snapshot the group at apply time, once desired capacity is reached
+	instanceIDs := terraform.OutputList(t, webApp.Options, "autoscaling_group_instance_ids")
+	privateIPs := terraform.OutputList(t, webApp.Options, "autoscaling_group_private_ips")
+	assert.Len(t, instanceIDs, 2)
+	assert.Len(t, privateIPs, len(instanceIDs))
 
 	// Test Load Balancer
-	albDNS := terraform.Output(t, webAppOptions, "load_balancer_dns_name")
-	albArn := terraform.Output(t, webAppOptions, "load_balancer_arn")
+	albDNS := terraform.Output(t, webApp.Options, "load_balancer_dns_name")
+	albArn := terraform.Output(t, webApp.Options, "load_balancer_arn")
 
 	assert.NotEmpty(t, albDNS)
 	assert.NotEmpty(t, albArn)
@@ -113,30 +92,110 @@ func TestWebApplicationModule(t *testing.T) {
 	assert.NotEmpty(t, albArn)
 
 	// Test Target Group
-	targetGroupArn := terraform.Output(t, webAppOptions, "target_group_arn")
+	targetGroupArn := terraform.Output(t, webApp.Options, "target_group_arn")
 	assert.NotEmpty(t, targetGroupArn)
 
+	// The full name is the TargetGroup dimension used by RequestCountPerTarget
+	targetGroupFullName := terraform.Output(t, webApp.Options, "target_group_full_name")
+	assert.Regexp(t, `^targetgroup/[a-zA-Z0-9-]+/[0-9a-f]+$`, targetGroupFullName)
+	assert.True(t, strings.HasSuffix(targetGroupArn, targetGroupFullName))
+
 	// Test Launch Template
-	launchTemplateID := terraform.Output(t, webAppOptions, "launch_template_id")
+	launchTemplateID := terraform.Output(t, webApp.Options, "launch_template_id")
 	assert.NotEmpty(t, launchTemplateID)
 
+	// Verify the instance profile is wired into the launch template
+	assert.Equal(t, "test-instance-profile", getLaunchTemplateInstanceProfileName(t, awsRegion, launchTemplateID))
+
+	// Test data volumes
+	dataVolumeDeviceNames := terraform.OutputList(t, webApp.Options, "data_volume_device_names")
+	assert.Equal(t, []string{"/dev/xvdb"}, dataVolumeDeviceNames)
+
 	// Test WAF Web ACL
-	wafWebACLArn := terraform.Output(t, webAppOptions, "waf_web_acl_arn")
-	wafWebACLName := terraform.Output(t, webAppOptions, "waf_web_acl_name")
+	wafWebACLArn := terraform.Output(t, webApp.Options, "waf_web_acl_arn")
+	wafWebACLName := terraform.Output(t, webApp.Options, "waf_web_acl_name")
 
 	assert.NotEmpty(t, wafWebACLArn)
 	assert.NotEmpty(t, wafWebACLName)
 
+	// Verify the managed rule groups appear in the Web ACL
+	managedRuleGroupNames := terraform.OutputList(t, webApp.Options, "waf_managed_rule_group_names")
+	assert.ElementsMatch(t, []string{
+		"AWSManagedRulesCommonRuleSet",
+		"AWSManagedRulesKnownBadInputsRuleSet",
+		"AWSManagedRulesSQLiRuleSet",
+	}, managedRuleGroupNames)
+
+	wafWebACLID := terraform.Output(t, webApp.Options, "waf_web_acl_id")
+	webACL := getRegionalWebACL(t, awsRegion, wafWebACLID, wafWebACLName)
+	ruleNames := getWebACLRuleNames(webACL)
+	for _, groupName := range managedRuleGroupNames {
+		assert.Contains(t, ruleNames, groupName)
+	}
+	assert.Contains(t, ruleNames, "RateLimitRule")
+
+	// The default managed rule groups keep their original metric names, which share
+	// the project and environment prefix of the rate limiting rule's metric
+	metricNames := map[string]string{}
+	for _, rule := range webACL.Rules {
+		metricNames[awssdk.StringValue(rule.Name)] = awssdk.StringValue(rule.VisibilityConfig.MetricName)
+	}
+	metricPrefix := strings.TrimSuffix(metricNames["RateLimitRule"], "RateLimitMetric")
+	assert.Equal(t, metricPrefix+"CommonRuleSetMetric", metricNames["AWSManagedRulesCommonRuleSet"])
+	assert.Equal(t, metricPrefix+"KnownBadInputsMetric", metricNames["AWSManagedRulesKnownBadInputsRuleSet"])
+	assert.Equal(t, metricPrefix+"AWSManagedRulesSQLiRuleSetMetric", metricNames["AWSManagedRulesSQLiRuleSet"])
+
+	// Verify the Web ACL is REGIONAL scoped and actually attached to the ALB
+	assert.Contains(t, wafWebACLArn, ":regional/webacl/")
+	assert.Contains(t, getWebACLResourceArns(t, awsRegion, wafWebACLArn), albArn)
+
 	// Test CloudWatch Alarms
-	cpuHighAlarmArn := terraform.Output(t, webAppOptions, "cpu_high_alarm_arn")
-	cpuLowAlarmArn := terraform.Output(t, webAppOptions, "cpu_low_alarm_arn")
+	cpuHighAlarmArn := terraform.Output(t, webApp.Options, "cpu_high_alarm_arn")
+	cpuLowAlarmArn := terraform.Output(t, webApp.Options, "cpu_low_alarm_arn")
 
 	assert.NotEmpty(t, cpuHighAlarmArn)
 	assert.NotEmpty(t, cpuLowAlarmArn)
 
+	// Verify the common tags reach the CloudWatch alarms
+	cpuHighAlarmTags := getCloudWatchAlarmTags(t, awsRegion, cpuHighAlarmArn)
+	assert.Contains(t, cpuHighAlarmTags, "Environment")
+	assert.Equal(t, "web-application", cpuHighAlarmTags["Module"])
+
+	// Verify the CPU high alarm notifies the alarm topic alongside the scale-up policy
+	alarmTopicArn := terraform.Output(t, webApp.Options, "alarm_sns_topic_arn")
+	assert.NotEmpty(t, alarmTopicArn)
+	cpuHighAlarmActions := getCloudWatchAlarmActions(t, awsRegion, cpuHighAlarmArn)
+	assert.NotEmpty(t, cpuHighAlarmActions)
+	assert.Contains(t, cpuHighAlarmActions, alarmTopicArn)
+
+	// Verify the deployment summary decodes and its nested fields are populated
+	var summary struct {
+		Networking struct {
+			LoadBalancerArn     string `json:"load_balancer_arn"`
+			LoadBalancerDNSName string `json:"load_balancer_dns_name"`
+			TargetGroupArn      string `json:"target_group_arn"`
+		} `json:"networking"`
+		Compute struct {
+			AutoscalingGroupName string `json:"autoscaling_group_name"`
+			LaunchTemplateID     string `json:"launch_template_id"`
+		} `json:"compute"`
+		Security struct {
+			AlbSecurityGroupID string `json:"alb_security_group_id"`
+			WafWebACLArn       string `json:"waf_web_acl_arn"`
+		} `json:"security"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(terraform.OutputJson(t, webApp.Options, "deployment_summary")), &summary))
+	assert.Equal(t, albArn, summary.Networking.LoadBalancerArn)
+	assert.Equal(t, albDNS, summary.Networking.LoadBalancerDNSName)
+	assert.Equal(t, targetGroupArn, summary.Networking.TargetGroupArn)
+	assert.Equal(t, asgName, summary.Compute.AutoscalingGroupName)
+	assert.Equal(t, launchTemplateID, summary.Compute.LaunchTemplateID)
+	assert.Equal(t, networking.WebSecurityGroupID, summary.Security.AlbSecurityGroupID)
+	assert.Equal(t, wafWebACLArn, summary.Security.WafWebACLArn)
+
 	// Test Auto Scaling Policies
-	scaleUpPolicyArn := terraform.Output(t, webAppOptions, "scale_up_policy_arn")
-	scaleDownPolicyArn := terraform.Output(t, webAppOptions, "scale_down_policy_arn")
+	scaleUpPolicyArn := terraform.Output(t, webApp.Options, "scale_up_policy_arn")
+	scaleDownPolicyArn := terraform.Output(t, webApp.Options, "scale_down_policy_arn")
 
 	assert.NotEmpty(t, scaleUpPolicyArn)
 	assert.NotEmpty(t, scaleDownPolicyArn)
@@ -154,66 +213,23 @@ func TestWebApplicationModuleWithoutWAF(t *testing.T) {
 	uniqueID := random.UniqueId()
 
 	// Create minimal networking setup
-	networkingOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: "../terraform/modules/shared-networking",
-
-		Vars: map[string]interface{}{
-			"project_name":            fmt.Sprintf("test-nowaf-%s", uniqueID),
-			"environment":            "test",
-			"public_subnet_count":    1,
-			"private_subnet_count":   1,
-			"database_subnet_count":  0,
-			"enable_nat_gateway":     false,
-			"enable_flow_logs":       false,
-			"enable_vpc_endpoints":   false,
-		},
-
-		EnvVars: map[string]string{
-			"AWS_DEFAULT_REGION": awsRegion,
-		},
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-nowaf-%s", uniqueID), map[string]interface{}{
+		"public_subnet_count": 1,
 	})
 
-	defer terraform.Destroy(t, networkingOptions)
-	terraform.InitAndApply(t, networkingOptions)
-
-	vpcID := terraform.Output(t, networkingOptions, "vpc_id")
-	publicSubnetIDs := terraform.OutputList(t, networkingOptions, "public_subnet_ids")
-	privateSubnetIDs := terraform.OutputList(t, networkingOptions, "private_subnet_ids")
-	webSGID := terraform.Output(t, networkingOptions, "web_security_group_id")
-	appSGID := terraform.Output(t, networkingOptions, "application_security_group_id")
-
 	// Test web application without WAF
-	webAppOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: "../terraform/modules/web-application",
-
-		Vars: map[string]interface{}{
-			"project_name":            fmt.Sprintf("test-nowaf-%s", uniqueID),
-			"environment":            "test",
-			"application_name":       "test-app-nowaf",
-			"vpc_id":                 vpcID,
-			"subnet_ids":             privateSubnetIDs,
-			"public_subnet_ids":      publicSubnetIDs,
-			"security_group_id":      appSGID,
-			"alb_security_group_id":  webSGID,
-			"instance_profile_name":  "test-instance-profile",
-			"enable_waf":            false,
-		},
-
-		EnvVars: map[string]string{
-			"AWS_DEFAULT_REGION": awsRegion,
-		},
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-nowaf-%s", uniqueID), networking, map[string]interface{}{
+		"application_name": "test-app-nowaf",
+		"enable_waf":       false,
 	})
 
-	defer terraform.Destroy(t, webAppOptions)
-	terraform.InitAndApply(t, webAppOptions)
-
 	// Verify WAF is not created
-	wafWebACLArn := terraform.Output(t, webAppOptions, "waf_web_acl_arn")
+	wafWebACLArn := terraform.Output(t, webApp.Options, "waf_web_acl_arn")
 	assert.Empty(t, wafWebACLArn)
 
 	// But other components should still exist
-	asgName := terraform.Output(t, webAppOptions, "autoscaling_group_name")
-	albDNS := terraform.Output(t, webAppOptions, "load_balancer_dns_name")
+	asgName := terraform.Output(t, webApp.Options, "autoscaling_group_name")
+	albDNS := terraform.Output(t, webApp.Options, "load_balancer_dns_name")
 
 	assert.NotEmpty(t, asgName)
 	assert.NotEmpty(t, albDNS)
@@ -231,15 +247,7 @@ func TestWebApplicationModuleValidation(t *testing.T) {
 		{
 			name: "invalid_environment",
 			vars: map[string]interface{}{
-				"project_name":           "test",
-				"environment":           "invalid",
-				"application_name":      "test-app",
-				"vpc_id":                "vpc-123",
-				"subnet_ids":            []string{"subnet-123"},
-				"public_subnet_ids":     []string{"subnet-456"},
-				"security_group_id":     "sg-123",
-				"alb_security_group_id": "sg-456",
-				"instance_profile_name": "test-profile",
+				"environment": "invalid",
 			},
 			expectError:   true,
 			errorContains: "Environment must be one of: staging, production",
@@ -247,16 +255,7 @@ func TestWebApplicationModuleValidation(t *testing.T) {
 		{
 			name: "invalid_instance_type",
 			vars: map[string]interface{}{
-				"project_name":           "test",
-				"environment":           "staging",
-				"application_name":      "test-app",
-				"vpc_id":                "vpc-123",
-				"subnet_ids":            []string{"subnet-123"},
-				"public_subnet_ids":     []string{"subnet-456"},
-				"security_group_id":     "sg-123",
-				"alb_security_group_id": "sg-456",
-				"instance_profile_name": "test-profile",
-				"instance_type":         "invalid.type",
+				"instance_type": "invalid.type",
 			},
 			expectError:   true,
 			errorContains: "Instance type must be a valid EC2 instance type",
@@ -264,16 +263,7 @@ func TestWebApplicationModuleValidation(t *testing.T) {
 		{
 			name: "invalid_root_volume_size",
 			vars: map[string]interface{}{
-				"project_name":           "test",
-				"environment":           "staging",
-				"application_name":      "test-app",
-				"vpc_id":                "vpc-123",
-				"subnet_ids":            []string{"subnet-123"},
-				"public_subnet_ids":     []string{"subnet-456"},
-				"security_group_id":     "sg-123",
-				"alb_security_group_id": "sg-456",
-				"instance_profile_name": "test-profile",
-				"root_volume_size":      5,
+				"root_volume_size": 5,
 			},
 			expectError:   true,
 			errorContains: "Root volume size must be between 8 and 1000 GB",
@@ -281,21 +271,271 @@ func TestWebApplicationModuleValidation(t *testing.T) {
 		{
 			name: "invalid_scaling_config",
 			vars: map[string]interface{}{
-				"project_name":           "test",
-				"environment":           "staging",
-				"application_name":      "test-app",
-				"vpc_id":                "vpc-123",
-				"subnet_ids":            []string{"subnet-123"},
-				"public_subnet_ids":     []string{"subnet-456"},
-				"security_group_id":     "sg-123",
-				"alb_security_group_id": "sg-456",
-				"instance_profile_name": "test-profile",
-				"min_size":              5,
-				"max_size":              3,
+				"min_size": 5,
+				"max_size": 3,
 			},
 			expectError:   true,
 			errorContains: "desired_capacity cannot be greater than max_size",
 		},
+		{
+			name: "duplicate_listener_rule_priority",
+			vars: map[string]interface{}{
+				"listener_rules": []map[string]interface{}{
+					{"priority": 10, "path_patterns": []string{"/api/*"}},
+					{"priority": 10, "host_headers": []string{"admin.example.com"}},
+				},
+			},
+			expectError:   true,
+			errorContains: "Listener rule priorities must be unique",
+		},
+		{
+			name: "invalid_listener_rule_priority",
+			vars: map[string]interface{}{
+				"listener_rules": []map[string]interface{}{
+					{"priority": 50001, "path_patterns": []string{"/api/*"}},
+				},
+			},
+			expectError:   true,
+			errorContains: "Listener rule priorities must be between 1 and 50000",
+		},
+		{
+			name: "invalid_stickiness_duration",
+			vars: map[string]interface{}{
+				"enable_stickiness":   true,
+				"stickiness_duration": 700000,
+			},
+			expectError:   true,
+			errorContains: "stickiness_duration must be between 1 and 604800 seconds",
+		},
+		{
+			name: "conflicting_user_data",
+			vars: map[string]interface{}{
+				"user_data":               "#!/bin/bash\necho hello",
+				"user_data_template_file": "user_data.sh",
+			},
+			expectError:   true,
+			errorContains: "Only one of user_data or user_data_template_file can be set",
+		},
+		{
+			name: "invalid_data_volume_size",
+			vars: map[string]interface{}{
+				"data_volumes": []map[string]interface{}{
+					{"device_name": "/dev/xvdb", "size": 20000},
+				},
+			},
+			expectError:   true,
+			errorContains: "Data volume size must be between 1 and 16384 GB",
+		},
+		{
+			name: "data_volume_root_device_collision",
+			vars: map[string]interface{}{
+				"data_volumes": []map[string]interface{}{
+					{"device_name": "/dev/xvda", "size": 50},
+				},
+			},
+			expectError:   true,
+			errorContains: "Data volume device names must not collide with the root device",
+		},
+		{
+			name: "invalid_deregistration_delay",
+			vars: map[string]interface{}{
+				"deregistration_delay": 4000,
+			},
+			expectError:   true,
+			errorContains: "Deregistration delay must be between 0 and 3600 seconds",
+		},
+		{
+			name: "invalid_slow_start",
+			vars: map[string]interface{}{
+				"slow_start": 10,
+			},
+			expectError:   true,
+			errorContains: "Slow start must be 0 or between 30 and 900 seconds",
+		},
+		{
+			name: "route53_zone_without_dns_records",
+			vars: map[string]interface{}{
+				"route53_zone_id": "Z0123456789ABCDEFGHIJ",
+			},
+			expectError:   true,
+			errorContains: "At least one DNS record must be provided when route53_zone_id is set",
+		},
+		{
+			name: "health_check_timeout_exceeds_interval",
+			vars: map[string]interface{}{
+				"health_check_interval": 10,
+				"health_check_timeout":  15,
+			},
+			expectError:   true,
+			errorContains: "health_check_timeout must be less than health_check_interval",
+		},
+		{
+			name: "invalid_healthy_threshold",
+			vars: map[string]interface{}{
+				"healthy_threshold": 11,
+			},
+			expectError:   true,
+			errorContains: "Healthy threshold must be between 2 and 10",
+		},
+		{
+			name: "invalid_scaling_metric",
+			vars: map[string]interface{}{
+				"scaling_metric": "memory",
+			},
+			expectError:   true,
+			errorContains: "Scaling metric must be one of: cpu, alb_request_count, network_in",
+		},
+		{
+			name: "invalid_lifecycle_hook_heartbeat_timeout",
+			vars: map[string]interface{}{
+				"lifecycle_hooks": []map[string]interface{}{
+					{"name": "drain-jobs", "lifecycle_transition": "autoscaling:EC2_INSTANCE_TERMINATING", "heartbeat_timeout": 10},
+				},
+			},
+			expectError:   true,
+			errorContains: "Lifecycle hook heartbeat timeout must be between 30 and 7200 seconds",
+		},
+		{
+			name: "invalid_lifecycle_transition",
+			vars: map[string]interface{}{
+				"lifecycle_hooks": []map[string]interface{}{
+					{"name": "drain-jobs", "lifecycle_transition": "terminating"},
+				},
+			},
+			expectError:   true,
+			errorContains: "Lifecycle transition must be one of",
+		},
+		{
+			name: "grpc_with_http_listener",
+			vars: map[string]interface{}{
+				"target_group_protocol": "GRPC",
+				"force_allow_http":      true,
+			},
+			expectError:   true,
+			errorContains: "GRPC protocol version requires an HTTPS listener",
+		},
+		{
+			name: "app_cookie_without_cookie_name",
+			vars: map[string]interface{}{
+				"enable_stickiness": true,
+				"stickiness_type":   "app_cookie",
+			},
+			expectError:   true,
+			errorContains: "A stickiness cookie name is required when stickiness_type is app_cookie",
+		},
+		{
+			name: "memory_alarm_threshold_above_100",
+			vars: map[string]interface{}{
+				"enable_memory_alarms":   true,
+				"memory_alarm_threshold": 150,
+			},
+			expectError:   true,
+			errorContains: "Memory alarm threshold must be between 1 and 100 percent",
+		},
+		{
+			name: "internet_facing_without_public_subnets",
+			vars: map[string]interface{}{
+				"public_subnet_ids": nil,
+			},
+			expectError:   true,
+			errorContains: "Public subnet IDs are required unless internal_load_balancer is true",
+		},
+		{
+			name: "alarm_topic_arn_and_create_alarm_topic",
+			vars: map[string]interface{}{
+				"create_alarm_topic":  true,
+				"alarm_sns_topic_arn": "arn:aws:sns:us-east-1:123456789012:alarms",
+			},
+			expectError:   true,
+			errorContains: "Only one of alarm_sns_topic_arn or create_alarm_topic can be set",
+		},
+		{
+			name: "health_check_grace_period_too_long",
+			vars: map[string]interface{}{
+				"health_check_grace_period": 9000,
+			},
+			expectError:   true,
+			errorContains: "Health check grace period must be between 0 and 7200 seconds",
+		},
+		{
+			name: "grpc_additional_listener_over_http",
+			vars: map[string]interface{}{
+				"additional_listeners": []map[string]interface{}{
+					{"port": 50051, "target_port": 50051, "protocol": "HTTP", "protocol_version": "GRPC"},
+				},
+			},
+			expectError:   true,
+			errorContains: "HTTP2 and GRPC protocol versions require an HTTPS additional listener",
+		},
+		{
+			name: "invalid_launch_template_version",
+			vars: map[string]interface{}{
+				"launch_template_version": "latest",
+			},
+			expectError:   true,
+			errorContains: "Launch template version must be $Latest, $Default, or a positive version number",
+		},
+		{
+			name: "blue_green_weights_not_summing_to_100",
+			vars: map[string]interface{}{
+				"enable_blue_green": true,
+				"blue_weight":       70,
+				"green_weight":      20,
+			},
+			expectError:   true,
+			errorContains: "Blue and green weights must sum to 100",
+		},
+		{
+			name: "instance_profile_and_shared_role",
+			vars: map[string]interface{}{
+				"shared_instance_role_arn": "arn:aws:iam::123456789012:role/shared-web-role",
+			},
+			expectError:   true,
+			errorContains: "Exactly one of instance_profile_name, shared_instance_role_arn, or create_instance_role must be set",
+		},
+		{
+			name: "waf_ip_blocklist_overlapping_allowlist",
+			vars: map[string]interface{}{
+				"waf_ip_allowlist": []string{"203.0.113.0/24"},
+				"waf_ip_blocklist": []string{"203.0.113.128/25"},
+			},
+			expectError:   true,
+			errorContains: "WAF IP blocklist entries must not overlap the WAF IP allowlist",
+		},
+		{
+			name: "instance_store_without_instance_storage",
+			vars: map[string]interface{}{
+				"instance_type":               "t3.micro",
+				"instance_store_device_names": []string{"/dev/sdb"},
+			},
+			expectError:   true,
+			errorContains: "Instance store volumes require instance types with instance storage",
+		},
+		{
+			name: "invalid_desync_mitigation_mode",
+			vars: map[string]interface{}{
+				"desync_mitigation_mode": "lenient",
+			},
+			expectError:   true,
+			errorContains: "Desync mitigation mode must be one of: monitor, defensive, strictest",
+		},
+		{
+			name: "invalid_termination_policy",
+			vars: map[string]interface{}{
+				"termination_policies": []string{"YoungestInstance"},
+			},
+			expectError:   true,
+			errorContains: "Termination policies must be from: OldestInstance, NewestInstance",
+		},
+		{
+			name: "alarm_datapoints_exceeding_evaluation_periods",
+			vars: map[string]interface{}{
+				"alarm_evaluation_periods":  3,
+				"alarm_datapoints_to_alarm": 5,
+			},
+			expectError:   true,
+			errorContains: "Alarm datapoints to alarm must be between 1 and alarm_evaluation_periods",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -305,7 +545,7 @@ func TestWebApplicationModuleValidation(t *testing.T) {
 
 			terraformOptions := &terraform.Options{
 				TerraformDir: "../terraform/modules/web-application",
-				Vars:         tc.vars,
+				Vars:         helpers.WebApplicationPlanVars(tc.vars),
 			}
 
 			_, err := terraform.InitAndPlanE(t, terraformOptions)
@@ -332,73 +572,1668 @@ func TestWebApplicationModuleWithGeographicBlocking(t *testing.T) {
 	uniqueID := random.UniqueId()
 
 	// Create minimal networking setup
-	networkingOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: "../terraform/modules/shared-networking",
-
-		Vars: map[string]interface{}{
-			"project_name":            fmt.Sprintf("test-geo-%s", uniqueID),
-			"environment":            "test",
-			"public_subnet_count":    1,
-			"private_subnet_count":   1,
-			"database_subnet_count":  0,
-			"enable_nat_gateway":     false,
-			"enable_flow_logs":       false,
-			"enable_vpc_endpoints":   false,
-		},
-
-		EnvVars: map[string]string{
-			"AWS_DEFAULT_REGION": awsRegion,
-		},
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-geo-%s", uniqueID), map[string]interface{}{
+		"public_subnet_count": 1,
 	})
 
-	defer terraform.Destroy(t, networkingOptions)
-	terraform.InitAndApply(t, networkingOptions)
-
-	vpcID := terraform.Output(t, networkingOptions, "vpc_id")
-	publicSubnetIDs := terraform.OutputList(t, networkingOptions, "public_subnet_ids")
-	privateSubnetIDs := terraform.OutputList(t, networkingOptions, "private_subnet_ids")
-	webSGID := terraform.Output(t, networkingOptions, "web_security_group_id")
-	appSGID := terraform.Output(t, networkingOptions, "application_security_group_id")
-
 	// Test web application with geographic blocking
-	webAppOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
-		TerraformDir: "../terraform/modules/web-application",
-
-		Vars: map[string]interface{}{
-			"project_name":            fmt.Sprintf("test-geo-%s", uniqueID),
-			"environment":            "test",
-			"application_name":       "test-app-geo",
-			"vpc_id":                 vpcID,
-			"subnet_ids":             privateSubnetIDs,
-			"public_subnet_ids":      publicSubnetIDs,
-			"security_group_id":      appSGID,
-			"alb_security_group_id":  webSGID,
-			"instance_profile_name":  "test-instance-profile",
-			"enable_waf":            true,
-			"enable_geo_blocking":   true,
-			"blocked_countries":     []string{"CN", "RU"},
-			"waf_rate_limit":        500,
-		},
-
-		EnvVars: map[string]string{
-			"AWS_DEFAULT_REGION": awsRegion,
-		},
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-geo-%s", uniqueID), networking, map[string]interface{}{
+		"application_name":    "test-app-geo",
+		"enable_waf":          true,
+		"enable_geo_blocking": true,
+		"blocked_countries":   []string{"CN", "RU"},
+		"waf_rate_limit":      500,
 	})
 
-	defer terraform.Destroy(t, webAppOptions)
-	terraform.InitAndApply(t, webAppOptions)
-
 	// Verify WAF is created with geographic blocking
-	wafWebACLArn := terraform.Output(t, webAppOptions, "waf_web_acl_arn")
-	wafWebACLName := terraform.Output(t, webAppOptions, "waf_web_acl_name")
+	wafWebACLArn := terraform.Output(t, webApp.Options, "waf_web_acl_arn")
+	wafWebACLName := terraform.Output(t, webApp.Options, "waf_web_acl_name")
 
 	assert.NotEmpty(t, wafWebACLArn)
 	assert.NotEmpty(t, wafWebACLName)
 
 	// The rest of the infrastructure should also be created
-	asgName := terraform.Output(t, webAppOptions, "autoscaling_group_name")
-	albDNS := terraform.Output(t, webAppOptions, "load_balancer_dns_name")
+	asgName := terraform.Output(t, webApp.Options, "autoscaling_group_name")
+	albDNS := terraform.Output(t, webApp.Options, "load_balancer_dns_name")
 
 	assert.NotEmpty(t, asgName)
 	assert.NotEmpty(t, albDNS)
-}
\ No newline at end of file
+}
+
+func TestWebApplicationModuleWithMirrorTargetGroup(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Create minimal networking setup
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-mirror-%s", uniqueID))
+
+	// Test web application with a mirror target group
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-mirror-%s", uniqueID), networking, map[string]interface{}{
+		"application_name":           "test-app-mirror",
+		"enable_waf":                 false,
+		"enable_mirror_target_group": true,
+		"mirror_target_group_name":   fmt.Sprintf("test-mirror-%s", uniqueID),
+		"mirror_traffic_weight":      10,
+	})
+
+	// Verify the mirror target group is created
+	targetGroupArn := terraform.Output(t, webApp.Options, "target_group_arn")
+	mirrorTargetGroupArn := terraform.Output(t, webApp.Options, "mirror_target_group_arn")
+	assert.NotEmpty(t, mirrorTargetGroupArn)
+	assert.NotEqual(t, targetGroupArn, mirrorTargetGroupArn)
+
+	// Verify the HTTPS listener splits traffic with the configured weight
+	httpsListenerArn := terraform.Output(t, webApp.Options, "https_listener_arn")
+	weights := getListenerForwardWeights(t, awsRegion, httpsListenerArn)
+	assert.Equal(t, int64(10), weights[mirrorTargetGroupArn])
+	assert.Equal(t, int64(90), weights[targetGroupArn])
+}
+
+func TestWebApplicationModuleUserDataTemplate(t *testing.T) {
+	t.Parallel()
+
+	templatePath, err := filepath.Abs("fixtures/user_data.sh.tpl")
+	require.NoError(t, err)
+
+	// Plan only - the rendered user data is visible on the planned launch template
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":            "test-userdata",
+		"user_data_template_file": templatePath,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	launchTemplate, ok := plan.ResourcePlannedValuesMap["aws_launch_template.web"]
+	require.True(t, ok, "launch template should be planned")
+
+	userData, err := base64.StdEncoding.DecodeString(launchTemplate.AttributeValues["user_data"].(string))
+	require.NoError(t, err)
+	assert.Contains(t, string(userData), "Bootstrapping test-app in staging")
+}
+
+func TestWebApplicationModuleUserDataDetection(t *testing.T) {
+	t.Parallel()
+
+	script := "#!/bin/bash\necho hello"
+	encoded := base64.StdEncoding.EncodeToString([]byte(script))
+
+	// Raw scripts are encoded by the module and base64 input is passed through unchanged,
+	// so both forms reach the launch template as the same encoded script
+	testCases := []struct {
+		name     string
+		userData string
+	}{
+		{"raw", script},
+		{"base64", encoded},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Plan only - the user data is visible on the planned launch template
+			webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+				"project_name": "test-userdata",
+				"user_data":    tc.userData,
+			})
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+			launchTemplate, ok := plan.ResourcePlannedValuesMap["aws_launch_template.web"]
+			require.True(t, ok, "launch template should be planned")
+			assert.Equal(t, encoded, launchTemplate.AttributeValues["user_data"])
+		})
+	}
+}
+
+func TestWebApplicationModuleWarmPool(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		enableWarmPool bool
+		reuseOnScaleIn bool
+	}{
+		{"Enabled", true, false},
+		{"EnabledWithReuse", true, true},
+		{"Disabled", false, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Plan only - the warm pool block is visible on the planned ASG
+			webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+				"project_name":                    "test-warmpool",
+				"enable_warm_pool":                tc.enableWarmPool,
+				"warm_pool_min_size":              1,
+				"warm_pool_max_prepared_capacity": 4,
+				"warm_pool_reuse_on_scale_in":     tc.reuseOnScaleIn,
+			})
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+			asg, ok := plan.ResourcePlannedValuesMap["aws_autoscaling_group.web"]
+			require.True(t, ok, "auto scaling group should be planned")
+
+			warmPools, _ := asg.AttributeValues["warm_pool"].([]interface{})
+			if !tc.enableWarmPool {
+				assert.Empty(t, warmPools, "warm pool should not be planned when disabled")
+				return
+			}
+
+			require.Len(t, warmPools, 1)
+			warmPool := warmPools[0].(map[string]interface{})
+			assert.Equal(t, "Stopped", warmPool["pool_state"])
+			assert.EqualValues(t, 1, warmPool["min_size"])
+			assert.EqualValues(t, 4, warmPool["max_group_prepared_capacity"])
+
+			reusePolicies, _ := warmPool["instance_reuse_policy"].([]interface{})
+			if !tc.reuseOnScaleIn {
+				assert.Empty(t, reusePolicies, "instance reuse policy should not be planned without reuse")
+				return
+			}
+
+			require.Len(t, reusePolicies, 1)
+			assert.Equal(t, true, reusePolicies[0].(map[string]interface{})["reuse_on_scale_in"])
+		})
+	}
+}
+
+func TestWebApplicationModuleWafLogFilter(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the logging filter is visible on the planned logging configuration
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":       "test-waflog",
+		"enable_waf":         true,
+		"enable_waf_logging": true,
+		"waf_log_filter":     "blocked",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	loggingConfig, ok := plan.ResourcePlannedValuesMap["aws_wafv2_web_acl_logging_configuration.web_acl[0]"]
+	require.True(t, ok, "WAF logging configuration should be planned")
+
+	loggingFilters := loggingConfig.AttributeValues["logging_filter"].([]interface{})
+	require.Len(t, loggingFilters, 1)
+	loggingFilter := loggingFilters[0].(map[string]interface{})
+	assert.Equal(t, "DROP", loggingFilter["default_behavior"])
+
+	filters := loggingFilter["filter"].([]interface{})
+	require.Len(t, filters, 1)
+	filter := filters[0].(map[string]interface{})
+	assert.Equal(t, "KEEP", filter["behavior"])
+
+	conditions := filter["condition"].([]interface{})
+	require.Len(t, conditions, 1)
+	actionConditions := conditions[0].(map[string]interface{})["action_condition"].([]interface{})
+	require.Len(t, actionConditions, 1)
+	assert.Equal(t, "BLOCK", actionConditions[0].(map[string]interface{})["action"])
+}
+
+func TestWebApplicationModuleWafFirehoseLogging(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the delivery stream's S3 destination settings are known at plan time
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":                    "test-waffh",
+		"enable_waf":                      true,
+		"enable_waf_logging":              true,
+		"waf_log_destination":             "firehose",
+		"waf_firehose_s3_prefix":          "security/waf/",
+		"waf_firehose_buffering_interval": 60,
+	})
+	kmsKeyArn := fmt.Sprintf("arn:aws:kms:%s:123456789012:key/00000000-0000-0000-0000-000000000000", webAppOptions.EnvVars["AWS_DEFAULT_REGION"])
+	webAppOptions.Vars["waf_log_kms_key_arn"] = kmsKeyArn
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	stream, ok := plan.ResourcePlannedValuesMap["aws_kinesis_firehose_delivery_stream.waf[0]"]
+	require.True(t, ok, "Firehose delivery stream should be planned")
+	assert.Equal(t, "aws-waf-logs-test-waffh-staging", stream.AttributeValues["name"])
+
+	s3Configs := stream.AttributeValues["extended_s3_configuration"].([]interface{})
+	require.Len(t, s3Configs, 1)
+	s3Config := s3Configs[0].(map[string]interface{})
+	assert.Equal(t, "GZIP", s3Config["compression_format"])
+	assert.Equal(t, "security/waf/", s3Config["prefix"])
+	assert.Equal(t, kmsKeyArn, s3Config["kms_key_arn"])
+	assert.EqualValues(t, 60, s3Config["buffering_interval"])
+
+	// The destination bucket is encrypted with the same key
+	encryption, ok := plan.ResourcePlannedValuesMap["aws_s3_bucket_server_side_encryption_configuration.waf_logs[0]"]
+	require.True(t, ok, "WAF log bucket encryption should be planned")
+	rules := encryption.AttributeValues["rule"].([]interface{})
+	require.Len(t, rules, 1)
+	defaults := rules[0].(map[string]interface{})["apply_server_side_encryption_by_default"].([]interface{})
+	require.Len(t, defaults, 1)
+	assert.Equal(t, "aws:kms", defaults[0].(map[string]interface{})["sse_algorithm"])
+	assert.Equal(t, kmsKeyArn, defaults[0].(map[string]interface{})["kms_master_key_id"])
+
+	// No CloudWatch log group is created for the Firehose destination
+	_, ok = plan.ResourcePlannedValuesMap["aws_cloudwatch_log_group.waf[0]"]
+	assert.False(t, ok)
+}
+
+func TestWebApplicationModuleLifecycleHooks(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the hook and its ASG attachment are visible in the plan
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name": "test-hooks",
+		"lifecycle_hooks": []map[string]interface{}{
+			{
+				"name":                 "drain-jobs",
+				"lifecycle_transition": "autoscaling:EC2_INSTANCE_TERMINATING",
+				"heartbeat_timeout":    300,
+			},
+		},
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	hook, ok := plan.ResourcePlannedValuesMap[`aws_autoscaling_lifecycle_hook.web["drain-jobs"]`]
+	require.True(t, ok, "lifecycle hook should be planned")
+
+	assert.Equal(t, "test-hooks-staging-web-asg", hook.AttributeValues["autoscaling_group_name"])
+	assert.Equal(t, "autoscaling:EC2_INSTANCE_TERMINATING", hook.AttributeValues["lifecycle_transition"])
+	assert.EqualValues(t, 300, hook.AttributeValues["heartbeat_timeout"])
+	assert.Equal(t, "CONTINUE", hook.AttributeValues["default_result"])
+}
+
+func TestWebApplicationModuleSpotMixedInstances(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the mixed instances policy is visible on the planned ASG
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":             "test-spot",
+		"enable_mixed_instances":   true,
+		"instance_types":           []string{"t3.micro", "t3a.micro", "t2.micro"},
+		"spot_allocation_strategy": "price-capacity-optimized",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	asg, ok := plan.ResourcePlannedValuesMap["aws_autoscaling_group.web"]
+	require.True(t, ok, "auto scaling group should be planned")
+
+	policies := asg.AttributeValues["mixed_instances_policy"].([]interface{})
+	require.Len(t, policies, 1)
+	distributions := policies[0].(map[string]interface{})["instances_distribution"].([]interface{})
+	require.Len(t, distributions, 1)
+	distribution := distributions[0].(map[string]interface{})
+
+	// Pure Spot: no On-Demand base or percentage above it
+	assert.Equal(t, "price-capacity-optimized", distribution["spot_allocation_strategy"])
+	assert.EqualValues(t, 0, distribution["on_demand_base_capacity"])
+	assert.EqualValues(t, 0, distribution["on_demand_percentage_above_base_capacity"])
+
+	// The plain launch template block is replaced by the mixed instances policy
+	launchTemplates, _ := asg.AttributeValues["launch_template"].([]interface{})
+	assert.Empty(t, launchTemplates)
+}
+
+func TestWebApplicationModuleCommonTags(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Module tags must win over a conflicting caller-supplied Environment tag
+	commonTags := map[string]string{
+		"cost_center": "engineering",
+		"Environment": "override",
+	}
+
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-tags-%s", uniqueID), map[string]interface{}{
+		"private_subnet_count": 2,
+		"enable_nat_gateway":   true,
+		"nat_gateway_count":    1,
+		"tags":                 commonTags,
+	})
+
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-tags-%s", uniqueID), networking, map[string]interface{}{
+		"min_size":         1,
+		"max_size":         1,
+		"desired_capacity": 1,
+		"enable_waf":       false,
+		"tags":             commonTags,
+	})
+
+	// Verify the computed tag map keeps the module's Environment tag
+	computedTags := terraform.OutputMap(t, webApp.Options, "common_tags")
+	assert.Equal(t, "engineering", computedTags["cost_center"])
+	assert.Equal(t, "staging", computedTags["Environment"])
+
+	// Verify the VPC carries the cost allocation tag
+	vpc := aws.GetVpcById(t, networking.VpcID, awsRegion)
+	assert.Equal(t, "engineering", vpc.Tags["cost_center"])
+	assert.Equal(t, "staging", vpc.Tags["Environment"])
+
+	// Verify instances launched by the ASG inherit the tag
+	asgName := terraform.Output(t, webApp.Options, "autoscaling_group_name")
+	instanceIDs := aws.GetInstanceIdsForAsg(t, asgName, awsRegion)
+	require.NotEmpty(t, instanceIDs)
+
+	instanceTags := aws.GetTagsForEc2Instance(t, awsRegion, instanceIDs[0])
+	assert.Equal(t, "engineering", instanceTags["cost_center"])
+	assert.Equal(t, "web-application", instanceTags["Module"])
+}
+
+func TestWebApplicationModuleGrpcTargetGroup(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - protocol version and matcher are visible on the planned target group
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":          "test-grpc",
+		"target_group_protocol": "GRPC",
+		"health_check_path":     "/AWS.ALB/healthcheck",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	targetGroup, ok := plan.ResourcePlannedValuesMap["aws_lb_target_group.web"]
+	require.True(t, ok, "target group should be planned")
+
+	assert.Equal(t, "HTTP", targetGroup.AttributeValues["protocol"])
+	assert.Equal(t, "GRPC", targetGroup.AttributeValues["protocol_version"])
+
+	healthChecks := targetGroup.AttributeValues["health_check"].([]interface{})
+	require.Len(t, healthChecks, 1)
+	assert.Equal(t, "0", healthChecks[0].(map[string]interface{})["matcher"])
+}
+
+func TestWebApplicationModuleLatencyRouting(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the routing policy is visible on the planned records
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":    "test-latency",
+		"route53_zone_id": "Z0123456789ABCDEFGHIJ",
+		"dns_records":     []string{"app.example.com"},
+		"routing_policy":  "latency",
+	})
+	awsRegion := webAppOptions.EnvVars["AWS_DEFAULT_REGION"]
+	webAppOptions.Vars["set_identifier"] = awsRegion
+	webAppOptions.Vars["routing_region"] = awsRegion
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	for _, recordType := range []string{"A", "AAAA"} {
+		address := fmt.Sprintf(`aws_route53_record.alb["app.example.com-%s"]`, recordType)
+		record, ok := plan.ResourcePlannedValuesMap[address]
+		require.True(t, ok, "%s should be planned", address)
+
+		assert.Equal(t, awsRegion, record.AttributeValues["set_identifier"])
+
+		latencyPolicies := record.AttributeValues["latency_routing_policy"].([]interface{})
+		require.Len(t, latencyPolicies, 1)
+		assert.Equal(t, awsRegion, latencyPolicies[0].(map[string]interface{})["region"])
+
+		weightedPolicies, _ := record.AttributeValues["weighted_routing_policy"].([]interface{})
+		assert.Empty(t, weightedPolicies)
+	}
+}
+
+func TestWebApplicationModuleStickiness(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the stickiness block is visible on the planned target group
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":        "test-sticky",
+		"enable_stickiness":   true,
+		"stickiness_type":     "lb_cookie",
+		"stickiness_duration": 3600,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	targetGroup, ok := plan.ResourcePlannedValuesMap["aws_lb_target_group.web"]
+	require.True(t, ok, "target group should be planned")
+
+	stickiness := targetGroup.AttributeValues["stickiness"].([]interface{})
+	require.Len(t, stickiness, 1)
+	config := stickiness[0].(map[string]interface{})
+	assert.Equal(t, true, config["enabled"])
+	assert.Equal(t, "lb_cookie", config["type"])
+	assert.EqualValues(t, 3600, config["cookie_duration"])
+
+	// Disabled stickiness still plans the block, switched off, so turning it off takes effect
+	disabledOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":      "test-sticky-off",
+		"enable_stickiness": false,
+	})
+
+	disabledPlan := terraform.InitAndPlanAndShowWithStruct(t, disabledOptions)
+
+	disabledTargetGroup, ok := disabledPlan.ResourcePlannedValuesMap["aws_lb_target_group.web"]
+	require.True(t, ok, "target group should be planned")
+
+	disabledStickiness := disabledTargetGroup.AttributeValues["stickiness"].([]interface{})
+	require.Len(t, disabledStickiness, 1)
+	assert.Equal(t, false, disabledStickiness[0].(map[string]interface{})["enabled"])
+}
+
+func TestWebApplicationModuleAdditionalSecurityGroups(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the launch template's security groups are known at plan time
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":                  "test-extra-sg",
+		"additional_security_group_ids": []string{"sg-789"},
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	launchTemplate, ok := plan.ResourcePlannedValuesMap["aws_launch_template.web"]
+	require.True(t, ok, "launch template should be planned")
+
+	securityGroupIDs := launchTemplate.AttributeValues["vpc_security_group_ids"].([]interface{})
+	assert.ElementsMatch(t, []interface{}{"sg-123", "sg-789"}, securityGroupIDs)
+}
+
+func TestWebApplicationModuleMemoryAndDiskAlarms(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the alarm metric configuration is known at plan time
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":            "test-agent-alarms",
+		"enable_cloudwatch_agent": true,
+		"enable_memory_alarms":    true,
+		"memory_alarm_threshold":  85,
+		"enable_disk_alarms":      true,
+		"disk_alarm_threshold":    90,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	testCases := []struct {
+		address    string
+		metricName string
+		threshold  float64
+	}{
+		{"aws_cloudwatch_metric_alarm.memory_high[0]", "mem_used_percent", 85},
+		{"aws_cloudwatch_metric_alarm.disk_high[0]", "disk_used_percent", 90},
+	}
+
+	for _, tc := range testCases {
+		alarm, ok := plan.ResourcePlannedValuesMap[tc.address]
+		require.True(t, ok, "%s should be planned", tc.address)
+
+		assert.Equal(t, "CWAgent", alarm.AttributeValues["namespace"])
+		assert.Equal(t, tc.metricName, alarm.AttributeValues["metric_name"])
+		assert.EqualValues(t, tc.threshold, alarm.AttributeValues["threshold"])
+	}
+
+	// The agent bootstrap is delivered through the launch template user data
+	launchTemplate, ok := plan.ResourcePlannedValuesMap["aws_launch_template.web"]
+	require.True(t, ok, "launch template should be planned")
+	assert.NotEmpty(t, launchTemplate.AttributeValues["user_data"])
+}
+
+func TestWebApplicationModuleAlarmTreatMissingData(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - TreatMissingData is set directly on the planned alarms
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":             "test-missing-data",
+		"alarm_treat_missing_data": "breaching",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	for _, address := range []string{"aws_cloudwatch_metric_alarm.cpu_high[0]", "aws_cloudwatch_metric_alarm.cpu_low[0]"} {
+		alarm, ok := plan.ResourcePlannedValuesMap[address]
+		require.True(t, ok, "%s should be planned", address)
+		assert.Equal(t, "breaching", alarm.AttributeValues["treat_missing_data"])
+	}
+}
+
+func TestWebApplicationModuleInternalLoadBalancer(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// An internal ALB needs private subnets in at least two availability zones
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-int-%s", uniqueID), map[string]interface{}{
+		"public_subnet_count":  1,
+		"private_subnet_count": 2,
+	})
+
+	// No public subnets are passed to the module
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-int-%s", uniqueID), networking, map[string]interface{}{
+		"application_name":        "test-app-int",
+		"public_subnet_ids":       nil,
+		"alb_security_group_id":   nil,
+		"enable_waf":              false,
+		"internal_load_balancer":  true,
+		"alb_ingress_cidr_blocks": []string{networking.VpcCidrBlock},
+	})
+
+	assert.Equal(t, "internal", terraform.Output(t, webApp.Options, "load_balancer_scheme"))
+
+	// Verify the scheme reported by the load balancer itself
+	loadBalancerArn := terraform.Output(t, webApp.Options, "load_balancer_arn")
+	assert.Equal(t, "internal", getLoadBalancerScheme(t, awsRegion, loadBalancerArn))
+}
+
+func TestWebApplicationModuleBlueGreen(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - target group names are known at plan time, ARNs are not
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":               "test-bg",
+		"enable_blue_green":          true,
+		"blue_green_traffic_control": "codedeploy",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	// Both target groups are planned with matching settings
+	blue, ok := plan.ResourcePlannedValuesMap["aws_lb_target_group.web"]
+	require.True(t, ok, "blue target group should be planned")
+	green, ok := plan.ResourcePlannedValuesMap["aws_lb_target_group.green[0]"]
+	require.True(t, ok, "green target group should be planned")
+	assert.Equal(t, "test-bg-staging-web-tg", blue.AttributeValues["name"])
+	assert.Equal(t, "test-bg-staging-green-tg", green.AttributeValues["name"])
+	assert.Equal(t, blue.AttributeValues["port"], green.AttributeValues["port"])
+	assert.Equal(t, blue.AttributeValues["protocol"], green.AttributeValues["protocol"])
+
+	// CodeDeploy owns the listener's default action, so the listener that ignores
+	// default action changes is planned instead of the weighted one
+	_, ok = plan.ResourcePlannedValuesMap["aws_lb_listener.web_https_codedeploy[0]"]
+	assert.True(t, ok, "CodeDeploy-controlled HTTPS listener should be planned")
+	_, ok = plan.ResourcePlannedValuesMap["aws_lb_listener.web_https[0]"]
+	assert.False(t, ok, "weighted HTTPS listener should not be planned")
+	if weightsChange, ok := plan.RawPlan.OutputChanges["blue_green_weights"]; ok {
+		assert.Nil(t, weightsChange.After, "blue_green_weights should be null when CodeDeploy controls traffic")
+	}
+
+	// The CodeDeploy output lists the production listener and both target groups
+	outputChange, ok := plan.RawPlan.OutputChanges["codedeploy_ready"]
+	require.True(t, ok, "codedeploy_ready output should be planned")
+	codeDeploy := outputChange.After.(map[string]interface{})
+	pairInfo := codeDeploy["target_group_pair_info"].(map[string]interface{})
+
+	prodTrafficRoute := pairInfo["prod_traffic_route"].(map[string]interface{})
+	assert.Len(t, prodTrafficRoute["listener_arns"], 1)
+
+	targetGroups := pairInfo["target_groups"].([]interface{})
+	require.Len(t, targetGroups, 2)
+	assert.Equal(t, "test-bg-staging-web-tg", targetGroups[0].(map[string]interface{})["name"])
+	assert.Equal(t, "test-bg-staging-green-tg", targetGroups[1].(map[string]interface{})["name"])
+}
+
+func TestWebApplicationModuleHealthCheckDefaults(t *testing.T) {
+	t.Parallel()
+
+	// Plan only with no health check inputs so the defaults are exercised
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name": "test-health",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	asg, ok := plan.ResourcePlannedValuesMap["aws_autoscaling_group.web"]
+	require.True(t, ok, "Auto Scaling Group should be planned")
+	assert.Equal(t, "ELB", asg.AttributeValues["health_check_type"])
+	assert.EqualValues(t, 300, asg.AttributeValues["health_check_grace_period"])
+}
+
+func TestWebApplicationModuleDestroyReleasesENIs(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-eni-%s", uniqueID))
+
+	// Let the module create the ALB security group so its deletion races the ALB's ENIs
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-eni-%s", uniqueID), networking, map[string]interface{}{
+		"application_name":      "test-app-eni",
+		"alb_security_group_id": nil,
+		"enable_waf":            false,
+	})
+
+	albSGID := terraform.Output(t, webApp.Options, "alb_security_group_id")
+	require.NotEmpty(t, albSGID)
+	require.NotEmpty(t, getSecurityGroupNetworkInterfaceIDs(t, awsRegion, albSGID))
+
+	// Destroy now rather than at cleanup so the aftermath can be inspected
+	helpers.DestroyWaitingForENIs(t, webApp.Options, awsRegion, []string{albSGID}, helpers.ENIWaitTimeout(t))
+
+	assert.Empty(t, getSecurityGroupNetworkInterfaceIDs(t, awsRegion, albSGID))
+	assert.False(t, securityGroupExists(t, awsRegion, albSGID), "security group %s should be deleted", albSGID)
+}
+
+func TestWebApplicationModuleAdditionalGrpcListener(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the additional target group's matcher is visible in the plan
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name": "test-addl",
+		"additional_listeners": []map[string]interface{}{
+			{"port": 50051, "target_port": 50051, "protocol_version": "GRPC"},
+			{"port": 8443, "target_port": 8443, "protocol_version": "HTTP2"},
+		},
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	grpcListener, ok := plan.ResourcePlannedValuesMap[`aws_lb_listener.additional["50051"]`]
+	require.True(t, ok, "gRPC listener should be planned")
+	assert.Equal(t, "HTTPS", grpcListener.AttributeValues["protocol"])
+
+	grpcTargetGroup, ok := plan.ResourcePlannedValuesMap[`aws_lb_target_group.additional["50051"]`]
+	require.True(t, ok, "gRPC target group should be planned")
+	assert.Equal(t, "GRPC", grpcTargetGroup.AttributeValues["protocol_version"])
+
+	healthChecks := grpcTargetGroup.AttributeValues["health_check"].([]interface{})
+	require.Len(t, healthChecks, 1)
+	assert.Equal(t, "0", healthChecks[0].(map[string]interface{})["matcher"])
+	assert.Equal(t, "/AWS.ALB/healthcheck", healthChecks[0].(map[string]interface{})["path"])
+
+	// HTTP2 target groups keep the HTTP status matcher
+	http2TargetGroup, ok := plan.ResourcePlannedValuesMap[`aws_lb_target_group.additional["8443"]`]
+	require.True(t, ok, "HTTP2 target group should be planned")
+	http2HealthChecks := http2TargetGroup.AttributeValues["health_check"].([]interface{})
+	require.Len(t, http2HealthChecks, 1)
+	assert.Equal(t, "200", http2HealthChecks[0].(map[string]interface{})["matcher"])
+
+	// The module's own target group keeps the HTTP1 matcher
+	targetGroup, ok := plan.ResourcePlannedValuesMap["aws_lb_target_group.web"]
+	require.True(t, ok, "target group should be planned")
+	assert.Equal(t, "200", targetGroup.AttributeValues["health_check"].([]interface{})[0].(map[string]interface{})["matcher"])
+}
+
+func TestWebApplicationModuleLaunchTemplateVersion(t *testing.T) {
+	t.Parallel()
+
+	for _, version := range []string{"$Default", "3"} {
+		version := version
+		t.Run(version, func(t *testing.T) {
+			t.Parallel()
+
+			// Plan only - the pinned version is visible on the planned Auto Scaling Group
+			webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+				"project_name":            "test-ltv",
+				"launch_template_version": version,
+			})
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+			asg, ok := plan.ResourcePlannedValuesMap["aws_autoscaling_group.web"]
+			require.True(t, ok, "Auto Scaling Group should be planned")
+
+			launchTemplates := asg.AttributeValues["launch_template"].([]interface{})
+			require.Len(t, launchTemplates, 1)
+			assert.Equal(t, version, launchTemplates[0].(map[string]interface{})["version"])
+
+			assert.Equal(t, version, plan.RawPlan.OutputChanges["launch_template_version"].After)
+		})
+	}
+}
+
+func TestWebApplicationModuleSuspendedProcesses(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-susp-%s", uniqueID))
+
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-susp-%s", uniqueID), networking, map[string]interface{}{
+		"application_name":    "test-app-susp",
+		"enable_waf":          false,
+		"suspended_processes": []string{"Terminate", "ReplaceUnhealthy"},
+	})
+
+	// Verify the processes are suspended on the ASG itself
+	suspendedProcesses := getAsgSuspendedProcessNames(t, awsRegion, webApp.AutoscalingGroupName)
+	assert.ElementsMatch(t, []string{"Terminate", "ReplaceUnhealthy"}, suspendedProcesses)
+
+	// Scaling policies are left in place
+	assert.NotEmpty(t, terraform.Output(t, webApp.Options, "scale_up_policy_arn"))
+}
+
+func TestWebApplicationModuleWeightedBlueGreen(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-bg-%s", uniqueID))
+
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-bg-%s", uniqueID), networking, map[string]interface{}{
+		"application_name":  "test-app-bg",
+		"enable_waf":        false,
+		"enable_blue_green": true,
+		"blue_weight":       80,
+		"green_weight":      20,
+	})
+
+	// Verify both target groups exist
+	blueTargetGroupArn := terraform.Output(t, webApp.Options, "target_group_arn")
+	greenTargetGroupArn := terraform.Output(t, webApp.Options, "green_target_group_arn")
+	require.NotEmpty(t, blueTargetGroupArn)
+	require.NotEmpty(t, greenTargetGroupArn)
+	assert.NotEqual(t, blueTargetGroupArn, greenTargetGroupArn)
+
+	// The ASG registers with blue, the default active target group
+	assert.Equal(t, blueTargetGroupArn, terraform.Output(t, webApp.Options, "active_target_group_arn"))
+
+	// Verify the HTTPS listener forwards to both with the configured weights
+	httpsListenerArn := terraform.Output(t, webApp.Options, "https_listener_arn")
+	weights := getListenerForwardWeights(t, awsRegion, httpsListenerArn)
+	assert.Len(t, weights, 2)
+	assert.Equal(t, int64(80), weights[blueTargetGroupArn])
+	assert.Equal(t, int64(20), weights[greenTargetGroupArn])
+}
+
+func TestWebApplicationModuleSharedInstanceRole(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - a shared role is wrapped in a module-owned instance profile
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":             "test-role",
+		"shared_instance_role_arn": "arn:aws:iam::123456789012:role/shared-web-role",
+		"instance_profile_name":    nil,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	_, roleCreated := plan.ResourcePlannedValuesMap["aws_iam_role.instance[0]"]
+	assert.False(t, roleCreated, "No instance role should be created when a shared role is supplied")
+
+	profile, ok := plan.ResourcePlannedValuesMap["aws_iam_instance_profile.instance[0]"]
+	require.True(t, ok, "Instance profile should be planned")
+	assert.Equal(t, "shared-web-role", profile.AttributeValues["role"])
+	assert.Equal(t, "test-role-staging-test-app-profile", profile.AttributeValues["name"])
+
+	launchTemplate, ok := plan.ResourcePlannedValuesMap["aws_launch_template.web"]
+	require.True(t, ok, "Launch template should be planned")
+	instanceProfiles := launchTemplate.AttributeValues["iam_instance_profile"].([]interface{})
+	require.Len(t, instanceProfiles, 1)
+	assert.Equal(t, "test-role-staging-test-app-profile", instanceProfiles[0].(map[string]interface{})["name"])
+
+	assert.Equal(t, "arn:aws:iam::123456789012:role/shared-web-role", plan.RawPlan.OutputChanges["instance_role_arn"].After)
+}
+
+func TestWebApplicationModuleWafIPSets(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the IP sets and their rule priorities are visible on the planned Web ACL
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":              "test-wafip",
+		"enable_waf":                true,
+		"waf_ip_allowlist":          []string{"198.51.100.0/24"},
+		"waf_ip_blocklist":          []string{"203.0.113.0/24", "192.0.2.10/32"},
+		"waf_ip_blocklist_priority": 6,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	allowlist, ok := plan.ResourcePlannedValuesMap["aws_wafv2_ip_set.allowlist[0]"]
+	require.True(t, ok, "Allowlist IP set should be planned")
+	assert.ElementsMatch(t, []interface{}{"198.51.100.0/24"}, allowlist.AttributeValues["addresses"])
+
+	blocklist, ok := plan.ResourcePlannedValuesMap["aws_wafv2_ip_set.blocklist[0]"]
+	require.True(t, ok, "Blocklist IP set should be planned")
+	assert.ElementsMatch(t, []interface{}{"203.0.113.0/24", "192.0.2.10/32"}, blocklist.AttributeValues["addresses"])
+
+	webACL, ok := plan.ResourcePlannedValuesMap["aws_wafv2_web_acl.web_acl[0]"]
+	require.True(t, ok, "WAF Web ACL should be planned")
+
+	rulePriorities := map[string]float64{}
+	ruleActions := map[string]map[string]interface{}{}
+	for _, rule := range webACL.AttributeValues["rule"].([]interface{}) {
+		ruleMap := rule.(map[string]interface{})
+		name := ruleMap["name"].(string)
+		rulePriorities[name] = ruleMap["priority"].(float64)
+		if actions, ok := ruleMap["action"].([]interface{}); ok && len(actions) == 1 {
+			ruleActions[name] = actions[0].(map[string]interface{})
+		}
+	}
+
+	// The allowlist must be evaluated before everything else, including rate limiting
+	require.Contains(t, rulePriorities, "IPAllowlistRule")
+	require.Contains(t, rulePriorities, "IPBlocklistRule")
+	assert.Equal(t, float64(0), rulePriorities["IPAllowlistRule"])
+	assert.Equal(t, float64(6), rulePriorities["IPBlocklistRule"])
+	for name, priority := range rulePriorities {
+		if name != "IPAllowlistRule" {
+			assert.Less(t, rulePriorities["IPAllowlistRule"], priority, "Allowlist should precede %s", name)
+		}
+	}
+
+	assert.NotEmpty(t, ruleActions["IPAllowlistRule"]["allow"])
+	assert.NotEmpty(t, ruleActions["IPBlocklistRule"]["block"])
+}
+
+func TestWebApplicationModuleInstanceStoreVolumes(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the ephemeral mappings are visible on the planned launch template
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":                "test-nvme",
+		"instance_type":               "m5d.2xlarge",
+		"instance_store_device_names": []string{"/dev/sdb", "/dev/sdc"},
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	launchTemplate, ok := plan.ResourcePlannedValuesMap["aws_launch_template.web"]
+	require.True(t, ok, "Launch template should be planned")
+
+	virtualNames := map[string]interface{}{}
+	for _, mapping := range launchTemplate.AttributeValues["block_device_mappings"].([]interface{}) {
+		mappingMap := mapping.(map[string]interface{})
+		if virtualName, ok := mappingMap["virtual_name"].(string); ok && virtualName != "" {
+			virtualNames[mappingMap["device_name"].(string)] = virtualName
+		}
+	}
+	assert.Equal(t, map[string]interface{}{
+		"/dev/sdb": "ephemeral0",
+		"/dev/sdc": "ephemeral1",
+	}, virtualNames)
+}
+
+func TestWebApplicationModuleIdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-idle-%s", uniqueID))
+
+	// No instances are needed to check the load balancer attributes
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-idle-%s", uniqueID), networking, map[string]interface{}{
+		"enable_waf":       false,
+		"min_size":         0,
+		"desired_capacity": 0,
+		"idle_timeout":     300,
+	})
+
+	assert.Equal(t, "300", terraform.Output(t, webApp.Options, "load_balancer_idle_timeout"))
+
+	attributes := getLoadBalancerAttributes(t, awsRegion, webApp.LoadBalancerArn)
+	assert.Equal(t, "300", attributes["idle_timeout.timeout_seconds"])
+}
+
+func TestWebApplicationModuleTargetGroupHealth(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the health requirements are visible on the planned target group
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name": "test-tgh",
+		"unhealthy_state_routing_min_healthy_count":      1,
+		"unhealthy_state_routing_min_healthy_percentage": "50",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	targetGroup, ok := plan.ResourcePlannedValuesMap["aws_lb_target_group.web"]
+	require.True(t, ok, "Target group should be planned")
+
+	targetGroupHealth := targetGroup.AttributeValues["target_group_health"].([]interface{})
+	require.Len(t, targetGroupHealth, 1)
+	health := targetGroupHealth[0].(map[string]interface{})
+
+	dnsFailover := health["dns_failover"].([]interface{})
+	require.Len(t, dnsFailover, 1)
+	assert.Equal(t, "1", dnsFailover[0].(map[string]interface{})["minimum_healthy_targets_count"])
+	assert.Equal(t, "50", dnsFailover[0].(map[string]interface{})["minimum_healthy_targets_percentage"])
+
+	unhealthyStateRouting := health["unhealthy_state_routing"].([]interface{})
+	require.Len(t, unhealthyStateRouting, 1)
+	assert.Equal(t, float64(1), unhealthyStateRouting[0].(map[string]interface{})["minimum_healthy_targets_count"])
+	assert.Equal(t, "50", unhealthyStateRouting[0].(map[string]interface{})["minimum_healthy_targets_percentage"])
+}
+
+func TestWebApplicationModuleSniCertificates(t *testing.T) {
+	t.Parallel()
+
+	additionalCertificateArns := []string{
+		"arn:aws:acm:us-east-1:123456789012:certificate/example-org",
+		"arn:aws:acm:us-east-1:123456789012:certificate/example-net",
+	}
+
+	// Plan only - the listener certificates are visible in the plan
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":                "test-sni",
+		"ssl_certificate_arn":         "arn:aws:acm:us-east-1:123456789012:certificate/example-com",
+		"additional_certificate_arns": additionalCertificateArns,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	// The primary certificate stays the listener default
+	httpsListener, ok := plan.ResourcePlannedValuesMap["aws_lb_listener.web_https[0]"]
+	require.True(t, ok, "HTTPS listener should be planned")
+	assert.Equal(t, "arn:aws:acm:us-east-1:123456789012:certificate/example-com", httpsListener.AttributeValues["certificate_arn"])
+
+	for i, certificateArn := range additionalCertificateArns {
+		listenerCertificate, ok := plan.ResourcePlannedValuesMap[fmt.Sprintf("aws_lb_listener_certificate.additional[%d]", i)]
+		require.True(t, ok, "Listener certificate %s should be planned", certificateArn)
+		assert.Equal(t, certificateArn, listenerCertificate.AttributeValues["certificate_arn"])
+	}
+
+	assert.Equal(t, []interface{}{
+		"arn:aws:acm:us-east-1:123456789012:certificate/example-org",
+		"arn:aws:acm:us-east-1:123456789012:certificate/example-net",
+	}, plan.RawPlan.OutputChanges["additional_certificate_arns"].After)
+}
+
+func TestWebApplicationModuleDeletionProtectionDefaults(t *testing.T) {
+	t.Parallel()
+
+	// Deletion protection is resolved from the environment when not set explicitly
+	testCases := []struct {
+		environment              string
+		expectDeletionProtection bool
+	}{
+		{environment: "production", expectDeletionProtection: true},
+		{environment: "staging", expectDeletionProtection: false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.environment, func(t *testing.T) {
+			t.Parallel()
+
+			// Plan only - the load balancer attributes are visible on the planned resource
+			webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+				"project_name":               "test-dp",
+				"environment":                tc.environment,
+				"drop_invalid_header_fields": true,
+				"desync_mitigation_mode":     "strictest",
+			})
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+			loadBalancer, ok := plan.ResourcePlannedValuesMap["aws_lb.web"]
+			require.True(t, ok, "Load balancer should be planned")
+			assert.Equal(t, tc.expectDeletionProtection, loadBalancer.AttributeValues["enable_deletion_protection"])
+			assert.Equal(t, true, loadBalancer.AttributeValues["drop_invalid_header_fields"])
+			assert.Equal(t, "strictest", loadBalancer.AttributeValues["desync_mitigation_mode"])
+
+			deletionProtection, ok := plan.RawPlan.OutputChanges["load_balancer_deletion_protection_enabled"]
+			require.True(t, ok, "Deletion protection output should be planned")
+			assert.Equal(t, tc.expectDeletionProtection, deletionProtection.After)
+		})
+	}
+}
+
+func TestWebApplicationModuleListenerRulePriorityAutoAssignment(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - resolved priorities are known at plan time
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":                "test-lrp",
+		"listener_rule_priority_base": 200,
+		"listener_rules": []map[string]interface{}{
+			{"path_patterns": []string{"/api/*"}},
+			{"path_patterns": []string{"/admin/*"}},
+			{"host_headers": []string{"static.example.com"}},
+		},
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	// Each rule gets the next sequential priority from the base, in list order
+	priorities, ok := plan.RawPlan.OutputChanges["listener_rule_priorities"]
+	require.True(t, ok, "listener_rule_priorities output should be planned")
+	assert.Equal(t, []interface{}{float64(200), float64(201), float64(202)}, priorities.After)
+
+	for _, priority := range []int{200, 201, 202} {
+		rule, ok := plan.ResourcePlannedValuesMap[fmt.Sprintf(`aws_lb_listener_rule.web["%d"]`, priority)]
+		require.True(t, ok, "listener rule with priority %d should be planned", priority)
+		assert.EqualValues(t, priority, rule.AttributeValues["priority"])
+	}
+}
+
+func TestWebApplicationModuleMetricsTargetGroup(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-metrics-%s", uniqueID))
+
+	// A secondary metrics port served alongside the application
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-metrics-%s", uniqueID), networking, map[string]interface{}{
+		"enable_waf": false,
+		"additional_listeners": []map[string]interface{}{
+			{
+				"port":              9090,
+				"target_port":       9090,
+				"protocol":          "HTTP",
+				"health_check_path": "/metrics",
+			},
+		},
+	})
+
+	additionalTargetGroupArns := terraform.OutputMap(t, webApp.Options, "additional_target_group_arns")
+	metricsTargetGroupArn, ok := additionalTargetGroupArns["9090"]
+	require.True(t, ok, "metrics target group should be keyed by its listener port")
+	require.NotEmpty(t, metricsTargetGroupArn)
+
+	// The ASG registers instances with both the main and the metrics target group
+	asgName := terraform.Output(t, webApp.Options, "autoscaling_group_name")
+	mainTargetGroupArn := terraform.Output(t, webApp.Options, "target_group_arn")
+	assert.ElementsMatch(t, []string{mainTargetGroupArn, metricsTargetGroupArn}, getAsgTargetGroupArns(t, awsRegion, asgName))
+}
+
+func TestWebApplicationModuleSharedAsgTargetGroups(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := strings.ToLower(random.UniqueId())
+
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-asgtg-%s", uniqueID))
+
+	// Two target groups behind a separate load balancer. Cleanups run in reverse order,
+	// so the web application detaches from them before they are destroyed.
+	targetGroupOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "fixtures/target-groups",
+
+		Vars: map[string]interface{}{
+			"name_prefix":       fmt.Sprintf("asgtg-%s", uniqueID),
+			"vpc_id":            networking.VpcID,
+			"subnet_ids":        networking.PublicSubnetIDs,
+			"security_group_id": networking.WebSecurityGroupID,
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+	t.Cleanup(func() {
+		terraform.Destroy(t, targetGroupOptions)
+	})
+	terraform.InitAndApply(t, targetGroupOptions)
+
+	sharedTargetGroupArns := terraform.OutputMap(t, targetGroupOptions, "target_group_arns")
+	require.Len(t, sharedTargetGroupArns, 2)
+
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-asgtg-%s", uniqueID), networking, map[string]interface{}{
+		"enable_waf":            false,
+		"asg_target_group_arns": sharedTargetGroupArns,
+	})
+
+	// Both shared target groups are attached alongside the module's own
+	expectedArns := []string{webApp.TargetGroupArn, sharedTargetGroupArns["admin"], sharedTargetGroupArns["api"]}
+	assert.ElementsMatch(t, expectedArns, terraform.OutputList(t, webApp.Options, "asg_target_group_arns"))
+	assert.ElementsMatch(t, expectedArns, getAsgTargetGroupArns(t, awsRegion, webApp.AutoscalingGroupName))
+
+	// Removing the first entry leaves the remaining shared target group attached
+	webApp.Options.Vars["asg_target_group_arns"] = map[string]string{"api": sharedTargetGroupArns["api"]}
+	terraform.Apply(t, webApp.Options)
+
+	expectedArns = []string{webApp.TargetGroupArn, sharedTargetGroupArns["api"]}
+	assert.ElementsMatch(t, expectedArns, terraform.OutputList(t, webApp.Options, "asg_target_group_arns"))
+	assert.ElementsMatch(t, expectedArns, getAsgTargetGroupArns(t, awsRegion, webApp.AutoscalingGroupName))
+}
+
+func TestWebApplicationModuleTerminationPolicies(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the termination policy order is visible in the plan
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":           "test-term",
+		"enable_mixed_instances": true,
+		"capacity_rebalance":     true,
+		"termination_policies":   []string{"OldestInstance", "Default"},
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	asg, ok := plan.ResourcePlannedValuesMap["aws_autoscaling_group.web"]
+	require.True(t, ok, "Auto Scaling Group should be planned")
+
+	// Policies are evaluated in the order given, so the oldest instances drain first
+	assert.Equal(t, []interface{}{"OldestInstance", "Default"}, asg.AttributeValues["termination_policies"])
+	assert.Equal(t, true, asg.AttributeValues["capacity_rebalance"])
+
+	terminationPolicies, ok := plan.RawPlan.OutputChanges["termination_policies"]
+	require.True(t, ok, "termination_policies output should be planned")
+	assert.Equal(t, []interface{}{"OldestInstance", "Default"}, terminationPolicies.After)
+}
+
+func TestWebApplicationModuleSecretHeaderRule(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the rule statement is visible on the planned Web ACL
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":          "test-secret",
+		"enable_waf":            true,
+		"require_secret_header": true,
+		"secret_header_name":    "X-Origin-Verify",
+		"secret_header_value":   "test-origin-secret",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	webACL, ok := plan.ResourcePlannedValuesMap["aws_wafv2_web_acl.web_acl[0]"]
+	require.True(t, ok, "WAF Web ACL should be planned")
+
+	var secretHeaderRule map[string]interface{}
+	for _, rule := range webACL.AttributeValues["rule"].([]interface{}) {
+		if ruleMap := rule.(map[string]interface{}); ruleMap["name"] == "SecretHeaderRule" {
+			secretHeaderRule = ruleMap
+		}
+	}
+	require.NotNil(t, secretHeaderRule, "Secret header rule should be planned")
+	assert.Equal(t, float64(6), secretHeaderRule["priority"])
+
+	// Requests are blocked unless they carry the exact header value
+	actions := secretHeaderRule["action"].([]interface{})
+	require.Len(t, actions, 1)
+	assert.NotEmpty(t, actions[0].(map[string]interface{})["block"])
+
+	statement := secretHeaderRule["statement"].([]interface{})[0].(map[string]interface{})
+	notStatement := statement["not_statement"].([]interface{})[0].(map[string]interface{})
+	innerStatement := notStatement["statement"].([]interface{})[0].(map[string]interface{})
+	byteMatch := innerStatement["byte_match_statement"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "test-origin-secret", byteMatch["search_string"])
+	assert.Equal(t, "EXACTLY", byteMatch["positional_constraint"])
+
+	fieldToMatch := byteMatch["field_to_match"].([]interface{})[0].(map[string]interface{})
+	singleHeader := fieldToMatch["single_header"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "x-origin-verify", singleHeader["name"])
+}
+
+func TestWebApplicationModuleWafRuleOrder(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - the summary is computed from configuration
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":          "test-waforder",
+		"enable_waf":            true,
+		"waf_ip_allowlist":      []string{"198.51.100.0/24"},
+		"waf_ip_blocklist":      []string{"203.0.113.0/24"},
+		"enable_geo_blocking":   true,
+		"blocked_countries":     []string{"CN"},
+		"require_secret_header": true,
+		"secret_header_value":   "test-origin-secret",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	summary, ok := plan.RawPlan.OutputChanges["waf_rules_summary"]
+	require.True(t, ok, "waf_rules_summary output should be planned")
+	summaryJSON, err := json.Marshal(summary.After)
+	require.NoError(t, err)
+
+	// Allowlisted IPs bypass everything; geo blocking runs after rate limiting
+	helpers.AssertWafRuleOrder(t, string(summaryJSON), []string{
+		"IPAllowlistRule",
+		"AWSManagedRulesCommonRuleSet",
+		"AWSManagedRulesKnownBadInputsRuleSet",
+		"RateLimitRule",
+		"GeoBlockingRule",
+		"IPBlocklistRule",
+		"SecretHeaderRule",
+	})
+}
+
+func TestWebApplicationModuleEndpointService(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Create minimal networking setup
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-pl-%s", uniqueID))
+
+	// Expose the application to our own account over PrivateLink
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-pl-%s", uniqueID), networking, map[string]interface{}{
+		"application_name":                    "test-app-pl",
+		"enable_waf":                          false,
+		"nlb_mode":                            true,
+		"enable_endpoint_service":             true,
+		"endpoint_service_allowed_principals": []string{fmt.Sprintf("arn:aws:iam::%s:root", aws.GetAccountId(t))},
+	})
+
+	nlbArn := terraform.Output(t, webApp.Options, "nlb_arn")
+	assert.Contains(t, nlbArn, ":loadbalancer/net/")
+
+	// Consumers create interface endpoints to com.amazonaws.vpce.<region>.vpce-svc-<id>
+	serviceName := terraform.Output(t, webApp.Options, "endpoint_service_name")
+	serviceID := terraform.Output(t, webApp.Options, "endpoint_service_id")
+	require.NotEmpty(t, serviceID)
+	assert.Equal(t, fmt.Sprintf("com.amazonaws.vpce.%s.%s", awsRegion, serviceID), serviceName)
+}
+
+func TestWebApplicationModuleCPUCredits(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name         string
+		instanceType string
+		expected     []interface{}
+	}{
+		{
+			name:         "burstable",
+			instanceType: "t3.micro",
+			expected:     []interface{}{map[string]interface{}{"cpu_credits": "unlimited"}},
+		},
+		{
+			// Credit specification is rejected for non-burstable families
+			name:         "non_burstable",
+			instanceType: "m5.large",
+			expected:     []interface{}{},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Plan only - the credit specification is visible on the planned launch template
+			webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+				"project_name":  "test-credits",
+				"instance_type": tc.instanceType,
+				"cpu_credits":   "unlimited",
+			})
+
+			plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+			launchTemplate, ok := plan.ResourcePlannedValuesMap["aws_launch_template.web"]
+			require.True(t, ok, "Launch template should be planned")
+			assert.Equal(t, tc.expected, launchTemplate.AttributeValues["credit_specification"])
+		})
+	}
+}
+
+func TestWebApplicationModulePlacementGroup(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - spread avoids the single-AZ subnet lookup a cluster group needs
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":           "test-placement",
+		"create_placement_group": true,
+		"placement_group_name":   "test-placement-pg",
+		"placement_strategy":     "spread",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	placementGroup, ok := plan.ResourcePlannedValuesMap["aws_placement_group.web[0]"]
+	require.True(t, ok, "Placement group should be planned")
+	assert.Equal(t, "test-placement-pg", placementGroup.AttributeValues["name"])
+	assert.Equal(t, "spread", placementGroup.AttributeValues["strategy"])
+
+	launchTemplate, ok := plan.ResourcePlannedValuesMap["aws_launch_template.web"]
+	require.True(t, ok, "Launch template should be planned")
+	placement := launchTemplate.AttributeValues["placement"].([]interface{})
+	require.Len(t, placement, 1)
+	assert.Equal(t, "test-placement-pg", placement[0].(map[string]interface{})["group_name"])
+
+	assert.Equal(t, "test-placement-pg", plan.RawPlan.OutputChanges["placement_group_name"].After)
+}
+
+func TestWebApplicationModuleExistingPlacementGroup(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - an existing spread group is used as is, without the single-AZ subnet lookup
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":         "test-existing-pg",
+		"placement_group_name": "shared-spread-pg",
+		"placement_strategy":   "spread",
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	_, ok := plan.ResourcePlannedValuesMap["aws_placement_group.web[0]"]
+	assert.False(t, ok, "No placement group should be created for an existing one")
+
+	launchTemplate, ok := plan.ResourcePlannedValuesMap["aws_launch_template.web"]
+	require.True(t, ok, "Launch template should be planned")
+	placement := launchTemplate.AttributeValues["placement"].([]interface{})
+	require.Len(t, placement, 1)
+	assert.Equal(t, "shared-spread-pg", placement[0].(map[string]interface{})["group_name"])
+}
+
+func TestWebApplicationModuleCPUAlarmDatapoints(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - 3 of 5 one-minute datapoints must breach before scaling
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":              "test-alarmdp",
+		"alarm_period":              60,
+		"alarm_evaluation_periods":  5,
+		"alarm_datapoints_to_alarm": 3,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	for _, address := range []string{"aws_cloudwatch_metric_alarm.cpu_high[0]", "aws_cloudwatch_metric_alarm.cpu_low[0]"} {
+		alarm, ok := plan.ResourcePlannedValuesMap[address]
+		require.True(t, ok, "%s should be planned", address)
+		assert.Equal(t, float64(5), alarm.AttributeValues["evaluation_periods"], address)
+		assert.Equal(t, float64(3), alarm.AttributeValues["datapoints_to_alarm"], address)
+		assert.Equal(t, float64(60), alarm.AttributeValues["period"], address)
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"period":              float64(60),
+		"evaluation_periods":  float64(5),
+		"datapoints_to_alarm": float64(3),
+	}, plan.RawPlan.OutputChanges["cpu_alarm_evaluation"].After)
+}
+
+func TestWebApplicationModuleCPUAlarmDatapointsDefault(t *testing.T) {
+	t.Parallel()
+
+	// Plan only - without alarm_datapoints_to_alarm the alarms leave the setting unset
+	webAppOptions := helpers.WebApplicationPlanOptions(t, map[string]interface{}{
+		"project_name":             "test-alarmdef",
+		"alarm_evaluation_periods": 4,
+	})
+
+	plan := terraform.InitAndPlanAndShowWithStruct(t, webAppOptions)
+
+	for _, address := range []string{"aws_cloudwatch_metric_alarm.cpu_high[0]", "aws_cloudwatch_metric_alarm.cpu_low[0]"} {
+		alarm, ok := plan.ResourcePlannedValuesMap[address]
+		require.True(t, ok, "%s should be planned", address)
+		assert.Nil(t, alarm.AttributeValues["datapoints_to_alarm"], address)
+	}
+
+	// The output reports the effective value CloudWatch applies
+	evaluation := plan.RawPlan.OutputChanges["cpu_alarm_evaluation"].After.(map[string]interface{})
+	assert.Equal(t, float64(4), evaluation["datapoints_to_alarm"])
+}
+
+// defaultMaxMonthlyCostUSD is the cost ceiling for the default web application configuration,
+// overridable with TEST_MAX_MONTHLY_COST
+const defaultMaxMonthlyCostUSD = 100.0
+
+func TestWebApplicationCostGuardrail(t *testing.T) {
+	t.Parallel()
+
+	maxMonthlyCost := defaultMaxMonthlyCostUSD
+	if value := os.Getenv("TEST_MAX_MONTHLY_COST"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		require.NoError(t, err, "TEST_MAX_MONTHLY_COST must be a number of USD")
+		maxMonthlyCost = parsed
+	}
+
+	// Defaults only (t3.micro instances), so an expensive default fails before anything is applied
+	helpers.AssertMonthlyCostBelow(t, "../terraform/modules/web-application", map[string]interface{}{
+		"project_name":          "test-cost",
+		"environment":           "staging",
+		"application_name":      "test-app",
+		"vpc_id":                "vpc-123",
+		"subnet_ids":            []string{"subnet-123"},
+		"public_subnet_ids":     []string{"subnet-456"},
+		"security_group_id":     "sg-123",
+		"alb_security_group_id": "sg-456",
+		"instance_profile_name": "test-profile",
+	}, maxMonthlyCost)
+}
+
+func TestWebApplicationModuleDualstackLoadBalancer(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Dual-stack networking gives every public subnet an IPv6 CIDR block for the load balancer
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-dual-%s", uniqueID), map[string]interface{}{
+		"ip_address_type": "dualstack",
+	})
+
+	// The module creates the ALB security group so it can open the listeners to IPv6 clients
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-dual-%s", uniqueID), networking, map[string]interface{}{
+		"alb_security_group_id":        nil,
+		"enable_waf":                   false,
+		"min_size":                     0,
+		"desired_capacity":             0,
+		"ip_address_type":              "dualstack",
+		"alb_ingress_ipv6_cidr_blocks": []string{"::/0"},
+	})
+
+	assert.Equal(t, "dualstack", terraform.Output(t, webApp.Options, "load_balancer_ip_address_type"))
+
+	// Verify the address type reported by the load balancer itself
+	assert.Equal(t, "dualstack", getLoadBalancerIPAddressType(t, awsRegion, webApp.LoadBalancerArn))
+}
+
+func TestWebApplicationModuleWithPrefixListIngress(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Create minimal networking setup
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-pl-%s", uniqueID))
+
+	// Use the AWS-managed S3 prefix list as a stand-in for an office range prefix list
+	prefixListID := getManagedPrefixListID(t, awsRegion, fmt.Sprintf("com.amazonaws.%s.s3", awsRegion))
+
+	// Let the module create the ALB security group
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-pl-%s", uniqueID), networking, map[string]interface{}{
+		"application_name":            "test-app-pl",
+		"alb_security_group_id":       nil,
+		"enable_waf":                  false,
+		"alb_ingress_cidr_blocks":     []string{},
+		"alb_ingress_prefix_list_ids": []string{prefixListID},
+	})
+
+	// Verify the ALB security group ingress references the prefix list
+	albSGID := terraform.Output(t, webApp.Options, "alb_security_group_id")
+	assert.NotEmpty(t, albSGID)
+	assert.Contains(t, getSecurityGroupIngressPrefixListIDs(t, awsRegion, albSGID), prefixListID)
+}
+
+func TestWebApplicationModuleWithDNSRecords(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := strings.ToLower(random.UniqueId())
+
+	// Create minimal networking setup
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-dns-%s", uniqueID))
+
+	// A private hosted zone avoids needing a registered domain. Cleanups run in reverse,
+	// so the zone is deleted after the web application's records and before the VPC.
+	zoneName := fmt.Sprintf("test-dns-%s.internal", uniqueID)
+	zoneID := createPrivateHostedZone(t, awsRegion, zoneName, networking.VpcID)
+	t.Cleanup(func() {
+		deleteHostedZone(t, awsRegion, zoneID)
+	})
+
+	appRecord := fmt.Sprintf("app.%s", zoneName)
+
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-dns-%s", uniqueID), networking, map[string]interface{}{
+		"application_name": "test-app-dns",
+		"enable_waf":       false,
+		"route53_zone_id":  zoneID,
+		"dns_records":      []string{zoneName, appRecord},
+	})
+
+	// Verify both the apex and subdomain records are created
+	dnsRecordFQDNs := terraform.OutputList(t, webApp.Options, "dns_record_fqdns")
+	assert.ElementsMatch(t, []string{zoneName, appRecord}, dnsRecordFQDNs)
+
+	// Verify the alias targets match the ALB
+	albDNS := strings.ToLower(terraform.Output(t, webApp.Options, "load_balancer_dns_name"))
+	for _, record := range []string{zoneName, appRecord} {
+		for _, recordType := range []string{"A", "AAAA"} {
+			aliasTarget := getRoute53AliasTargetDNSName(t, awsRegion, zoneID, record, recordType)
+			assert.True(t, strings.HasSuffix(aliasTarget, albDNS), "alias target %s should point at ALB %s", aliasTarget, albDNS)
+		}
+	}
+}
+
+func TestWebApplicationModuleWithRequestCountScaling(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := random.UniqueId()
+
+	// Create minimal networking setup
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-rcs-%s", uniqueID))
+
+	// Test web application scaling on ALB request count
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-rcs-%s", uniqueID), networking, map[string]interface{}{
+		"application_name":             "test-app-rcs",
+		"enable_waf":                   false,
+		"scaling_metric":               "alb_request_count",
+		"target_requests_per_instance": 500,
+	})
+
+	assert.Equal(t, "ALBRequestCountPerTarget", terraform.Output(t, webApp.Options, "scaling_metric_name"))
+
+	// CPU step scaling resources are not created; null outputs are left out of the state entirely
+	outputs := terraform.OutputAll(t, webApp.Options)
+	assert.NotContains(t, outputs, "cpu_high_alarm_arn")
+	assert.NotContains(t, outputs, "scale_up_policy_arn")
+
+	// Verify the request count policy references the module's target group
+	asgName := terraform.Output(t, webApp.Options, "autoscaling_group_name")
+	policyArn := terraform.Output(t, webApp.Options, "target_tracking_policy_arn")
+	targetGroupArn := terraform.Output(t, webApp.Options, "target_group_arn")
+	require.NotEmpty(t, policyArn)
+
+	resourceLabel := getTargetTrackingResourceLabel(t, awsRegion, asgName, policyArn)
+	targetGroupIndex := strings.Index(resourceLabel, "targetgroup/")
+	require.GreaterOrEqual(t, targetGroupIndex, 0, "resource label %s should reference a target group", resourceLabel)
+	assert.True(t, strings.HasSuffix(targetGroupArn, ":"+resourceLabel[targetGroupIndex:]), "resource label %s should reference target group %s", resourceLabel, targetGroupArn)
+}
+
+func TestWebApplicationModuleLogSubscriptionFilter(t *testing.T) {
+	t.Parallel()
+
+	// Skip if AWS credentials are not configured
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		t.Skip("Skipping test: AWS credentials not configured")
+	}
+
+	awsRegion := aws.GetRandomStableRegion(t, nil, nil)
+	uniqueID := strings.ToLower(random.UniqueId())
+
+	networking := helpers.DeployNetworking(t, awsRegion, fmt.Sprintf("test-logsub-%s", uniqueID))
+
+	// Kinesis stream standing in for the central log aggregator. Cleanups run in reverse
+	// order, so the subscription filter is removed before the stream is destroyed.
+	destinationOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+		TerraformDir: "fixtures/log-destination",
+
+		Vars: map[string]interface{}{
+			"name_prefix": fmt.Sprintf("test-logsub-%s", uniqueID),
+		},
+
+		EnvVars: map[string]string{
+			"AWS_DEFAULT_REGION": awsRegion,
+		},
+	})
+	t.Cleanup(func() {
+		terraform.Destroy(t, destinationOptions)
+	})
+	terraform.InitAndApply(t, destinationOptions)
+
+	streamArn := terraform.Output(t, destinationOptions, "stream_arn")
+	roleArn := terraform.Output(t, destinationOptions, "role_arn")
+	filterPattern := "?ERROR ?WARN"
+
+	webApp := helpers.DeployWebApplication(t, awsRegion, fmt.Sprintf("test-logsub-%s", uniqueID), networking, map[string]interface{}{
+		"enable_waf":                       false,
+		"enable_cloudwatch_agent":          true,
+		"enable_instance_logs":             true,
+		"log_subscription_destination_arn": streamArn,
+		"log_subscription_role_arn":        roleArn,
+		"log_subscription_filter_pattern":  filterPattern,
+	})
+
+	logGroupName := terraform.Output(t, webApp.Options, "instance_log_group_name")
+	filterName := terraform.Output(t, webApp.Options, "log_subscription_filter_name")
+	require.NotEmpty(t, logGroupName)
+	require.NotEmpty(t, filterName)
+
+	// The filter forwards the configured pattern to the provided stream through the role
+	filter := getLogSubscriptionFilter(t, awsRegion, logGroupName, filterName)
+	assert.Equal(t, streamArn, awssdk.StringValue(filter.DestinationArn))
+	assert.Equal(t, filterPattern, awssdk.StringValue(filter.FilterPattern))
+	assert.Equal(t, roleArn, awssdk.StringValue(filter.RoleArn))
+}